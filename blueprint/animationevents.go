@@ -0,0 +1,48 @@
+package blueprint
+
+import (
+	"github.com/TheBitDrifter/bappa/blueprint/client"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// AnimationEventSystem fires the frame events of every active sprite animation
+// as client.AnimationEventFired events on the scene storage's event bus
+//
+// It only needs sprite data, not rendering, so it runs as a core system on the server as well
+// and keeps animation timing (Config.StartTick) authoritative on both sides
+type AnimationEventSystem struct{}
+
+func (AnimationEventSystem) Run(scene Scene, dt float64) error {
+	currentTick := scene.CurrentTick()
+
+	cursor := scene.NewCursor(Queries.SpriteBundle)
+	for range cursor.Next() {
+		bundle := client.Components.SpriteBundle.GetFromCursor(cursor)
+		var entity warehouse.Entity
+		for i := range bundle.Blueprints {
+			bp := &bundle.Blueprints[i]
+			if !bp.Config.Active || !bp.HasAnimations() {
+				continue
+			}
+			anim := bp.ActiveAnimation()
+			if len(anim.Events) == 0 {
+				continue
+			}
+			if entity == nil {
+				var err error
+				if entity, err = cursor.CurrentEntity(); err != nil {
+					return err
+				}
+			}
+			bp.AdvanceEvents(currentTick, func(event client.AnimationEvent) {
+				warehouse.Emit(scene.Storage(), client.AnimationEventFired{
+					Entity:         entity,
+					BlueprintIndex: i,
+					Animation:      anim.Name,
+					AnimationEvent: event,
+				})
+			})
+		}
+	}
+	return nil
+}
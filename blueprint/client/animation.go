@@ -3,11 +3,14 @@ package client
 import (
 	"embed"
 	"encoding/json"
+	"fmt"
+	"image"
 	"log"
 	"os"
 
 	"github.com/TheBitDrifter/bappa/blueprint/vector"
 	"github.com/TheBitDrifter/bappa/environment"
+	"github.com/TheBitDrifter/bappa/warehouse"
 )
 
 type AnimationCollection struct {
@@ -15,6 +18,7 @@ type AnimationCollection struct {
 }
 
 // AnimationData contains configuration for sprite-based animations
+// It is shared through an AnimationSet, so playback state lives on the SpriteBlueprint
 type AnimationData struct {
 	// Name is the animation name
 	Name string `json:"name"`
@@ -37,22 +41,161 @@ type AnimationData struct {
 	// Speed controls how quickly the animation plays (how many ticks per frame)
 	Speed int `json:"speed"`
 
-	// StartTick defines when the animation begins
+	// StartTick is only read by the deprecated IsFinished
+	//
+	// Deprecated: playback state lives on SpriteBlueprint.Config.StartTick, nothing sets this field
 	StartTick int `json:"-"`
 
 	// Freeze indicates whether the animation should stay on the last frame once finished
 	Freeze bool `json:"freeze"`
+
+	// Frames optionally lists explicit source rects and per-frame durations (packed sheets, Aseprite exports)
+	// When set it overrides RowIndex, FrameWidth, FrameCount and Speed for frame lookup and timing
+	Frames []AnimationFrame `json:"frames,omitempty"`
+
+	// Events fire when playback reaches their frame, once per loop
+	Events []AnimationEvent `json:"events,omitempty"`
 }
 
-// Only works with freeze true
+// AnimationEvent is tagged to a frame of an animation
+type AnimationEvent struct {
+	// Frame is the index of the frame that triggers the event
+	Frame int `json:"frame"`
+	// Name identifies the event for gameplay systems (e.g. "hitbox_on", "footstep")
+	Name string `json:"name,omitempty"`
+	// Sound is the path of a sound in the entity's SoundBundle to play
+	Sound string `json:"sound,omitempty"`
+}
+
+// AnimationFrame is a single frame cut from an arbitrary rect of the sprite sheet
+type AnimationFrame struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+
+	// Duration is how many ticks the frame is shown
+	Duration int `json:"duration"`
+
+	// Offset is the frame's displacement within the untrimmed sprite (trimmed exports)
+	Offset vector.Two `json:"offset"`
+}
+
+// AnimationEventFired is emitted on the storage's event bus when an animation reaches a tagged frame
+type AnimationEventFired struct {
+	Entity         warehouse.Entity
+	BlueprintIndex int
+	Animation      string
+	AnimationEvent
+}
+
+// Length returns the number of frames in the animation
+func (a AnimationData) Length() int {
+	if len(a.Frames) > 0 {
+		return len(a.Frames)
+	}
+	return a.FrameCount
+}
+
+// Duration returns how many ticks one playthrough of the animation takes
+func (a AnimationData) Duration() int {
+	if len(a.Frames) == 0 {
+		return a.FrameCount * a.Speed
+	}
+	total := 0
+	for _, frame := range a.Frames {
+		total += max(frame.Duration, 1)
+	}
+	return total
+}
+
+// FrameAt returns the index of the frame shown the given number of ticks into the animation, looping
+func (a AnimationData) FrameAt(elapsed int) int {
+	duration := a.Duration()
+	if duration <= 0 || elapsed < 0 {
+		return 0
+	}
+	elapsed %= duration
+	if len(a.Frames) == 0 {
+		return elapsed / a.Speed
+	}
+	for i, frame := range a.Frames {
+		elapsed -= max(frame.Duration, 1)
+		if elapsed < 0 {
+			return i
+		}
+	}
+	return len(a.Frames) - 1
+}
+
+// FrameStart returns how many ticks into the animation a frame begins
+func (a AnimationData) FrameStart(index int) int {
+	if len(a.Frames) == 0 {
+		return index * a.Speed
+	}
+	start := 0
+	for _, frame := range a.Frames[:min(index, len(a.Frames))] {
+		start += max(frame.Duration, 1)
+	}
+	return start
+}
+
+// FrameRect returns the source rect of a frame in the sprite sheet
+func (a AnimationData) FrameRect(index int) image.Rectangle {
+	if index >= 0 && index < len(a.Frames) {
+		f := a.Frames[index]
+		return image.Rect(f.X, f.Y, f.X+f.W, f.Y+f.H)
+	}
+	x, y := index*a.FrameWidth, a.RowIndex*a.FrameHeight
+	return image.Rect(x, y, x+a.FrameWidth, y+a.FrameHeight)
+}
+
+// FrameOffset returns the trim offset of a frame, zero for uniform rows
+func (a AnimationData) FrameOffset(index int) vector.Two {
+	if index >= 0 && index < len(a.Frames) {
+		return a.Frames[index].Offset
+	}
+	return vector.Two{}
+}
+
+// validateEvents reports events tagged to a frame the animation does not have, which would never fire
+func (a AnimationData) validateEvents() error {
+	for _, event := range a.Events {
+		if event.Frame < 0 || event.Frame >= a.Length() {
+			return fmt.Errorf("animation %s: event %q is on frame %d, want 0-%d", a.Name, event.Name, event.Frame, a.Length()-1)
+		}
+	}
+	return nil
+}
+
+// validate checks the events of every animation in the collection
+func (c *AnimationCollection) validate() error {
+	for _, anim := range c.Animations {
+		if err := anim.validateEvents(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsFinished reports whether the animation has played through since its StartTick field
+//
+// Deprecated: AnimationData is shared and no longer carries playback state, use IsFinishedFrom with
+// SpriteBlueprint.Config.StartTick
 func (a AnimationData) IsFinished(currentTick int) bool {
-	duration := a.FrameCount * a.Speed
+	return a.IsFinishedFrom(a.StartTick, currentTick)
+}
+
+// IsFinishedFrom reports whether an animation started at startTick has played through by currentTick
+// Only works with freeze true
+func (a AnimationData) IsFinishedFrom(startTick, currentTick int) bool {
+	duration := a.Duration()
 
 	if duration <= 0 {
 		return true
 	}
 
-	return currentTick >= a.StartTick+duration
+	return currentTick >= startTick+duration
 }
 
 func LoadAnimationsFromJSON(filename, path string, fs embed.FS) (*AnimationCollection, error) {
@@ -67,6 +210,9 @@ func LoadAnimationsFromJSON(filename, path string, fs embed.FS) (*AnimationColle
 		if err != nil {
 			return nil, err
 		}
+		if err := collection.validate(); err != nil {
+			return nil, err
+		}
 		return collection, nil
 	}
 
@@ -79,6 +225,9 @@ func LoadAnimationsFromJSON(filename, path string, fs embed.FS) (*AnimationColle
 	if err != nil {
 		log.Fatal(err)
 	}
+	if err := collection.validate(); err != nil {
+		return nil, err
+	}
 
 	return collection, nil
 }
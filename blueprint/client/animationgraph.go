@@ -0,0 +1,308 @@
+package client
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/environment"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// AnimationGraphCollection is the JSON format of LoadAnimationGraphsFromJSON
+type AnimationGraphCollection struct {
+	Graphs []AnimationGraph `json:"graphs"`
+}
+
+// AnimationGraph declares which animation a sprite plays as a state machine
+//
+// Each state plays an animation (by AnimationData name). Transitions move between states when all
+// of their conditions hold; when several match, the target with the highest priority wins
+type AnimationGraph struct {
+	Name        string                `json:"name"`
+	Initial     string                `json:"initial"`
+	States      []AnimationGraphState `json:"states"`
+	Transitions []AnimationTransition `json:"transitions"`
+
+	states map[string]int
+}
+
+// AnimationGraphState maps a graph state to an animation
+type AnimationGraphState struct {
+	Name      string `json:"name"`
+	Animation string `json:"animation"`
+
+	// Priority decides between matching transitions and which states may interrupt a Once state
+	Priority int `json:"priority"`
+
+	// Once plays the animation a single time, then returns to Return
+	// (or to the state that was active before it) unless a higher priority state interrupts it first
+	Once   bool   `json:"once"`
+	Return string `json:"return,omitempty"`
+}
+
+// AnimationTransition moves from one state to another when all conditions hold
+// An empty From (or "*") matches every state
+type AnimationTransition struct {
+	From       string               `json:"from,omitempty"`
+	To         string               `json:"to"`
+	Conditions []AnimationCondition `json:"conditions,omitempty"`
+}
+
+// AnimationCondition tests either a component field or a buffered action
+//
+// Field conditions compare the numeric value at a warehouse field path (e.g. "Dynamics.Vel.X")
+// against Value using Op ("==", "!=", "<", "<=", ">", ">="). Booleans read as 0 and 1
+//
+// Action conditions hold when the entity's ActionBuffer has the named action stamped within
+// the last Window ticks
+type AnimationCondition struct {
+	Field string  `json:"field,omitempty"`
+	Op    string  `json:"op,omitempty"`
+	Value float64 `json:"value,omitempty"`
+	Abs   bool    `json:"abs,omitempty"`
+
+	Action string `json:"action,omitempty"`
+	Window int    `json:"window,omitempty"`
+
+	Not bool `json:"not,omitempty"`
+}
+
+// AnimationMachine is the per entity state of an AnimationGraph
+type AnimationMachine struct {
+	// Graph is the name of a graph in AnimationGraphRegistry
+	Graph string
+	// BlueprintIndex selects the sprite in the entity's SpriteBundle the graph drives
+	BlueprintIndex int
+
+	State       string
+	Return      string
+	EnteredTick int
+}
+
+// NewAnimationMachine creates a machine for a registered graph, driving the bundle's first sprite
+func NewAnimationMachine(graph string) AnimationMachine {
+	return AnimationMachine{Graph: graph}
+}
+
+// AnimationGraphRegistry holds the graphs available to AnimationMachine components
+var AnimationGraphRegistry map[string]*AnimationGraph = map[string]*AnimationGraph{}
+
+// RegisterAnimationGraphs validates the graphs and adds them to AnimationGraphRegistry
+func RegisterAnimationGraphs(graphs ...AnimationGraph) error {
+	for i := range graphs {
+		graph := graphs[i]
+		if err := graph.compile(); err != nil {
+			return err
+		}
+		AnimationGraphRegistry[graph.Name] = &graph
+	}
+	return nil
+}
+
+// LoadAnimationGraphsFromJSON reads graphs the same way LoadAnimationsFromJSON reads animations
+func LoadAnimationGraphsFromJSON(filename, path string, fs embed.FS) (*AnimationGraphCollection, error) {
+	var (
+		jsonDataBytes []byte
+		err           error
+	)
+	if environment.IsProd() || environment.IsWASM() {
+		jsonDataBytes, err = fs.ReadFile(filename)
+	} else {
+		jsonDataBytes, err = os.ReadFile(path + filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read animation graphs %s: %w", filename, err)
+	}
+
+	collection := &AnimationGraphCollection{}
+	if err := json.Unmarshal(jsonDataBytes, collection); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal animation graphs %s: %w", filename, err)
+	}
+	return collection, nil
+}
+
+// conditionOps are the comparisons conditions may use
+var conditionOps = map[string]func(a, b float64) bool{
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+}
+
+// compile indexes the states and checks every reference
+func (g *AnimationGraph) compile() error {
+	if g.Name == "" {
+		return fmt.Errorf("animation graph is missing a name")
+	}
+	g.states = make(map[string]int, len(g.States))
+	for i, state := range g.States {
+		if _, dup := g.states[state.Name]; dup {
+			return fmt.Errorf("animation graph %s: duplicate state %q", g.Name, state.Name)
+		}
+		if state.Animation == "" {
+			return fmt.Errorf("animation graph %s: state %s has no animation", g.Name, state.Name)
+		}
+		g.states[state.Name] = i
+	}
+	if _, ok := g.states[g.Initial]; !ok {
+		return fmt.Errorf("animation graph %s: unknown initial state %q", g.Name, g.Initial)
+	}
+	for _, state := range g.States {
+		if _, ok := g.states[state.Return]; state.Return != "" && !ok {
+			return fmt.Errorf("animation graph %s: state %s returns to unknown state %q", g.Name, state.Name, state.Return)
+		}
+	}
+	for _, tr := range g.Transitions {
+		if _, ok := g.states[tr.From]; !ok && tr.From != "" && tr.From != "*" {
+			return fmt.Errorf("animation graph %s: transition from unknown state %q", g.Name, tr.From)
+		}
+		if _, ok := g.states[tr.To]; !ok {
+			return fmt.Errorf("animation graph %s: transition to unknown state %q", g.Name, tr.To)
+		}
+		for _, cond := range tr.Conditions {
+			if cond.Field != "" {
+				if _, ok := conditionOps[cond.Op]; !ok {
+					return fmt.Errorf("animation graph %s: invalid condition op %q", g.Name, cond.Op)
+				}
+			} else if _, ok := input.ActionByName(cond.Action); !ok {
+				return fmt.Errorf("animation graph %s: unknown action %q", g.Name, cond.Action)
+			}
+		}
+	}
+	return nil
+}
+
+// Drive advances the machine for the current tick and applies its state to the sprite
+func (g *AnimationGraph) Drive(m *AnimationMachine, sprite *SpriteBlueprint, entity warehouse.Entity, tick int) error {
+	if m.State == "" {
+		return g.enter(m, sprite, g.Initial, tick)
+	}
+	idx, ok := g.states[m.State]
+	if !ok {
+		return fmt.Errorf("animation graph %s: unknown state %q", g.Name, m.State)
+	}
+	current := g.States[idx]
+
+	finished := false
+	if current.Once {
+		anim, ok := sprite.AnimationIndex(current.Animation)
+		if !ok {
+			return fmt.Errorf("animation graph %s: sprite has no animation %q", g.Name, current.Animation)
+		}
+		finished = tick >= m.EnteredTick+sprite.Animations.Get(anim).Duration()
+	}
+
+	next, best := "", math.MinInt
+	for _, tr := range g.Transitions {
+		if tr.To == m.State || (tr.From != "" && tr.From != "*" && tr.From != m.State) {
+			continue
+		}
+		priority := g.States[g.states[tr.To]].Priority
+		if priority <= best || (current.Once && !finished && priority <= current.Priority) {
+			continue
+		}
+		ok, err := conditionsHold(tr.Conditions, entity, tick)
+		if err != nil {
+			return fmt.Errorf("animation graph %s: %w", g.Name, err)
+		}
+		if ok {
+			next, best = tr.To, priority
+		}
+	}
+
+	if next == "" && finished {
+		next = m.Return
+		if next == "" {
+			next = g.Initial
+		}
+	}
+	if next == "" {
+		return nil
+	}
+	return g.enter(m, sprite, next, tick)
+}
+
+// enter switches the machine and sprite to a state, restarting its animation
+func (g *AnimationGraph) enter(m *AnimationMachine, sprite *SpriteBlueprint, name string, tick int) error {
+	state := g.States[g.states[name]]
+	anim, ok := sprite.AnimationIndex(state.Animation)
+	if !ok {
+		return fmt.Errorf("animation graph %s: sprite has no animation %q", g.Name, state.Animation)
+	}
+
+	switch {
+	case !state.Once:
+		m.Return = ""
+	case state.Return != "":
+		m.Return = state.Return
+	case m.State != "" && !g.States[g.states[m.State]].Once:
+		m.Return = m.State
+	}
+	m.State = name
+	m.EnteredTick = tick
+
+	sprite.TryAnimationFromIndex(anim)
+	sprite.Config.StartTick = 0
+	return nil
+}
+
+// conditionsHold reports whether every condition holds for the entity
+func conditionsHold(conditions []AnimationCondition, entity warehouse.Entity, tick int) (bool, error) {
+	for _, cond := range conditions {
+		ok, err := cond.holds(entity, tick)
+		if err != nil {
+			return false, err
+		}
+		if ok == cond.Not {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (c AnimationCondition) holds(entity warehouse.Entity, tick int) (bool, error) {
+	if c.Field == "" {
+		action, _ := input.ActionByName(c.Action)
+		if !entity.Table().Contains(input.Components.ActionBuffer) {
+			return false, nil
+		}
+		for _, stamped := range input.Components.ActionBuffer.GetFromEntity(entity).Values {
+			if stamped.Val == action && stamped.Tick >= tick-c.Window {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	value, err := warehouse.GetField(entity, c.Field)
+	if err != nil {
+		return false, err
+	}
+	v := reflect.ValueOf(value)
+	var f float64
+	switch {
+	case v.CanFloat():
+		f = v.Float()
+	case v.CanInt():
+		f = float64(v.Int())
+	case v.CanUint():
+		f = float64(v.Uint())
+	case v.Kind() == reflect.Bool:
+		if v.Bool() {
+			f = 1
+		}
+	default:
+		return false, fmt.Errorf("field %s is %T, not numeric", c.Field, value)
+	}
+	if c.Abs {
+		f = math.Abs(f)
+	}
+	return conditionOps[c.Op](f, c.Value), nil
+}
@@ -0,0 +1,203 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/table"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// GraphBody is the component animation graph conditions read in tests
+type GraphBody struct {
+	Speed float64
+	Hurt  bool
+}
+
+var graphBody = warehouse.FactoryNewComponent[GraphBody]()
+
+var graphAttack = input.RegisterAction("animationgraph_test.attack")
+
+// testGraph idles, runs while moving, attacks once on the attack action and is interrupted by getting hurt
+func testGraph() AnimationGraph {
+	return AnimationGraph{
+		Name:    "animationgraph_test",
+		Initial: "idle",
+		States: []AnimationGraphState{
+			{Name: "idle", Animation: "idle"},
+			{Name: "run", Animation: "run", Priority: 1},
+			{Name: "attack", Animation: "attack", Priority: 3, Once: true},
+			{Name: "hurt", Animation: "hurt", Priority: 5, Once: true, Return: "idle"},
+		},
+		Transitions: []AnimationTransition{
+			{From: "idle", To: "run", Conditions: []AnimationCondition{{Field: "GraphBody.Speed", Op: ">", Value: 0, Abs: true}}},
+			{To: "idle", Conditions: []AnimationCondition{{Field: "GraphBody.Speed", Op: "==", Value: 0}}},
+			{From: "*", To: "attack", Conditions: []AnimationCondition{{Action: "animationgraph_test.attack"}}},
+			{To: "hurt", Conditions: []AnimationCondition{{Field: "GraphBody.Hurt", Op: "==", Value: 1}}},
+		},
+	}
+}
+
+func testSprite() SpriteBlueprint {
+	sprite := SpriteBlueprint{}
+	sprite.RegisterAnimations(
+		AnimationData{Name: "idle", FrameCount: 1, Speed: 1},
+		AnimationData{Name: "run", FrameCount: 4, Speed: 2},
+		AnimationData{Name: "attack", FrameCount: 3, Speed: 2},
+		AnimationData{Name: "hurt", FrameCount: 2, Speed: 2},
+	)
+	return sprite
+}
+
+func TestAnimationGraphDrive(t *testing.T) {
+	graph := testGraph()
+	if err := graph.compile(); err != nil {
+		t.Fatal(err)
+	}
+	sprite := testSprite()
+
+	storage := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	archetype, err := storage.NewOrExistingArchetype(graphBody, input.Components.ActionBuffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entities, err := archetype.GenerateAndReturnEntity(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity := entities[0]
+
+	steps := []struct {
+		name   string
+		tick   int
+		speed  float64
+		hurt   bool
+		attack bool
+		state  string
+		ret    string
+	}{
+		{name: "enters the initial state", tick: 1, state: "idle"},
+		{name: "conditions read absolute values", tick: 2, speed: -3, state: "run"},
+		{name: "action starts a once state", tick: 3, speed: -3, attack: true, state: "attack", ret: "run"},
+		{name: "lower priority cannot interrupt", tick: 4, state: "attack", ret: "run"},
+		{name: "still playing on its last tick", tick: 8, speed: -3, state: "attack", ret: "run"},
+		{name: "returns to the previous state when finished", tick: 9, speed: -3, state: "run"},
+		{name: "attacks again", tick: 10, speed: -3, attack: true, state: "attack", ret: "run"},
+		{name: "higher priority interrupts", tick: 11, speed: -3, hurt: true, state: "hurt", ret: "idle"},
+		{name: "a state does not transition to itself", tick: 12, speed: -3, hurt: true, state: "hurt", ret: "idle"},
+		{name: "returns to its return state when finished", tick: 15, speed: -3, state: "idle"},
+		{name: "plays on from the return state", tick: 16, speed: -3, state: "run"},
+		{name: "finished once state takes matching transitions", tick: 17, speed: -3, attack: true, state: "attack", ret: "run"},
+		{name: "transition wins over the return state", tick: 23, state: "idle"},
+	}
+
+	machine := NewAnimationMachine(graph.Name)
+	for _, step := range steps {
+		body := graphBody.GetFromEntity(entity)
+		body.Speed, body.Hurt = step.speed, step.hurt
+		buffer := input.Components.ActionBuffer.GetFromEntity(entity)
+		buffer.Values = nil
+		if step.attack {
+			buffer.Values = []input.StampedAction{{Tick: step.tick, Val: graphAttack}}
+		}
+
+		previous := machine.State
+		sprite.Config.StartTick = 99
+		if err := graph.Drive(&machine, &sprite, entity, step.tick); err != nil {
+			t.Fatalf("%s: Drive failed: %v", step.name, err)
+		}
+		if machine.State != step.state || machine.Return != step.ret {
+			t.Errorf("%s: machine in %q returning to %q, want %q returning to %q",
+				step.name, machine.State, machine.Return, step.state, step.ret)
+		}
+		if anim := sprite.ActiveAnimation().Name; anim != step.state {
+			t.Errorf("%s: sprite plays %q, want %q", step.name, anim, step.state)
+		}
+		if entered := machine.State != previous; entered != (sprite.Config.StartTick == 0) {
+			t.Errorf("%s: StartTick = %d after entering %v", step.name, sprite.Config.StartTick, entered)
+		}
+		if machine.State != previous && machine.EnteredTick != step.tick {
+			t.Errorf("%s: EnteredTick = %d, want %d", step.name, machine.EnteredTick, step.tick)
+		}
+	}
+}
+
+// TestAnimationGraphOnceWithoutReturn tests that a once state entered first falls back to the initial state
+func TestAnimationGraphOnceWithoutReturn(t *testing.T) {
+	graph := AnimationGraph{
+		Name:    "animationgraph_test.intro",
+		Initial: "idle",
+		States: []AnimationGraphState{
+			{Name: "idle", Animation: "idle"},
+			{Name: "attack", Animation: "attack", Once: true},
+		},
+	}
+	if err := graph.compile(); err != nil {
+		t.Fatal(err)
+	}
+	sprite := testSprite()
+	machine := AnimationMachine{Graph: graph.Name, State: "attack", EnteredTick: 1}
+
+	if err := graph.Drive(&machine, &sprite, nil, 6); err != nil || machine.State != "attack" {
+		t.Fatalf("Drive = %v in %q, want to keep playing attack", err, machine.State)
+	}
+	if err := graph.Drive(&machine, &sprite, nil, 7); err != nil || machine.State != "idle" {
+		t.Errorf("Drive = %v in %q, want the initial state", err, machine.State)
+	}
+}
+
+func TestAnimationGraphDriveErrors(t *testing.T) {
+	graph := testGraph()
+	if err := graph.compile(); err != nil {
+		t.Fatal(err)
+	}
+
+	sprite := testSprite()
+	machine := AnimationMachine{State: "missing"}
+	if err := graph.Drive(&machine, &sprite, nil, 1); err == nil || !strings.Contains(err.Error(), `unknown state "missing"`) {
+		t.Errorf("Drive from an unknown state = %v", err)
+	}
+
+	bare := SpriteBlueprint{}
+	bare.RegisterAnimations(AnimationData{Name: "run", FrameCount: 1, Speed: 1})
+	machine = AnimationMachine{}
+	if err := graph.Drive(&machine, &bare, nil, 1); err == nil || !strings.Contains(err.Error(), `no animation "idle"`) {
+		t.Errorf("Drive with a sprite missing the animation = %v", err)
+	}
+	if machine.State != "" {
+		t.Errorf("Failed Drive moved the machine to %q", machine.State)
+	}
+}
+
+func TestAnimationGraphCompile(t *testing.T) {
+	valid := testGraph()
+	if err := valid.compile(); err != nil {
+		t.Fatalf("compile failed on a valid graph: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*AnimationGraph)
+		want   string
+	}{
+		{"missing name", func(g *AnimationGraph) { g.Name = "" }, "missing a name"},
+		{"duplicate state", func(g *AnimationGraph) { g.States = append(g.States, g.States[0]) }, `duplicate state "idle"`},
+		{"state without animation", func(g *AnimationGraph) { g.States[1].Animation = "" }, "state run has no animation"},
+		{"unknown initial state", func(g *AnimationGraph) { g.Initial = "sleep" }, `unknown initial state "sleep"`},
+		{"unknown return state", func(g *AnimationGraph) { g.States[3].Return = "sleep" }, `returns to unknown state "sleep"`},
+		{"transition from unknown state", func(g *AnimationGraph) { g.Transitions[0].From = "sleep" }, `transition from unknown state "sleep"`},
+		{"transition to unknown state", func(g *AnimationGraph) { g.Transitions[0].To = "sleep" }, `transition to unknown state "sleep"`},
+		{"invalid op", func(g *AnimationGraph) { g.Transitions[0].Conditions[0].Op = "=>" }, `invalid condition op "=>"`},
+		{"unknown action", func(g *AnimationGraph) { g.Transitions[2].Conditions[0].Action = "nope" }, `unknown action "nope"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := testGraph()
+			tt.modify(&graph)
+			if err := graph.compile(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("compile error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
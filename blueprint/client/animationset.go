@@ -0,0 +1,95 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// AnimationSet is a handle to a list of animations shared by every sprite that uses it
+//
+// Sprites store the handle instead of the animations, so an entity only carries a number per
+// sprite and serializes compactly. The zero value is the empty set
+//
+// Since SerializeStorage stores just the handle, a saved sprite only resolves its animations in a process
+// that registered the same animations first, for example by building its sprites before LoadStorage.
+// An unregistered handle behaves like the empty set
+type AnimationSet uint32
+
+// animationSets holds the registered animation lists by handle
+var animationSets = struct {
+	sync.RWMutex
+	anims map[AnimationSet][]AnimationData
+	keys  map[AnimationSet]string
+}{
+	anims: make(map[AnimationSet][]AnimationData),
+	keys:  make(map[AnimationSet]string),
+}
+
+// NewAnimationSet registers the animations and returns their handle
+//
+// The handle is derived from the animations' content, so client and server agree on it regardless of
+// registration order, and registering the same animations again returns the same set. It panics if two
+// different lists hash to the same handle, which is a programming error to resolve by renaming an animation,
+// or if an event is tagged to a frame past the end of its animation
+func NewAnimationSet(anims ...AnimationData) AnimationSet {
+	if len(anims) == 0 {
+		return 0
+	}
+	for _, anim := range anims {
+		if err := anim.validateEvents(); err != nil {
+			panic(err)
+		}
+	}
+	key, err := json.Marshal(anims)
+	if err != nil {
+		panic(fmt.Errorf("failed to encode animations: %w", err))
+	}
+	h := fnv.New32a()
+	h.Write(key)
+	set := AnimationSet(max(h.Sum32(), 1))
+
+	animationSets.Lock()
+	defer animationSets.Unlock()
+	if existing, ok := animationSets.keys[set]; ok {
+		if existing != string(key) {
+			panic(fmt.Sprintf("animation set %q collides with another set (id %d)", anims[0].Name, set))
+		}
+		return set
+	}
+	animationSets.keys[set] = string(key)
+	animationSets.anims[set] = append([]AnimationData(nil), anims...)
+	return set
+}
+
+// Animations returns the set's animations, which are shared and must not be modified
+func (s AnimationSet) Animations() []AnimationData {
+	animationSets.RLock()
+	defer animationSets.RUnlock()
+	return animationSets.anims[s]
+}
+
+// Len returns the number of animations in the set
+func (s AnimationSet) Len() int {
+	return len(s.Animations())
+}
+
+// Get returns the animation at the index, or the zero AnimationData when out of range
+func (s AnimationSet) Get(index int) AnimationData {
+	anims := s.Animations()
+	if index < 0 || index >= len(anims) {
+		return AnimationData{}
+	}
+	return anims[index]
+}
+
+// Index returns the index of the animation with the given name
+func (s AnimationSet) Index(name string) (int, bool) {
+	for i, anim := range s.Animations() {
+		if anim.Name == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
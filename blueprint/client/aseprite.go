@@ -0,0 +1,195 @@
+package client
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/environment"
+)
+
+// asepriteRect is a rect as exported by Aseprite
+type asepriteRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// asepriteFrame is one entry of the Aseprite "frames" array or hash
+type asepriteFrame struct {
+	Filename         string       `json:"filename"`
+	Frame            asepriteRect `json:"frame"`
+	Rotated          bool         `json:"rotated"`
+	SpriteSourceSize asepriteRect `json:"spriteSourceSize"`
+	SourceSize       asepriteRect `json:"sourceSize"`
+	Duration         int          `json:"duration"` // milliseconds
+}
+
+// asepriteTag is a named frame range from the Aseprite timeline
+type asepriteTag struct {
+	Name      string `json:"name"`
+	From      int    `json:"from"`
+	To        int    `json:"to"`
+	Direction string `json:"direction"`
+}
+
+type asepriteSheet struct {
+	Frames json.RawMessage `json:"frames"`
+	Meta   struct {
+		FrameTags []asepriteTag `json:"frameTags"`
+	} `json:"meta"`
+}
+
+// ParseAseprite converts an Aseprite sprite sheet export (array or hash variant) into animations
+//
+// Each frame tag becomes an animation named after the tag, with reverse and ping-pong directions
+// expanded into explicit frames. A sheet without tags yields a single animation named "default".
+// Frame durations are converted from milliseconds to ticks at the given tick rate
+func ParseAseprite(data []byte, ticksPerSecond int) (*AnimationCollection, error) {
+	if ticksPerSecond <= 0 {
+		return nil, fmt.Errorf("invalid tick rate %d", ticksPerSecond)
+	}
+	sheet := asepriteSheet{}
+	if err := json.Unmarshal(data, &sheet); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal aseprite sheet: %w", err)
+	}
+	frames, err := parseAsepriteFrames(sheet.Frames)
+	if err != nil {
+		return nil, err
+	}
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("aseprite sheet has no frames")
+	}
+
+	converted := make([]AnimationFrame, len(frames))
+	for i, f := range frames {
+		if f.Rotated {
+			return nil, fmt.Errorf("aseprite frame %q is rotated, export without rotation", f.Filename)
+		}
+		ticks := int(math.Round(float64(f.Duration) * float64(ticksPerSecond) / 1000))
+		converted[i] = AnimationFrame{
+			X: f.Frame.X, Y: f.Frame.Y, W: f.Frame.W, H: f.Frame.H,
+			Duration: max(ticks, 1),
+			Offset:   vector.Two{X: float64(f.SpriteSourceSize.X), Y: float64(f.SpriteSourceSize.Y)},
+		}
+	}
+
+	tags := sheet.Meta.FrameTags
+	if len(tags) == 0 {
+		tags = []asepriteTag{{Name: "default", From: 0, To: len(frames) - 1}}
+	}
+
+	collection := &AnimationCollection{}
+	for _, tag := range tags {
+		if tag.From < 0 || tag.To >= len(frames) || tag.From > tag.To {
+			return nil, fmt.Errorf("aseprite tag %s has invalid range %d-%d", tag.Name, tag.From, tag.To)
+		}
+		order, err := asepriteFrameOrder(tag)
+		if err != nil {
+			return nil, err
+		}
+		size := frames[tag.From].SourceSize
+		if size.W == 0 || size.H == 0 {
+			size = frames[tag.From].Frame
+		}
+		anim := AnimationData{
+			Name:        tag.Name,
+			FrameWidth:  size.W,
+			FrameHeight: size.H,
+			FrameCount:  len(order),
+			Speed:       converted[order[0]].Duration,
+		}
+		for _, i := range order {
+			anim.Frames = append(anim.Frames, converted[i])
+		}
+		collection.Animations = append(collection.Animations, anim)
+	}
+	if err := collection.validate(); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// LoadAnimationsFromAseprite reads an Aseprite export the same way LoadAnimationsFromJSON reads animations
+func LoadAnimationsFromAseprite(filename, path string, fs embed.FS, ticksPerSecond int) (*AnimationCollection, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if environment.IsProd() || environment.IsWASM() {
+		data, err = fs.ReadFile(filename)
+	} else {
+		data, err = os.ReadFile(path + filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read aseprite sheet %s: %w", filename, err)
+	}
+	return ParseAseprite(data, ticksPerSecond)
+}
+
+// parseAsepriteFrames decodes the array variant directly and walks the hash variant
+// token by token, since its frame order is the key order in the document
+func parseAsepriteFrames(raw json.RawMessage) ([]asepriteFrame, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if raw[0] == '[' {
+		frames := []asepriteFrame{}
+		if err := json.Unmarshal(raw, &frames); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal aseprite frames: %w", err)
+		}
+		return frames, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to read aseprite frames: %w", err)
+	}
+	frames := []asepriteFrame{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read aseprite frames: %w", err)
+		}
+		frame := asepriteFrame{}
+		if err := dec.Decode(&frame); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal aseprite frame %v: %w", key, err)
+		}
+		if frame.Filename == "" {
+			frame.Filename, _ = key.(string)
+		}
+		frames = append(frames, frame)
+	}
+	return frames, nil
+}
+
+// asepriteFrameOrder expands a tag's playback direction into frame indices
+func asepriteFrameOrder(tag asepriteTag) ([]int, error) {
+	forward := []int{}
+	for i := tag.From; i <= tag.To; i++ {
+		forward = append(forward, i)
+	}
+	reverse := make([]int, len(forward))
+	for i, f := range forward {
+		reverse[len(forward)-1-i] = f
+	}
+
+	// Ping-pong doesn't repeat the turning frames, so the loop stays smooth
+	switch tag.Direction {
+	case "", "forward":
+		return forward, nil
+	case "reverse":
+		return reverse, nil
+	case "pingpong":
+		return append(forward, reverse[1:max(len(reverse)-1, 1)]...), nil
+	case "pingpong_reverse":
+		return append(reverse, forward[1:max(len(forward)-1, 1)]...), nil
+	}
+	return nil, fmt.Errorf("aseprite tag %s has unknown direction %q", tag.Name, tag.Direction)
+}
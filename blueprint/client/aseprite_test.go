@@ -0,0 +1,180 @@
+package client
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+)
+
+// asepriteArraySheet is an array export of three trimmed frames of a 32x32 sprite
+const asepriteArraySheet = `{
+	"frames": [
+		{"filename": "hero 0", "frame": {"x": 0, "y": 0, "w": 16, "h": 16}, "rotated": false, "trimmed": true,
+		 "spriteSourceSize": {"x": 0, "y": 0, "w": 16, "h": 16}, "sourceSize": {"w": 32, "h": 32}, "duration": 100},
+		{"filename": "hero 1", "frame": {"x": 16, "y": 0, "w": 12, "h": 14}, "rotated": false, "trimmed": true,
+		 "spriteSourceSize": {"x": 2, "y": 3, "w": 12, "h": 14}, "sourceSize": {"w": 32, "h": 32}, "duration": 25},
+		{"filename": "hero 2", "frame": {"x": 28, "y": 0, "w": 16, "h": 16}, "rotated": false, "trimmed": true,
+		 "spriteSourceSize": {"x": 8, "y": 8, "w": 16, "h": 16}, "sourceSize": {"w": 32, "h": 32}, "duration": 5}
+	],
+	"meta": {
+		"app": "https://www.aseprite.org/",
+		"frameTags": [
+			{"name": "walk", "from": 0, "to": 2, "direction": "forward"},
+			{"name": "back", "from": 0, "to": 2, "direction": "reverse"},
+			{"name": "bounce", "from": 0, "to": 2, "direction": "pingpong"},
+			{"name": "swing", "from": 1, "to": 2, "direction": "pingpong_reverse"},
+			{"name": "blink", "from": 0, "to": 1, "direction": "pingpong"},
+			{"name": "idle", "from": 2, "to": 2, "direction": "pingpong"}
+		]
+	}
+}`
+
+// asepriteHashSheet is the hash export of the same frames without tags, keys out of alphabetical order
+const asepriteHashSheet = `{
+	"frames": {
+		"hero 2.png": {"frame": {"x": 0, "y": 0, "w": 16, "h": 16}, "rotated": false,
+		 "spriteSourceSize": {"x": 0, "y": 0, "w": 16, "h": 16}, "sourceSize": {"w": 32, "h": 32}, "duration": 100},
+		"hero 10.png": {"frame": {"x": 16, "y": 0, "w": 12, "h": 14}, "rotated": false,
+		 "spriteSourceSize": {"x": 2, "y": 3, "w": 12, "h": 14}, "sourceSize": {"w": 32, "h": 32}, "duration": 25},
+		"hero 1.png": {"frame": {"x": 28, "y": 0, "w": 16, "h": 16}, "rotated": false,
+		 "spriteSourceSize": {"x": 8, "y": 8, "w": 16, "h": 16}, "sourceSize": {"w": 32, "h": 32}, "duration": 5}
+	},
+	"meta": {"app": "https://www.aseprite.org/"}
+}`
+
+// asepriteFixtureFrames are the fixture frames at 60 ticks per second
+var asepriteFixtureFrames = []AnimationFrame{
+	{X: 0, Y: 0, W: 16, H: 16, Duration: 6},
+	{X: 16, Y: 0, W: 12, H: 14, Duration: 2, Offset: vector.Two{X: 2, Y: 3}},
+	{X: 28, Y: 0, W: 16, H: 16, Duration: 1, Offset: vector.Two{X: 8, Y: 8}},
+}
+
+// asepriteAnimation builds the animation expected for a tag playing the fixture frames in order
+func asepriteAnimation(name string, order ...int) AnimationData {
+	anim := AnimationData{
+		Name:        name,
+		FrameWidth:  32,
+		FrameHeight: 32,
+		FrameCount:  len(order),
+		Speed:       asepriteFixtureFrames[order[0]].Duration,
+	}
+	for _, i := range order {
+		anim.Frames = append(anim.Frames, asepriteFixtureFrames[i])
+	}
+	return anim
+}
+
+func TestParseAseprite(t *testing.T) {
+	tests := []struct {
+		name  string
+		sheet string
+		want  []AnimationData
+	}{
+		{
+			name:  "array with tags",
+			sheet: asepriteArraySheet,
+			want: []AnimationData{
+				asepriteAnimation("walk", 0, 1, 2),
+				asepriteAnimation("back", 2, 1, 0),
+				asepriteAnimation("bounce", 0, 1, 2, 1),
+				asepriteAnimation("swing", 2, 1),
+				asepriteAnimation("blink", 0, 1),
+				asepriteAnimation("idle", 2),
+			},
+		},
+		{
+			name:  "hash in document order without tags",
+			sheet: asepriteHashSheet,
+			want:  []AnimationData{asepriteAnimation("default", 0, 1, 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection, err := ParseAseprite([]byte(tt.sheet), 60)
+			if err != nil {
+				t.Fatalf("ParseAseprite failed: %v", err)
+			}
+			if len(collection.Animations) != len(tt.want) {
+				t.Fatalf("Got %d animations, want %d", len(collection.Animations), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got := collection.Animations[i]; !reflect.DeepEqual(got, want) {
+					t.Errorf("Animation %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAsepriteDurations(t *testing.T) {
+	tests := []struct {
+		ms, ticksPerSecond, want int
+	}{
+		{100, 60, 6},
+		{1000, 30, 30},
+		{25, 60, 2}, // 1.5 rounds up
+		{50, 30, 2}, // 1.5 rounds up
+		{16, 60, 1}, // 0.96 rounds to 1
+		{40, 60, 2}, // 2.4 rounds down
+		{5, 60, 1},  // frames last at least a tick
+		{0, 60, 1},  // frames last at least a tick
+		{150, 100, 15},
+	}
+	for _, tt := range tests {
+		sheet := fmt.Sprintf(`{"frames": [{"frame": {"w": 8, "h": 8}, "duration": %d}]}`, tt.ms)
+		collection, err := ParseAseprite([]byte(sheet), tt.ticksPerSecond)
+		if err != nil {
+			t.Fatalf("ParseAseprite failed: %v", err)
+		}
+		anim := collection.Animations[0]
+		if got := anim.Frames[0].Duration; got != tt.want || anim.Speed != tt.want {
+			t.Errorf("%dms at %d ticks per second = %d ticks, want %d", tt.ms, tt.ticksPerSecond, got, tt.want)
+		}
+		// Untrimmed sheets without a source size use the frame size
+		if anim.FrameWidth != 8 || anim.FrameHeight != 8 {
+			t.Errorf("Frame size = %dx%d, want 8x8", anim.FrameWidth, anim.FrameHeight)
+		}
+	}
+}
+
+func TestParseAsepriteErrors(t *testing.T) {
+	frame := `{"frame": {"w": 8, "h": 8}, "duration": 100}`
+	tests := []struct {
+		name           string
+		sheet          string
+		ticksPerSecond int
+		want           string
+	}{
+		{"invalid tick rate", asepriteArraySheet, 0, "invalid tick rate 0"},
+		{"invalid json", `{"frames": [`, 60, "failed to unmarshal aseprite sheet"},
+		{"no frames", `{"frames": []}`, 60, "no frames"},
+		{"missing frames", `{"meta": {}}`, 60, "no frames"},
+		{"rotated frame", `{"frames": [{"filename": "spin", "rotated": true, "duration": 100}]}`, 60, `"spin" is rotated`},
+		{
+			"tag past the last frame",
+			`{"frames": [` + frame + `], "meta": {"frameTags": [{"name": "run", "from": 0, "to": 1}]}}`,
+			60, "tag run has invalid range 0-1",
+		},
+		{
+			"reversed tag range",
+			`{"frames": [` + frame + `,` + frame + `], "meta": {"frameTags": [{"name": "run", "from": 1, "to": 0}]}}`,
+			60, "tag run has invalid range 1-0",
+		},
+		{
+			"unknown direction",
+			`{"frames": [` + frame + `], "meta": {"frameTags": [{"name": "run", "from": 0, "to": 0, "direction": "sideways"}]}}`,
+			60, `unknown direction "sideways"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAseprite([]byte(tt.sheet), tt.ticksPerSecond)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseAseprite error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ type defaultComponents struct {
 	SoundBundle        warehouse.AccessibleComponent[SoundBundle]
 	CameraIndex        warehouse.AccessibleComponent[CameraIndex]
 	ParallaxBackground warehouse.AccessibleComponent[ParallaxBackground]
+	AnimationMachine   warehouse.AccessibleComponent[AnimationMachine]
 }
 
 var Components = defaultComponents{
@@ -16,4 +17,5 @@ var Components = defaultComponents{
 	ParallaxBackground: warehouse.FactoryNewComponent[ParallaxBackground](),
 	SpriteBundle:       warehouse.FactoryNewComponent[SpriteBundle](),
 	SoundBundle:        warehouse.FactoryNewComponent[SoundBundle](),
+	AnimationMachine:   warehouse.FactoryNewComponent[AnimationMachine](),
 }
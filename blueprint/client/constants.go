@@ -1,8 +1,12 @@
 package client
 
+const (
+	MaxSplit = 8 // Max number of players/cameras/receivers
+)
+
+// Deprecated: sprite bundles, animation sets and sound bundles grow as needed, these limits are no longer enforced
 const (
 	SPRITE_LIMIT = 40 // Max sprites per bundle
 	ANIM_LIMIT   = 40 // Max animations per sprite
 	SOUND_LIMIT  = 40 // Max sounds per bundle
-	MaxSplit     = 8  // Max number of players/cameras/receivers
 )
@@ -1,12 +1,16 @@
 package client
 
-import "github.com/TheBitDrifter/bappa/warehouse"
+import (
+	"slices"
 
-// SoundBundle stores a collection of sound blueprints up to a predefined limit
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// SoundBundle stores the sound blueprints an entity uses
+// Like SpriteBundle, only added sounds are stored and copies never alias each other
 type SoundBundle struct {
-	index int
 	// Blueprints collection of sound resources that can be referenced by entities
-	Blueprints [SOUND_LIMIT]SoundBlueprint
+	Blueprints []SoundBlueprint
 }
 
 // NewSoundBundle creates an empty sound bundle
@@ -14,21 +18,29 @@ func NewSoundBundle() SoundBundle {
 	return SoundBundle{}
 }
 
+// CloneComponent returns a copy of the bundle that shares no blueprints with it
+func (sb SoundBundle) CloneComponent() any {
+	blueprints := make([]SoundBlueprint, len(sb.Blueprints))
+	for i := range sb.Blueprints {
+		blueprints[i].Location.Key = sb.Blueprints[i].Location.Key
+		blueprints[i].Location.Index.Store(sb.Blueprints[i].Location.Index.Load())
+		blueprints[i].AudioPlayerCount = sb.Blueprints[i].AudioPlayerCount
+	}
+	return SoundBundle{Blueprints: blueprints}
+}
+
 // AddSoundFromConfig adds a new sound blueprint to the bundle based on configuration
 // Returns a new bundle with the added sound
 // Used to define the number of audio players, which determines how many instances
 // of a sound can play simultaneously
 func (sb SoundBundle) AddSoundFromConfig(soundConfig SoundConfig) SoundBundle {
-	if sb.index >= SOUND_LIMIT {
-		panic("Sound limit exceeded")
-	}
-	sb.Blueprints[sb.index] = SoundBlueprint{
+	// Clip so appending never writes into a backing array shared with other bundles
+	sb.Blueprints = append(slices.Clip(sb.Blueprints), SoundBlueprint{
 		AudioPlayerCount: soundConfig.AudioPlayerCount,
 		Location: warehouse.CacheLocation{
 			Key: soundConfig.Path,
 		},
-	}
-	sb.index++
+	})
 	return sb
 }
 
@@ -36,15 +48,5 @@ func (sb SoundBundle) AddSoundFromConfig(soundConfig SoundConfig) SoundBundle {
 // Creates a blueprint with default settings (single audio player)
 // Returns a new bundle with the added sound
 func (sb SoundBundle) AddSoundFromPath(path string) SoundBundle {
-	if sb.index >= SOUND_LIMIT {
-		panic("Sound limit exceeded")
-	}
-	sb.Blueprints[sb.index] = SoundBlueprint{
-		AudioPlayerCount: 1,
-		Location: warehouse.CacheLocation{
-			Key: path,
-		},
-	}
-	sb.index++
-	return sb
+	return sb.AddSoundFromConfig(SoundConfig{Path: path, AudioPlayerCount: 1})
 }
@@ -14,8 +14,8 @@ import (
 type SpriteBlueprint struct {
 	// Location reference to the sprite resource in the cache
 	Location warehouse.CacheLocation
-	// Animations collection of animation data for this sprite
-	Animations [ANIM_LIMIT]AnimationData
+	// Animations handle to the shared animation data for this sprite
+	Animations AnimationSet
 	// Config contains rendering and animation settings
 	Config struct {
 		// Offset position adjustment from entity location
@@ -29,46 +29,36 @@ type SpriteBlueprint struct {
 		// ActiveAnimIndex currently playing animation index
 		ActiveAnimIndex int
 		HasAnim         bool
+		// StartTick is when the active animation began (0 restarts it on the next tick)
+		StartTick int `json:"-"`
+		// EventTick is the last tick AdvanceEvents processed
+		EventTick int `json:"-"`
 	}
 	// A tilset contains the layout to render the blueprint's sprites as a tiles (cached subimages)
 	TileSet []Tile
 }
 
-// RegisterAnimations adds animations to the sprite
-// Panics if animations exceed the predefined limit
+// RegisterAnimations sets the sprite's animations, sharing them through an AnimationSet
 func (s *SpriteBlueprint) RegisterAnimations(anims ...AnimationData) {
-	if len(anims) > ANIM_LIMIT {
-		panic("beyond animation limit")
-	}
-	// I'm not sure how to use copy with array vs slice
-	for i, anim := range anims {
-		s.Animations[i] = anim
-	}
+	s.Animations = NewAnimationSet(anims...)
+	s.Config.HasAnim = len(anims) > 0
 }
 
 // TryAnimation changes the active animation if not already playing
 // Resets the previous animation's state
 func (s *SpriteBlueprint) TryAnimation(anim AnimationData) {
-	current := s.Config.ActiveAnimIndex
-	for i, bpAnim := range s.Animations {
-		if anim.Name == bpAnim.Name && i != current {
-			s.Animations[s.Config.ActiveAnimIndex].StartTick = 0 // Reset first
-			s.Config.ActiveAnimIndex = i
-			break
-		}
+	if i, ok := s.Animations.Index(anim.Name); ok && i != s.Config.ActiveAnimIndex {
+		s.Config.StartTick = 0 // Reset first
+		s.Config.ActiveAnimIndex = i
 	}
 }
 
 // Set changes the active animation
 // Resets the previous animation's state if needed
 func (s *SpriteBlueprint) SetAnimation(anim AnimationData) {
-	for i, bpAnim := range s.Animations {
-		if anim.Name == bpAnim.Name {
-
-			s.Animations[s.Config.ActiveAnimIndex].StartTick = 0
-			s.Config.ActiveAnimIndex = i
-			break
-		}
+	if i, ok := s.Animations.Index(anim.Name); ok {
+		s.Config.StartTick = 0
+		s.Config.ActiveAnimIndex = i
 	}
 }
 
@@ -76,24 +66,76 @@ func (s *SpriteBlueprint) TryAnimationFromIndex(index int) {
 	if index == s.Config.ActiveAnimIndex {
 		return
 	}
-	s.Animations[s.Config.ActiveAnimIndex].StartTick = 0 // Reset first
+	s.Config.StartTick = 0 // Reset first
 	s.Config.ActiveAnimIndex = index
 }
 
+// AnimationIndex returns the index of the animation with the given name
+func (s *SpriteBlueprint) AnimationIndex(name string) (int, bool) {
+	return s.Animations.Index(name)
+}
+
+// ActiveAnimation returns the currently playing animation
+func (s *SpriteBlueprint) ActiveAnimation() AnimationData {
+	return s.Animations.Get(s.Config.ActiveAnimIndex)
+}
+
 // HasAnimations returns whether this sprite has animations registered
 func (s *SpriteBlueprint) HasAnimations() bool {
 	return s.Config.HasAnim
 }
 
 func (sb SpriteBlueprint) GetAnim(anim AnimationData) (AnimationData, error) {
-	for _, sbAnim := range sb.Animations {
-		if anim.Name == sbAnim.Name {
-			return anim, nil
-		}
+	if _, ok := sb.Animations.Index(anim.Name); ok {
+		return anim, nil
 	}
 	return AnimationData{}, errors.New("animation not found")
 }
 
+// AdvanceEvents plays the active animation's timeline up to the tick and calls fire for every event reached
+//
+// Each tick since the last call is visited, so every event fires exactly once per loop even when
+// systems run late or frames are shorter than the render rate. When more than a whole loop passed
+// since the last call (e.g. the sprite was inactive) the missed events are skipped and only the
+// current tick is visited. It also starts the animation when StartTick is unset and moves StartTick
+// to the current loop, keeping renderers in step
+func (s *SpriteBlueprint) AdvanceEvents(tick int, fire func(AnimationEvent)) {
+	a := s.ActiveAnimation()
+	cfg := &s.Config
+	if cfg.StartTick == 0 {
+		cfg.StartTick = tick
+	}
+	if cfg.EventTick < cfg.StartTick-1 || cfg.EventTick > tick {
+		cfg.EventTick = cfg.StartTick - 1
+	}
+	duration := a.Duration()
+	if duration <= 0 {
+		cfg.EventTick = tick
+		return
+	}
+	if tick-cfg.EventTick > duration {
+		cfg.EventTick = tick - 1
+	}
+
+	for t := cfg.EventTick + 1; t <= tick; t++ {
+		elapsed := t - cfg.StartTick
+		if a.Freeze && elapsed >= duration {
+			break
+		}
+		position := elapsed % duration
+		for _, event := range a.Events {
+			if a.FrameStart(event.Frame) == position {
+				fire(event)
+			}
+		}
+	}
+	cfg.EventTick = tick
+
+	if elapsed := tick - cfg.StartTick; !a.Freeze && elapsed >= duration {
+		cfg.StartTick += elapsed / duration * duration
+	}
+}
+
 func (sb *SpriteBlueprint) Activate() {
 	sb.Config.Active = true
 }
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"embed"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type firedEvent struct {
+	tick int
+	name string
+}
+
+func TestAdvanceEvents(t *testing.T) {
+	// Eight ticks per loop, with events on the first and third frames (ticks 0 and 4 of the loop)
+	loop := AnimationData{
+		Name: "advance_events_test.loop", FrameCount: 4, Speed: 2,
+		Events: []AnimationEvent{{Frame: 0, Name: "a"}, {Frame: 2, Name: "b"}},
+	}
+	// Frozen after six ticks, with an event on its last frame
+	frozen := AnimationData{
+		Name: "advance_events_test.frozen", FrameCount: 3, Speed: 2, Freeze: true,
+		Events: []AnimationEvent{{Frame: 0, Name: "a"}, {Frame: 2, Name: "c"}},
+	}
+
+	ticks := func(from, to, step int) []int {
+		var calls []int
+		for tick := from; tick <= to; tick += step {
+			calls = append(calls, tick)
+		}
+		return calls
+	}
+
+	tests := []struct {
+		name  string
+		anim  AnimationData
+		calls []int
+		want  []firedEvent
+		// start is StartTick after the last call
+		start int
+	}{
+		{
+			name:  "every tick fires once per loop",
+			anim:  loop,
+			calls: ticks(10, 25, 1),
+			want:  []firedEvent{{10, "a"}, {14, "b"}, {18, "a"}, {22, "b"}},
+			start: 18,
+		},
+		{
+			name:  "skipped ticks still fire once per loop",
+			anim:  loop,
+			calls: ticks(10, 25, 3),
+			want:  []firedEvent{{10, "a"}, {16, "b"}, {19, "a"}, {22, "b"}},
+			start: 18,
+		},
+		{
+			name:  "a gap of one loop catches up",
+			anim:  loop,
+			calls: []int{10, 18},
+			want:  []firedEvent{{10, "a"}, {18, "b"}, {18, "a"}},
+			start: 18,
+		},
+		{
+			name:  "a gap longer than one loop skips the missed events",
+			anim:  loop,
+			calls: []int{10, 30, 34},
+			want:  []firedEvent{{10, "a"}, {30, "b"}, {34, "a"}},
+			start: 34,
+		},
+		{
+			name:  "frozen animations fire once",
+			anim:  frozen,
+			calls: ticks(10, 40, 1),
+			want:  []firedEvent{{10, "a"}, {14, "c"}},
+			start: 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sprite := SpriteBlueprint{}
+			sprite.RegisterAnimations(tt.anim)
+
+			var got []firedEvent
+			for _, tick := range tt.calls {
+				sprite.AdvanceEvents(tick, func(event AnimationEvent) {
+					got = append(got, firedEvent{tick, event.Name})
+				})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fired %v, want %v", got, tt.want)
+			}
+			if sprite.Config.StartTick != tt.start {
+				t.Errorf("StartTick = %d, want %d", sprite.Config.StartTick, tt.start)
+			}
+		})
+	}
+}
+
+// TestAdvanceEventsRestart tests that switching animations restarts their events
+func TestAdvanceEventsRestart(t *testing.T) {
+	sprite := SpriteBlueprint{}
+	sprite.RegisterAnimations(
+		AnimationData{Name: "advance_events_test.idle", FrameCount: 1, Speed: 10, Events: []AnimationEvent{{Frame: 0, Name: "idle"}}},
+		AnimationData{Name: "advance_events_test.run", FrameCount: 2, Speed: 10, Events: []AnimationEvent{{Frame: 0, Name: "run"}}},
+	)
+
+	var got []string
+	fire := func(event AnimationEvent) { got = append(got, event.Name) }
+	sprite.AdvanceEvents(5, fire)
+	sprite.AdvanceEvents(6, fire)
+	sprite.TryAnimationFromIndex(1)
+	sprite.AdvanceEvents(7, fire)
+
+	if want := []string{"idle", "run"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fired %v, want %v", got, want)
+	}
+	if sprite.Config.StartTick != 7 {
+		t.Errorf("StartTick = %d, want 7", sprite.Config.StartTick)
+	}
+}
+
+func TestAnimationEventFrameValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		anim AnimationData
+		want string
+	}{
+		{
+			name: "frame at the length of a uniform row",
+			anim: AnimationData{Name: "validate_test.row", FrameCount: 3, Speed: 2, Events: []AnimationEvent{{Frame: 3, Name: "late"}}},
+			want: `event "late" is on frame 3, want 0-2`,
+		},
+		{
+			name: "frame past explicit frames",
+			anim: AnimationData{
+				Name: "validate_test.frames", FrameCount: 5,
+				Frames: []AnimationFrame{{Duration: 1}, {Duration: 1}},
+				Events: []AnimationEvent{{Frame: 4, Name: "late"}},
+			},
+			want: `event "late" is on frame 4, want 0-1`,
+		},
+		{
+			name: "negative frame",
+			anim: AnimationData{Name: "validate_test.negative", FrameCount: 3, Speed: 2, Events: []AnimationEvent{{Frame: -1, Name: "early"}}},
+			want: `event "early" is on frame -1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Run("NewAnimationSet", func(t *testing.T) {
+				defer func() {
+					r := recover()
+					err, ok := r.(error)
+					if !ok || !strings.Contains(err.Error(), tt.want) {
+						t.Errorf("NewAnimationSet panic = %v, want one containing %q", r, tt.want)
+					}
+				}()
+				NewAnimationSet(tt.anim)
+			})
+			t.Run("LoadAnimationsFromJSON", func(t *testing.T) {
+				data, err := json.Marshal(AnimationCollection{Animations: []AnimationData{tt.anim}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				dir := t.TempDir()
+				if err := os.WriteFile(filepath.Join(dir, "anims.json"), data, 0644); err != nil {
+					t.Fatal(err)
+				}
+				_, err = LoadAnimationsFromJSON("anims.json", dir+"/", embed.FS{})
+				if err == nil || !strings.Contains(err.Error(), tt.want) {
+					t.Errorf("LoadAnimationsFromJSON error = %v, want one containing %q", err, tt.want)
+				}
+			})
+		})
+	}
+
+	valid := AnimationData{Name: "validate_test.valid", FrameCount: 3, Speed: 2, Events: []AnimationEvent{{Frame: 2, Name: "last"}}}
+	if NewAnimationSet(valid) == 0 {
+		t.Error("NewAnimationSet rejected an event on the last frame")
+	}
+}
+
+func TestIsFinished(t *testing.T) {
+	anim := AnimationData{FrameCount: 3, Speed: 2, Freeze: true}
+	tests := []struct {
+		start, current int
+		want           bool
+	}{
+		{10, 10, false},
+		{10, 15, false},
+		{10, 16, true},
+		{10, 30, true},
+	}
+	for _, tt := range tests {
+		if got := anim.IsFinishedFrom(tt.start, tt.current); got != tt.want {
+			t.Errorf("IsFinishedFrom(%d, %d) = %v, want %v", tt.start, tt.current, got, tt.want)
+		}
+		// The deprecated form reads the start from the StartTick field
+		legacy := anim
+		legacy.StartTick = tt.start
+		if got := legacy.IsFinished(tt.current); got != tt.want {
+			t.Errorf("IsFinished(%d) from %d = %v, want %v", tt.current, tt.start, got, tt.want)
+		}
+	}
+}
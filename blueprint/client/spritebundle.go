@@ -2,16 +2,21 @@ package client
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/TheBitDrifter/bappa/blueprint/vector"
 	"github.com/TheBitDrifter/bappa/warehouse"
 )
 
-// SpriteBundle stores a collection of sprite blueprints up to a predefined limit
+// SpriteBundle stores the sprite blueprints an entity uses
+//
+// Only added sprites are stored and their animations are shared AnimationSet handles,
+// so bundles stay small. Builder methods and warehouse (through CloneComponent) copy the
+// blueprints, so bundles built from or generated with the same value never alias each other
 type SpriteBundle struct {
 	index int
 	// Blueprints collection of sprite resources that can be referenced by entities
-	Blueprints [SPRITE_LIMIT]SpriteBlueprint
+	Blueprints []SpriteBlueprint
 }
 
 // NewSpriteBundle creates an empty sprite bundle
@@ -19,137 +24,120 @@ func NewSpriteBundle() SpriteBundle {
 	return SpriteBundle{}
 }
 
+// CloneComponent returns a copy of the bundle that shares no blueprints with it
+func (sb SpriteBundle) CloneComponent() any {
+	return sb.clone()
+}
+
+func (sb SpriteBundle) clone() SpriteBundle {
+	blueprints := make([]SpriteBlueprint, len(sb.Blueprints))
+	for i := range sb.Blueprints {
+		bp := &sb.Blueprints[i]
+		blueprints[i].Location.Key = bp.Location.Key
+		blueprints[i].Location.Index.Store(bp.Location.Index.Load())
+		blueprints[i].Animations = bp.Animations
+		blueprints[i].Config = bp.Config
+		blueprints[i].TileSet = slices.Clone(bp.TileSet)
+	}
+	return SpriteBundle{index: sb.index, Blueprints: blueprints}
+}
+
+// last returns the most recently added sprite, panicking with msg if there is none
+func (sb SpriteBundle) last(msg string) *SpriteBlueprint {
+	if sb.index == 0 {
+		panic(msg)
+	}
+	return &sb.Blueprints[sb.index-1]
+}
+
 // AddSprite adds a new sprite blueprint to the bundle
 // Returns a new bundle with the added sprite
 func (sb SpriteBundle) AddSprite(path string, active bool) SpriteBundle {
-	if sb.index >= SPRITE_LIMIT {
-		panic("Sprite limit exceeded")
-	}
-	sb.Blueprints[sb.index] = SpriteBlueprint{
-		Location: warehouse.CacheLocation{
-			Key: path,
-		},
-	}
-	sb.Blueprints[sb.index].Config.Active = active
-	sb.index++
-	return sb
+	return sb.AddSpriteAtIndex(len(sb.Blueprints), path, active)
 }
 
-// Warning: Using AddSprite after this will break unless AddSpriteAtIndex is called again at the last available index...
+// AddSpriteAtIndex sets the sprite blueprint at the index, growing the bundle as needed
+// The sprite becomes the most recently added one, which the With methods configure
 func (sb SpriteBundle) AddSpriteAtIndex(index int, path string, active bool) SpriteBundle {
-	if sb.index >= SPRITE_LIMIT {
-		panic("Sprite limit exceeded")
-	}
-
-	// Check if the provided index is valid.
-	if index < 0 || index > SPRITE_LIMIT {
+	if index < 0 {
 		panic("Index out of bounds")
 	}
-	sb.index = index + 1
-
+	sb = sb.clone()
+	if index >= len(sb.Blueprints) {
+		sb.Blueprints = append(sb.Blueprints, make([]SpriteBlueprint, index+1-len(sb.Blueprints))...)
+	}
 	sb.Blueprints[index] = SpriteBlueprint{
 		Location: warehouse.CacheLocation{
 			Key: path,
 		},
 	}
-
 	sb.Blueprints[index].Config.Active = active
-
+	sb.index = index + 1
 	return sb
 }
 
 // WithAnimations adds animations to the most recently added sprite
 // Returns the updated bundle
 func (sb SpriteBundle) WithAnimations(anims ...AnimationData) SpriteBundle {
-	if sb.index == 0 {
-		panic("No sprite to add animations to")
-	}
-	blueprint := &sb.Blueprints[sb.index-1]
-	for i, anim := range anims {
-		if i >= ANIM_LIMIT {
-			panic("Animation limit exceeded")
-		}
-		blueprint.Animations[i] = anim
-		blueprint.Config.HasAnim = true
-	}
+	sb = sb.clone()
+	sb.last("No sprite to add animations to").RegisterAnimations(anims...)
 	return sb
 }
 
 // WithOffset adds a position offset to the most recently added sprite
 // Returns the updated bundle
 func (sb SpriteBundle) WithOffset(offset vector.Two) SpriteBundle {
-	if sb.index == 0 {
-		panic("No sprite to add offset to")
-	}
-	blueprint := &sb.Blueprints[sb.index-1]
-	blueprint.Config.Offset = offset
+	sb = sb.clone()
+	sb.last("No sprite to add offset to").Config.Offset = offset
 	return sb
 }
 
 // WithPriority sets the rendering priority of the most recently added sprite
 // Returns the updated bundle
 func (sb SpriteBundle) WithPriority(prio int) SpriteBundle {
-	if sb.index == 0 {
-		panic("No sprite to prioritize")
-	}
-	blueprint := &sb.Blueprints[sb.index-1]
-	blueprint.Config.Priority = prio
+	sb = sb.clone()
+	sb.last("No sprite to prioritize").Config.Priority = prio
 	return sb
 }
 
 // WithStatic marks the most recently added sprite as static (not affected by camera)
 // Returns the updated bundle
 func (sb SpriteBundle) WithStatic(static bool) SpriteBundle {
-	if sb.index == 0 {
-		panic("No sprite to add animations to")
-	}
-	blueprint := &sb.Blueprints[sb.index-1]
-	blueprint.Config.Static = true
+	sb = sb.clone()
+	sb.last("No sprite to add animations to").Config.Static = true
 	return sb
 }
 
 // WithCustomRenderer marks the most recently added sprite to ignore default rendering
 // Returns the updated bundle
 func (sb SpriteBundle) WithCustomRenderer() SpriteBundle {
-	if sb.index == 0 {
-		panic("no sprite to ignore")
-	}
-	blueprint := &sb.Blueprints[sb.index-1]
-	blueprint.Config.IgnoreDefaultRenderer = true
+	sb = sb.clone()
+	sb.last("no sprite to ignore").Config.IgnoreDefaultRenderer = true
 	return sb
 }
 
 // Count returns the number of sprites in the bundle
 func (sb SpriteBundle) Count() int {
-	return sb.index
+	return len(sb.Blueprints)
 }
 
 func (sb SpriteBundle) SetActiveAnimation(anim AnimationData) SpriteBundle {
-	if sb.index == 0 {
-		panic("No sprite to add animations to")
-	}
-	blueprint := &sb.Blueprints[sb.index-1]
+	sb = sb.clone()
+	blueprint := sb.last("No sprite to add animations to")
 	if !blueprint.Config.HasAnim {
 		panic("sprite has no animations")
 	}
-	match := false
-	for i, bpAnim := range blueprint.Animations {
-		if anim.Name == bpAnim.Name {
-			blueprint.Config.ActiveAnimIndex = i
-			match = true
-		}
-	}
-	if !match {
+	i, ok := blueprint.Animations.Index(anim.Name)
+	if !ok {
 		panic(fmt.Errorf("animation not found: %s", anim.Name))
 	}
+	blueprint.Config.ActiveAnimIndex = i
 	return sb
 }
 
 func (sb SpriteBundle) SetActiveAnimationFromIndex(index int) SpriteBundle {
-	if sb.index == 0 {
-		panic("No sprite to add animations to")
-	}
-	blueprint := &sb.Blueprints[sb.index-1]
+	sb = sb.clone()
+	blueprint := sb.last("No sprite to add animations to")
 	if !blueprint.Config.HasAnim {
 		panic("sprite has no animations")
 	}
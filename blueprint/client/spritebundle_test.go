@@ -0,0 +1,28 @@
+package client
+
+import "testing"
+
+// TestSpriteBundleCloneTileSet tests that clones and derived bundles never share tiles
+func TestSpriteBundleCloneTileSet(t *testing.T) {
+	base := NewSpriteBundle().AddSprite("tiles.png", true)
+	tiles := make([]Tile, 2, 4)
+	tiles[0].TileID, tiles[1].TileID = 1, 2
+	base.Blueprints[0].TileSet = tiles
+
+	clone := base.CloneComponent().(SpriteBundle)
+	clone.Blueprints[0].TileSet[0].TileID = 9
+	clone.Blueprints[0].TileSet = append(clone.Blueprints[0].TileSet, Tile{TileID: 3})
+
+	derived := base.WithPriority(2)
+	derived.Blueprints[0].TileSet[1].TileID = 8
+
+	if base.Blueprints[0].TileSet[0].TileID != 1 || base.Blueprints[0].TileSet[1].TileID != 2 {
+		t.Errorf("Writes through copies changed the original tiles: %+v", base.Blueprints[0].TileSet)
+	}
+	if extended := tiles[:3]; extended[2].TileID != 0 {
+		t.Errorf("Appending to a clone wrote into the original's spare capacity: %+v", extended)
+	}
+	if len(clone.Blueprints[0].TileSet) != 3 || derived.Blueprints[0].TileSet[1].TileID != 8 {
+		t.Errorf("Copies lost their own tiles: %+v and %+v", clone.Blueprints[0].TileSet, derived.Blueprints[0].TileSet)
+	}
+}
@@ -1,7 +1,11 @@
 package dialogue
 
 import (
+	"fmt"
+	"maps"
+
 	"github.com/TheBitDrifter/bappa/blueprint"
+	"github.com/TheBitDrifter/bappa/blueprint/locale"
 	"github.com/TheBitDrifter/bappa/warehouse"
 )
 
@@ -9,16 +13,32 @@ type Conversation struct {
 	SlidesID         SlidesEnum
 	CallbackID       CallbackEnum
 	ActiveSlideIndex int
+
+	// Graph is the name of a graph in GraphRegistry, used instead of SlidesID when set
+	Graph       string
+	NodeID      string
+	ChoiceIndex int
+	Ended       bool
+	// Variables hold the state graph conditions read and assignments write, booleans as 0 and 1
+	// Conversations that should share state (e.g. one per NPC of a quest) can be given the same map
+	Variables map[string]float64
+
 	AnimationState
 	PortraitIDForSpriteBundleBlueprintIndex map[PortraitEnum]int
 }
 
 type AnimationState struct {
-	WrappedText     string
-	DisplayedText   string
+	// WrappedText is the slide's markup with line breaks added
+	WrappedText string
+	// DisplayedText is the revealed part of WrappedText without markup
+	DisplayedText string
+	// RevealedGlyphs is the number of visible glyphs of WrappedText
+	RevealedGlyphs  int
 	FinalUpdateTick int
 	RevealStartTick int
 	IsRevealing     bool
+	// LocaleVersion is the locale.Version the text was wrapped for
+	LocaleVersion int
 }
 
 type Slides []Slide
@@ -28,6 +48,21 @@ type Slide struct {
 	PortraitID       PortraitEnum
 	Text             string
 	CustomSpeedTicks int
+
+	// TextKey and OwnerKey are locale keys that replace Text and OwnerName when set
+	TextKey  string
+	OwnerKey string
+}
+
+// Localized returns the slide with its keys resolved for the active locale
+func (s Slide) Localized() Slide {
+	if s.TextKey != "" {
+		s.Text = locale.Get(s.TextKey)
+	}
+	if s.OwnerKey != "" {
+		s.OwnerName = locale.Get(s.OwnerKey)
+	}
+	return s
 }
 
 type PortraitEnum int
@@ -47,3 +82,149 @@ type comps struct {
 var Components = comps{
 	Conversation: warehouse.FactoryNewComponent[Conversation](),
 }
+
+// CloneComponent returns a copy of the conversation with its own variables, so entities generated
+// from the same conversation do not share state by accident
+func (c Conversation) CloneComponent() any {
+	c.Variables = maps.Clone(c.Variables)
+	return c
+}
+
+// ActiveSlide returns the localized slide being shown, from the current graph node or the slides list
+func (c *Conversation) ActiveSlide() (Slide, bool) {
+	if c.Graph != "" {
+		node, ok := c.node()
+		if !ok {
+			return Slide{}, false
+		}
+		return node.Slide().Localized(), true
+	}
+	slides := SlidesRegistry[c.SlidesID]
+	if c.Ended || c.ActiveSlideIndex < 0 || c.ActiveSlideIndex >= len(slides) {
+		return Slide{}, false
+	}
+	return slides[c.ActiveSlideIndex].Localized(), true
+}
+
+// Choices returns the localized choices of the current graph node whose conditions hold
+func (c *Conversation) Choices() []Choice {
+	node, ok := c.node()
+	if !ok {
+		return nil
+	}
+	var choices []Choice
+	for _, choice := range node.Choices {
+		if choice.Available(c.Variables) {
+			if choice.TextKey != "" {
+				choice.Text = locale.Get(choice.TextKey)
+			}
+			choices = append(choices, choice)
+		}
+	}
+	return choices
+}
+
+// SelectChoice moves the highlighted choice by delta, wrapping around
+func (c *Conversation) SelectChoice(delta int) {
+	if count := len(c.Choices()); count > 0 {
+		c.ChoiceIndex = ((c.ChoiceIndex+delta)%count + count) % count
+	}
+}
+
+// Start enters the graph's start node, linear conversations start at ActiveSlideIndex as is
+func (c *Conversation) Start(scene blueprint.Scene) error {
+	c.Ended = false
+	if c.Graph == "" {
+		return nil
+	}
+	graph, ok := GraphRegistry[c.Graph]
+	if !ok {
+		return fmt.Errorf("unknown dialogue graph %q", c.Graph)
+	}
+	return c.enter(scene, graph, graph.Start)
+}
+
+// Advance moves past the current line, picking the highlighted choice when the node offers choices
+// It returns false once the conversation has ended, after running the CallbackID callback
+func (c *Conversation) Advance(scene blueprint.Scene) (bool, error) {
+	if c.Ended {
+		return false, nil
+	}
+	if c.Graph == "" {
+		c.ActiveSlideIndex++
+		if c.ActiveSlideIndex < len(SlidesRegistry[c.SlidesID]) {
+			return true, nil
+		}
+		return false, c.end(scene)
+	}
+
+	node, ok := c.node()
+	if !ok {
+		return false, fmt.Errorf("dialogue graph %s: unknown node %q", c.Graph, c.NodeID)
+	}
+	if len(c.Choices()) > 0 {
+		return c.Choose(scene, c.ChoiceIndex)
+	}
+	return c.follow(scene, node.next(c.Variables))
+}
+
+// Choose picks one of the choices returned by Choices
+func (c *Conversation) Choose(scene blueprint.Scene, index int) (bool, error) {
+	choices := c.Choices()
+	if index < 0 || index >= len(choices) {
+		return false, fmt.Errorf("dialogue graph %s: node %s has no choice %d", c.Graph, c.NodeID, index)
+	}
+	applyAll(choices[index].Set, c.variables())
+	return c.follow(scene, choices[index].Next)
+}
+
+func (c *Conversation) node() (*Node, bool) {
+	graph, ok := GraphRegistry[c.Graph]
+	if !ok || c.Ended {
+		return nil, false
+	}
+	return graph.Node(c.NodeID)
+}
+
+func (c *Conversation) follow(scene blueprint.Scene, next string) (bool, error) {
+	if next == "" {
+		return false, c.end(scene)
+	}
+	return true, c.enter(scene, GraphRegistry[c.Graph], next)
+}
+
+// enter makes the node current, applying its assignments and callback
+func (c *Conversation) enter(scene blueprint.Scene, graph *Graph, id string) error {
+	node, ok := graph.Node(id)
+	if !ok {
+		return fmt.Errorf("dialogue graph %s: unknown node %q", graph.Name, id)
+	}
+	c.NodeID = id
+	c.ChoiceIndex = 0
+	applyAll(node.Set, c.variables())
+	if node.Callback == nil {
+		return nil
+	}
+	callback, ok := CallbackRegistry[*node.Callback]
+	if !ok {
+		return fmt.Errorf("dialogue graph %s: node %s has unknown callback %d", graph.Name, id, *node.Callback)
+	}
+	return callback(scene)
+}
+
+// variables returns Variables, creating the map on first write
+func (c *Conversation) variables() map[string]float64 {
+	if c.Variables == nil {
+		c.Variables = make(map[string]float64)
+	}
+	return c.Variables
+}
+
+// end finishes the conversation and runs its callback, if registered
+func (c *Conversation) end(scene blueprint.Scene) error {
+	c.Ended = true
+	if callback, ok := CallbackRegistry[c.CallbackID]; ok {
+		return callback(scene)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package dialogue
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/environment"
+)
+
+// GraphCollection is the JSON format of LoadGraphsFromJSON
+type GraphCollection struct {
+	Graphs []Graph `json:"graphs"`
+}
+
+// Graph is a branching conversation
+//
+// Each node shows one line of text like a Slide. After it, the conversation either waits for one of
+// the node's choices, or follows the first branch whose conditions hold, falling back to Next.
+// A node without a way forward ends the conversation
+type Graph struct {
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	Nodes []Node `json:"nodes"`
+
+	nodes map[string]int
+}
+
+// Node is a single line of a Graph
+type Node struct {
+	ID               string       `json:"id"`
+	OwnerName        string       `json:"owner,omitempty"`
+	PortraitID       PortraitEnum `json:"portrait,omitempty"`
+	Text             string       `json:"text"`
+	CustomSpeedTicks int          `json:"speed,omitempty"`
+
+	// TextKey and OwnerKey are locale keys that replace Text and OwnerName when set
+	TextKey  string `json:"textKey,omitempty"`
+	OwnerKey string `json:"ownerKey,omitempty"`
+
+	// Set is applied to the conversation's Variables when the node is entered
+	Set []Assignment `json:"set,omitempty"`
+	// Callback runs from CallbackRegistry when the node is entered, if set
+	Callback *CallbackEnum `json:"callback,omitempty"`
+
+	Choices  []Choice `json:"choices,omitempty"`
+	Branches []Branch `json:"branches,omitempty"`
+	Next     string   `json:"next,omitempty"`
+}
+
+// Choice is an answer the player picks to continue the conversation
+type Choice struct {
+	Text string `json:"text"`
+	// TextKey is a locale key that replaces Text when set
+	TextKey string `json:"textKey,omitempty"`
+	// Action is the name of an input action that picks the choice directly
+	Action string `json:"action,omitempty"`
+	// Conditions hide the choice unless they all hold
+	Conditions []Condition `json:"conditions,omitempty"`
+	// Set is applied to the conversation's Variables when the choice is picked
+	Set  []Assignment `json:"set,omitempty"`
+	Next string       `json:"next,omitempty"`
+}
+
+// Branch jumps to Next when all its conditions hold
+type Branch struct {
+	Conditions []Condition `json:"conditions,omitempty"`
+	Next       string      `json:"next"`
+}
+
+// Condition compares a variable against Value using Op ("==", "!=", "<", "<=", ">", ">=")
+type Condition struct {
+	Var   string  `json:"var"`
+	Op    string  `json:"op"`
+	Value float64 `json:"value"`
+}
+
+// Assignment changes a variable using Op ("=", "+=", "-=")
+type Assignment struct {
+	Var   string  `json:"var"`
+	Op    string  `json:"op"`
+	Value float64 `json:"value"`
+}
+
+// GraphRegistry holds the graphs available to conversations
+var GraphRegistry map[string]*Graph = map[string]*Graph{}
+
+// RegisterGraphs validates the graphs and adds them to GraphRegistry
+func RegisterGraphs(graphs ...Graph) error {
+	for i := range graphs {
+		graph := graphs[i]
+		if err := graph.compile(); err != nil {
+			return err
+		}
+		GraphRegistry[graph.Name] = &graph
+	}
+	return nil
+}
+
+// LoadGraphsFromJSON reads graphs from a JSON file
+// Like other loaders, it reads from the embedded filesystem in production and WASM builds
+func LoadGraphsFromJSON(filename, path string, fs embed.FS) (*GraphCollection, error) {
+	data, err := readDialogueFile(filename, path, fs)
+	if err != nil {
+		return nil, err
+	}
+	collection := &GraphCollection{}
+	if err := json.Unmarshal(data, collection); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dialogue graphs %s: %w", filename, err)
+	}
+	return collection, nil
+}
+
+func readDialogueFile(filename, path string, fs embed.FS) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if environment.IsProd() || environment.IsWASM() {
+		data, err = fs.ReadFile(filename)
+	} else {
+		data, err = os.ReadFile(path + filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dialogue file %s: %w", filename, err)
+	}
+	return data, nil
+}
+
+var conditionOps = map[string]func(a, b float64) bool{
+	"==": func(a, b float64) bool { return a == b },
+	"!=": func(a, b float64) bool { return a != b },
+	"<":  func(a, b float64) bool { return a < b },
+	"<=": func(a, b float64) bool { return a <= b },
+	">":  func(a, b float64) bool { return a > b },
+	">=": func(a, b float64) bool { return a >= b },
+}
+
+var assignmentOps = map[string]func(a, b float64) float64{
+	"=":  func(a, b float64) float64 { return b },
+	"+=": func(a, b float64) float64 { return a + b },
+	"-=": func(a, b float64) float64 { return a - b },
+}
+
+// Node returns the node with the given ID
+func (g *Graph) Node(id string) (*Node, bool) {
+	i, ok := g.nodes[id]
+	if !ok {
+		return nil, false
+	}
+	return &g.Nodes[i], true
+}
+
+// compile indexes the nodes and checks every reference
+func (g *Graph) compile() error {
+	if g.Name == "" {
+		return fmt.Errorf("dialogue graph is missing a name")
+	}
+	g.nodes = make(map[string]int, len(g.Nodes))
+	for i, node := range g.Nodes {
+		if node.ID == "" {
+			return fmt.Errorf("dialogue graph %s: node %d has no id", g.Name, i)
+		}
+		if _, dup := g.nodes[node.ID]; dup {
+			return fmt.Errorf("dialogue graph %s: duplicate node %q", g.Name, node.ID)
+		}
+		g.nodes[node.ID] = i
+	}
+	if _, ok := g.nodes[g.Start]; !ok {
+		return fmt.Errorf("dialogue graph %s: unknown start node %q", g.Name, g.Start)
+	}
+
+	target := func(node Node, next string) error {
+		if _, ok := g.nodes[next]; next != "" && !ok {
+			return fmt.Errorf("dialogue graph %s: node %s jumps to unknown node %q", g.Name, node.ID, next)
+		}
+		return nil
+	}
+	for _, node := range g.Nodes {
+		if err := g.checkAssignments(node.Set); err != nil {
+			return err
+		}
+		if err := target(node, node.Next); err != nil {
+			return err
+		}
+		for _, branch := range node.Branches {
+			if err := g.checkConditions(branch.Conditions); err != nil {
+				return err
+			}
+			if err := target(node, branch.Next); err != nil {
+				return err
+			}
+		}
+		for _, choice := range node.Choices {
+			if _, ok := input.ActionByName(choice.Action); choice.Action != "" && !ok {
+				return fmt.Errorf("dialogue graph %s: unknown action %q", g.Name, choice.Action)
+			}
+			if err := g.checkConditions(choice.Conditions); err != nil {
+				return err
+			}
+			if err := g.checkAssignments(choice.Set); err != nil {
+				return err
+			}
+			if err := target(node, choice.Next); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Graph) checkConditions(conditions []Condition) error {
+	for _, cond := range conditions {
+		if _, ok := conditionOps[cond.Op]; !ok || cond.Var == "" {
+			return fmt.Errorf("dialogue graph %s: invalid condition %s %s %v", g.Name, cond.Var, cond.Op, cond.Value)
+		}
+	}
+	return nil
+}
+
+func (g *Graph) checkAssignments(assignments []Assignment) error {
+	for _, set := range assignments {
+		if _, ok := assignmentOps[set.Op]; !ok || set.Var == "" {
+			return fmt.Errorf("dialogue graph %s: invalid assignment %s %s %v", g.Name, set.Var, set.Op, set.Value)
+		}
+	}
+	return nil
+}
+
+// Holds reports whether the condition holds for the variables (missing variables are 0)
+func (c Condition) Holds(vars map[string]float64) bool {
+	return conditionOps[c.Op](vars[c.Var], c.Value)
+}
+
+// Apply updates the variables
+func (a Assignment) Apply(vars map[string]float64) {
+	vars[a.Var] = assignmentOps[a.Op](vars[a.Var], a.Value)
+}
+
+// Available reports whether the choice's conditions hold for the variables
+func (c Choice) Available(vars map[string]float64) bool {
+	return allHold(c.Conditions, vars)
+}
+
+// Slide returns what the node shows, in the format of linear conversations (before localization)
+func (n Node) Slide() Slide {
+	return Slide{
+		OwnerName:        n.OwnerName,
+		PortraitID:       n.PortraitID,
+		Text:             n.Text,
+		CustomSpeedTicks: n.CustomSpeedTicks,
+		TextKey:          n.TextKey,
+		OwnerKey:         n.OwnerKey,
+	}
+}
+
+// next returns the node to follow when the node has no choice to make
+func (n Node) next(vars map[string]float64) string {
+	for _, branch := range n.Branches {
+		if allHold(branch.Conditions, vars) {
+			return branch.Next
+		}
+	}
+	return n.Next
+}
+
+func allHold(conditions []Condition, vars map[string]float64) bool {
+	for _, cond := range conditions {
+		if !cond.Holds(vars) {
+			return false
+		}
+	}
+	return true
+}
+
+func applyAll(assignments []Assignment, vars map[string]float64) {
+	for _, set := range assignments {
+		set.Apply(vars)
+	}
+}
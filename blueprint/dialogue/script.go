@@ -0,0 +1,343 @@
+package dialogue
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseScript reads a graph from the dialogue script format, a small Ink-like text format:
+//
+//	# start
+//	Guard@2: Halt! Who goes there?
+//	~ met_guard = true
+//	* [choice_1] {reputation >= 2} A friend. ~ reputation += 1 -> friendly
+//	* None of your business. -> hostile
+//
+//	# friendly
+//	Guard: Welcome back.
+//	{gold >= 10} -> rich
+//	Guard: Move along.
+//	!callback 3
+//
+// "# id" starts a node; the first section is the start of the graph. Each other line is a line of
+// text, optionally "Speaker: text" or "Speaker@portrait: text" (a leading ":" is narration), which
+// continues to the next line of the section. Speakers are single identifiers (letters, digits and "_"),
+// so a colon later in the text does not split it. A speaker or text of the form "$key" is a locale key.
+// The following attach to the most recent line:
+//   - "* " adds a choice with an optional [action], {conditions}, "~" assignments and "-> target"
+//   - "~ " adds assignments applied when the line is shown, separated by ";"
+//   - "-> target" jumps once the line is done, "{conditions} -> target" only when they hold
+//   - "!callback n" runs CallbackRegistry[n] when the line is shown
+//
+// Text that would read as one of these, like "* sigh *", or as a speaker, like "Note: ...", is escaped
+// with a leading backslash, which makes the rest of the line plain narration.
+// Conditions are comma separated comparisons ("gold >= 10"), "flag" or "!flag".
+// Blank lines and lines starting with "//" are ignored
+func ParseScript(name string, data []byte) (*Graph, error) {
+	graph := &Graph{Name: name}
+	var (
+		section string
+		lines   int
+		last    *Node
+	)
+	fail := func(n int, format string, args ...any) error {
+		return fmt.Errorf("dialogue script %s:%d: %s", name, n, fmt.Sprintf(format, args...))
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		if id, ok := strings.CutPrefix(line, "#"); ok {
+			if section != "" && lines == 0 {
+				return nil, fail(n, "node %s has no lines", section)
+			}
+			section, lines, last = strings.TrimSpace(id), 0, nil
+			if section == "" {
+				return nil, fail(n, "missing node id")
+			}
+			if graph.Start == "" {
+				graph.Start = section
+			}
+			continue
+		}
+		if section == "" {
+			return nil, fail(n, "line outside of a node")
+		}
+
+		directive := scriptDirective(line)
+		if directive == "" {
+			lines++
+			node := parseScriptLine(line)
+			node.ID = section
+			if lines > 1 {
+				node.ID = fmt.Sprintf("%s.%d", section, lines)
+			}
+			if last != nil && last.Next == "" && len(last.Choices) == 0 {
+				last.Next = node.ID
+			}
+			graph.Nodes = append(graph.Nodes, node)
+			last = &graph.Nodes[len(graph.Nodes)-1]
+			continue
+		}
+		if last == nil {
+			return nil, fail(n, "%q before the first line of node %s", line, section)
+		}
+
+		var err error
+		switch directive {
+		case "*":
+			var choice Choice
+			choice, err = parseScriptChoice(strings.TrimSpace(line[1:]))
+			last.Choices = append(last.Choices, choice)
+		case "~":
+			var sets []Assignment
+			sets, err = parseAssignments(line[1:])
+			last.Set = append(last.Set, sets...)
+		case "->":
+			last.Next = strings.TrimSpace(line[2:])
+		case "{":
+			var branch Branch
+			branch, err = parseScriptBranch(line)
+			last.Branches = append(last.Branches, branch)
+		default:
+			var id int
+			id, err = strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "!callback")))
+			callback := CallbackEnum(id)
+			last.Callback = &callback
+		}
+		if err != nil {
+			return nil, fail(n, "%v", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dialogue script %s: %w", name, err)
+	}
+	if section != "" && lines == 0 {
+		return nil, fmt.Errorf("dialogue script %s: node %s has no lines", name, section)
+	}
+	return graph, nil
+}
+
+// LoadGraphFromScript reads a dialogue script file, naming the graph after the file (without a .dlg extension)
+func LoadGraphFromScript(filename, path string, fs embed.FS) (*Graph, error) {
+	data, err := readDialogueFile(filename, path, fs)
+	if err != nil {
+		return nil, err
+	}
+	return ParseScript(strings.TrimSuffix(filepath.Base(filename), ".dlg"), data)
+}
+
+// scriptDirective returns the directive a line starts with, or "" for a line of text
+// Choices and assignments need a space after their marker and branches a jump, so text like
+// "*sigh*", "~la la~" or "{whispering} ..." stays text
+func scriptDirective(line string) string {
+	switch {
+	case strings.HasPrefix(line, "* "), line == "*":
+		return "*"
+	case strings.HasPrefix(line, "~ "), line == "~":
+		return "~"
+	case strings.HasPrefix(line, "->"):
+		return "->"
+	case strings.HasPrefix(line, "{"):
+		if _, rest, ok := strings.Cut(line, "}"); ok && strings.HasPrefix(strings.TrimSpace(rest), "->") {
+			return "{"
+		}
+	case strings.HasPrefix(line, "!callback"):
+		return "!callback"
+	}
+	return ""
+}
+
+// parseScriptLine splits the optional speaker and portrait from a line of text
+// A leading backslash escapes the line, keeping all of it as narration
+func parseScriptLine(line string) Node {
+	if escaped, ok := strings.CutPrefix(line, `\`); ok {
+		return Node{Text: strings.TrimSpace(escaped)}
+	}
+	speaker, text, ok := strings.Cut(line, ":")
+	if !ok || !isScriptSpeaker(strings.TrimSpace(speaker)) {
+		speaker, text = "", line
+	}
+	node := Node{Text: strings.TrimSpace(text)}
+	if key, ok := scriptKey(node.Text); ok {
+		node.Text, node.TextKey = "", key
+	}
+	speaker = strings.TrimSpace(speaker)
+	if name, portrait, ok := strings.Cut(speaker, "@"); ok {
+		if id, err := strconv.Atoi(strings.TrimSpace(portrait)); err == nil {
+			speaker = strings.TrimSpace(name)
+			node.PortraitID = PortraitEnum(id)
+		}
+	}
+	node.OwnerName = speaker
+	if key, ok := scriptKey(node.OwnerName); ok {
+		node.OwnerName, node.OwnerKey = "", key
+	}
+	return node
+}
+
+// isScriptSpeaker reports whether s can be the speaker of a line: empty for narration, or an
+// identifier or "$" locale key, with an optional "@portrait" number
+func isScriptSpeaker(s string) bool {
+	if s == "" {
+		return true
+	}
+	name, portrait, ok := strings.Cut(s, "@")
+	if ok {
+		if _, err := strconv.Atoi(strings.TrimSpace(portrait)); err != nil {
+			return false
+		}
+		name = strings.TrimSpace(name)
+	}
+	key, isKey := strings.CutPrefix(name, "$")
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		// Locale keys flatten nested tables with dots
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && (r != '.' || !isKey) {
+			return false
+		}
+	}
+	return true
+}
+
+// scriptKey returns the locale key of a "$key" text
+func scriptKey(text string) (string, bool) {
+	key, ok := strings.CutPrefix(text, "$")
+	if !ok || key == "" || strings.ContainsAny(key, " \t") {
+		return "", false
+	}
+	return key, true
+}
+
+// parseScriptChoice reads "[action] {conditions} text ~ assignments -> target"
+func parseScriptChoice(s string) (Choice, error) {
+	choice := Choice{}
+	if before, target, ok := cutLast(s, "->"); ok {
+		s, choice.Next = before, strings.TrimSpace(target)
+	}
+	if before, sets, ok := strings.Cut(s, "~"); ok {
+		assignments, err := parseAssignments(sets)
+		if err != nil {
+			return choice, err
+		}
+		s, choice.Set = before, assignments
+	}
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "["); ok {
+		action, text, ok := strings.Cut(rest, "]")
+		if !ok {
+			return choice, fmt.Errorf("unclosed action in choice %q", s)
+		}
+		s, choice.Action = strings.TrimSpace(text), strings.TrimSpace(action)
+	}
+	if rest, ok := strings.CutPrefix(s, "{"); ok {
+		conds, text, ok := strings.Cut(rest, "}")
+		if !ok {
+			return choice, fmt.Errorf("unclosed condition in choice %q", s)
+		}
+		conditions, err := parseConditions(conds)
+		if err != nil {
+			return choice, err
+		}
+		s, choice.Conditions = strings.TrimSpace(text), conditions
+	}
+	choice.Text = s
+	if key, ok := scriptKey(s); ok {
+		choice.Text, choice.TextKey = "", key
+	}
+	if s == "" {
+		return choice, fmt.Errorf("choice has no text")
+	}
+	return choice, nil
+}
+
+// parseScriptBranch reads "{conditions} -> target"
+func parseScriptBranch(s string) (Branch, error) {
+	conds, rest, ok := strings.Cut(s[1:], "}")
+	if !ok {
+		return Branch{}, fmt.Errorf("unclosed condition %q", s)
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(rest), "->")
+	if !ok {
+		return Branch{}, fmt.Errorf("condition %q has no jump", s)
+	}
+	conditions, err := parseConditions(conds)
+	return Branch{Conditions: conditions, Next: strings.TrimSpace(target)}, err
+}
+
+func parseConditions(s string) ([]Condition, error) {
+	var conditions []Condition
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		fields := strings.Fields(part)
+		switch {
+		case len(fields) == 3:
+			value, err := parseScriptValue(fields[2])
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, Condition{Var: fields[0], Op: fields[1], Value: value})
+		case len(fields) == 1 && strings.HasPrefix(part, "!"):
+			conditions = append(conditions, Condition{Var: part[1:], Op: "==", Value: 0})
+		case len(fields) == 1:
+			conditions = append(conditions, Condition{Var: part, Op: "!=", Value: 0})
+		default:
+			return nil, fmt.Errorf("invalid condition %q", part)
+		}
+	}
+	return conditions, nil
+}
+
+func parseAssignments(s string) ([]Assignment, error) {
+	var assignments []Assignment
+	for _, part := range strings.Split(s, ";") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid assignment %q", strings.TrimSpace(part))
+		}
+		value, err := parseScriptValue(fields[2])
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, Assignment{Var: fields[0], Op: fields[1], Value: value})
+	}
+	return assignments, nil
+}
+
+func parseScriptValue(s string) (float64, error) {
+	switch s {
+	case "true":
+		return 1, nil
+	case "false":
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return value, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
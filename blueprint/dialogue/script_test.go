@@ -0,0 +1,203 @@
+package dialogue
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseScript(t *testing.T) {
+	callback := CallbackEnum(3)
+
+	tests := []struct {
+		name   string
+		script string
+		want   []Node
+	}{
+		{
+			name: "speakers, portraits and narration",
+			script: `# start
+Guard@2: Halt!
+: The wind howls.
+$guard.name: $greeting`,
+			want: []Node{
+				{ID: "start", OwnerName: "Guard", PortraitID: 2, Text: "Halt!", Next: "start.2"},
+				{ID: "start.2", Text: "The wind howls.", Next: "start.3"},
+				{ID: "start.3", OwnerKey: "guard.name", TextKey: "greeting"},
+			},
+		},
+		{
+			name: "colons after the speaker stay in the text",
+			script: `# start
+It's 10:30.
+Guard: Be back by 10:30.
+Old man: Hello.`,
+			want: []Node{
+				{ID: "start", Text: "It's 10:30.", Next: "start.2"},
+				{ID: "start.2", OwnerName: "Guard", Text: "Be back by 10:30.", Next: "start.3"},
+				{ID: "start.3", Text: "Old man: Hello."},
+			},
+		},
+		{
+			name: "text that looks like directives",
+			script: `# start
+*sigh* Fine.
+{whispering} Over here.
+~la la la~`,
+			want: []Node{
+				{ID: "start", Text: "*sigh* Fine.", Next: "start.2"},
+				{ID: "start.2", Text: "{whispering} Over here.", Next: "start.3"},
+				{ID: "start.3", Text: "~la la la~"},
+			},
+		},
+		{
+			name: "escaped lines",
+			script: `# start
+\* sigh *
+\Note: this is narration
+\-> not a jump`,
+			want: []Node{
+				{ID: "start", Text: "* sigh *", Next: "start.2"},
+				{ID: "start.2", Text: "Note: this is narration", Next: "start.3"},
+				{ID: "start.3", Text: "-> not a jump"},
+			},
+		},
+		{
+			name: "directives attach to the last line",
+			script: `# start
+// comments and blank lines are skipped
+
+Guard: Who goes there?
+~ met_guard = true; visits += 1
+* [choice_1] {reputation >= 2, !banned} A friend. ~ reputation += 1 -> friendly
+* $choice.none -> hostile
+!callback 3
+
+# friendly
+Guard: Welcome back.
+{gold >= 10} -> rich
+-> start
+
+# hostile
+Guard: Move along.
+
+# rich
+Guard: Nice purse.`,
+			want: []Node{
+				{
+					ID: "start", OwnerName: "Guard", Text: "Who goes there?",
+					Set: []Assignment{{Var: "met_guard", Op: "=", Value: 1}, {Var: "visits", Op: "+=", Value: 1}},
+					Choices: []Choice{
+						{
+							Text: "A friend.", Action: "choice_1", Next: "friendly",
+							Conditions: []Condition{{Var: "reputation", Op: ">=", Value: 2}, {Var: "banned", Op: "==", Value: 0}},
+							Set:        []Assignment{{Var: "reputation", Op: "+=", Value: 1}},
+						},
+						{TextKey: "choice.none", Next: "hostile"},
+					},
+					Callback: &callback,
+				},
+				{
+					ID: "friendly", OwnerName: "Guard", Text: "Welcome back.", Next: "start",
+					Branches: []Branch{{Conditions: []Condition{{Var: "gold", Op: ">=", Value: 10}}, Next: "rich"}},
+				},
+				{ID: "hostile", OwnerName: "Guard", Text: "Move along."},
+				{ID: "rich", OwnerName: "Guard", Text: "Nice purse."},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph, err := ParseScript("test", []byte(tt.script))
+			if err != nil {
+				t.Fatalf("ParseScript failed: %v", err)
+			}
+			if graph.Name != "test" || graph.Start != tt.want[0].ID {
+				t.Errorf("Graph %q starts at %q, want test starting at %q", graph.Name, graph.Start, tt.want[0].ID)
+			}
+			if len(graph.Nodes) != len(tt.want) {
+				t.Fatalf("Got %d nodes %+v, want %d", len(graph.Nodes), graph.Nodes, len(tt.want))
+			}
+			for i := range tt.want {
+				if !reflect.DeepEqual(graph.Nodes[i], tt.want[i]) {
+					t.Errorf("Node %d = %+v, want %+v", i, graph.Nodes[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"line outside of a node", "Guard: Hi", "line outside of a node"},
+		{"missing node id", "#\nHi", "missing node id"},
+		{"empty node", "# a\n# b\nHi", "node a has no lines"},
+		{"empty last node", "# a\nHi\n# b", "node b has no lines"},
+		{"directive before a line", "# a\n-> b", "before the first line"},
+		{"bad callback", "# a\nHi\n!callback x", "test:3"},
+		{"bad assignment", "# a\nHi\n~ gold += 1 2", "invalid assignment"},
+		{"bad value", "# a\nHi\n~ gold = lots", "invalid value"},
+		{"unclosed action", "# a\nHi\n* [act Yes", "unclosed action"},
+		{"unclosed choice condition", "# a\nHi\n* {gold > 1 Yes", "unclosed condition"},
+		{"choice without text", "# a\nHi\n* -> b", "choice has no text"},
+		{"bad condition", "# a\nHi\n{gold >} -> b", "invalid condition"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScript("test", []byte(tt.script))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseScript error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestConversationVariables tests that conditions and assignments use the conversation's own variables
+func TestConversationVariables(t *testing.T) {
+	graph, err := ParseScript("variables_test", []byte(`# start
+Guard: Hello.
+~ visits += 1
+{visits >= 2} -> again
+-> start
+
+# again
+Guard: You again.`))
+	if err != nil {
+		t.Fatalf("ParseScript failed: %v", err)
+	}
+	if err := RegisterGraphs(*graph); err != nil {
+		t.Fatalf("RegisterGraphs failed: %v", err)
+	}
+	defer delete(GraphRegistry, graph.Name)
+
+	a := Conversation{Graph: graph.Name}
+	b := Conversation{Graph: graph.Name}
+	if err := a.Start(nil); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := b.Start(nil); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if _, err := a.Advance(nil); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if a.Variables["visits"] != 2 || b.Variables["visits"] != 1 {
+		t.Errorf("visits = %v and %v, want 2 and 1", a.Variables["visits"], b.Variables["visits"])
+	}
+	if _, err := a.Advance(nil); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if a.NodeID != "again" {
+		t.Errorf("Conversation at %q, want again", a.NodeID)
+	}
+
+	clone := a.CloneComponent().(Conversation)
+	clone.Variables["visits"] = 10
+	if a.Variables["visits"] == 10 {
+		t.Error("Cloned conversation shares its variables")
+	}
+}
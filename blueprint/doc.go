@@ -21,6 +21,8 @@ Blueprint organizes components by domain into several subpackages:
 
   - blueprint/client: Visual and audio components (sprites, animations, sounds)
   - blueprint/input: User interaction components (input/action buffers)
+  - blueprint/locale: String tables keyed by IDs with locale switching and fallbacks
+  - blueprint/tween: Tick-based tweening of positions, scales, rotations and component fields
   - blueprint/vector: 2D vector mathematics utilities
 
 # Working with Queries
@@ -4,12 +4,12 @@ go 1.24.1
 
 require (
 	github.com/TheBitDrifter/bappa/environment v0.0.0-20250805064827-5d0801e0a7d5
+	github.com/TheBitDrifter/bappa/table v0.0.0-20250827171242-3f6179875b16
 	github.com/TheBitDrifter/bappa/tteokbokki v0.0.0-20250805064827-5d0801e0a7d5
 	github.com/TheBitDrifter/bappa/warehouse v0.0.0-20250827171242-3f6179875b16
 )
 
 require (
-	github.com/TheBitDrifter/bappa/table v0.0.0-20250827171242-3f6179875b16 // indirect
 	github.com/TheBitDrifter/bark v0.0.0-20250302175939-26104a815ed9 // indirect
 	github.com/TheBitDrifter/mask v0.0.1-early-alpha.1 // indirect
 	github.com/TheBitDrifter/util v0.0.0-20241102212109-342f4c0a810e // indirect
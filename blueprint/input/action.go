@@ -1,5 +1,7 @@
 package input
 
+import "github.com/TheBitDrifter/bappa/blueprint/vector"
+
 // nextAction stores the counter for generating sequential action identifiers
 var nextAction Action = 0
 
@@ -12,9 +14,17 @@ type StampedAction struct {
 	Val            Action // The action identifier
 	X, Y           int    // Screen coordinates where the action occurred
 	LocalX, LocalY int    // Position relative to an entity's camera view
+	Analog         Analog // Analog payload for stick and axis actions, zero for digital actions
+}
+
+// Analog holds the continuous value of an analog action after deadzone and response curve processing
+type Analog struct {
+	Value  float64    // Axis value or stick magnitude in [0, 1] (or [-1, 1] for a single axis)
+	Vector vector.Two // Stick direction scaled by Value, with Y pointing up like the X/Y stick values
 }
 
 // NewAction generates a new unique Input identifier
+// IDs depend on declaration order, prefer RegisterAction when client and server must agree
 func NewAction() Action {
 	action := nextAction
 	nextAction++
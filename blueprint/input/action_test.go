@@ -0,0 +1,66 @@
+package input
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+)
+
+// TestAnalogSurvivesSerialization tests that analog payloads reach the server intact
+func TestAnalogSurvivesSerialization(t *testing.T) {
+	move, jump := NewAction(), NewAction()
+	msg := ClientActionMessage{
+		ReceiverIndex: 1,
+		Actions: []StampedAction{
+			{Tick: 7, Val: move, X: 60, Y: -80, Analog: Analog{Value: 1, Vector: vector.Two{X: 0.6, Y: -0.8}}},
+			{Tick: 7, Val: jump},
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var got ClientActionMessage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if got.ReceiverIndex != msg.ReceiverIndex || len(got.Actions) != len(msg.Actions) {
+		t.Fatalf("Got %+v, want %+v", got, msg)
+	}
+	for i := range msg.Actions {
+		if got.Actions[i] != msg.Actions[i] {
+			t.Errorf("Action %d = %+v, want %+v", i, got.Actions[i], msg.Actions[i])
+		}
+	}
+	if got.Actions[1].Analog != (Analog{}) {
+		t.Errorf("Digital action gained an analog payload: %+v", got.Actions[1].Analog)
+	}
+}
+
+// TestActionBufferKeepsAnalog tests that deduplication keeps the payload of the most recent action
+func TestActionBufferKeepsAnalog(t *testing.T) {
+	move := NewAction()
+	older := StampedAction{Tick: 1, Val: move, Analog: Analog{Value: 0.2, Vector: vector.Two{X: 0.2}}}
+	newer := StampedAction{Tick: 2, Val: move, Analog: Analog{Value: 0.9, Vector: vector.Two{Y: 0.9}}}
+
+	buffer := ActionBuffer{}
+	buffer.Add(newer)
+	buffer.Add(older)
+	if len(buffer.Values) != 1 || buffer.Values[0].Analog != newer.Analog {
+		t.Errorf("Add kept %+v, want the payload of tick 2", buffer.Values)
+	}
+
+	batched := ActionBuffer{}
+	batched.AddBatch([]StampedAction{older, newer})
+	if len(batched.Values) != 1 || batched.Values[0].Analog != newer.Analog {
+		t.Errorf("AddBatch kept %+v, want the payload of tick 2", batched.Values)
+	}
+
+	consumed, ok := batched.ConsumeAction(move)
+	if !ok || consumed.Analog != newer.Analog {
+		t.Errorf("ConsumeAction returned %+v, want the payload of tick 2", consumed)
+	}
+}
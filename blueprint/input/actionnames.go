@@ -0,0 +1,201 @@
+package input
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ActionKind describes which edge of an input triggers an action
+type ActionKind int
+
+const (
+	// ActionHeld fires every tick while the input is down
+	ActionHeld ActionKind = iota
+	// ActionPressed fires on the tick the input goes down
+	ActionPressed
+	// ActionReleased fires on the tick the input goes up
+	ActionReleased
+)
+
+func (k ActionKind) String() string {
+	switch k {
+	case ActionPressed:
+		return "pressed"
+	case ActionReleased:
+		return "released"
+	}
+	return "held"
+}
+
+// ActionInfo is the metadata of an action registered with RegisterAction
+type ActionInfo struct {
+	ID     Action     `json:"id"`
+	Name   string     `json:"name"`
+	Kind   ActionKind `json:"kind"`
+	Analog bool       `json:"analog"`
+}
+
+// ActionOption configures the metadata of a registered action
+type ActionOption func(*ActionInfo)
+
+// WithKind sets which input edge triggers the action
+func WithKind(kind ActionKind) ActionOption {
+	return func(info *ActionInfo) { info.Kind = kind }
+}
+
+// WithAnalog marks the action as carrying an Analog payload
+func WithAnalog() ActionOption {
+	return func(info *ActionInfo) { info.Analog = true }
+}
+
+// registeredActionBit keeps name derived IDs clear of the sequential IDs handed out by NewAction
+const registeredActionBit Action = 1 << 31
+
+// actionNames maps actions and their names in both directions
+var actionNames = struct {
+	sync.RWMutex
+	byName   map[string]Action
+	byAction map[Action]string
+	infos    map[Action]ActionInfo
+}{
+	byName:   make(map[string]Action),
+	byAction: make(map[Action]string),
+	infos:    make(map[Action]ActionInfo),
+}
+
+// RegisterAction returns the action for the name, registering it on first use
+//
+// The ID is derived from the name, so client and server agree on it regardless of declaration order.
+// Registering the same name again returns the same action. It panics if the name hashes to the ID of a
+// different action, which is a programming error to resolve by renaming, or if the options differ from
+// the first registration, since client and server compare this metadata
+func RegisterAction(name string, opts ...ActionOption) Action {
+	info := ActionInfo{ID: actionIDFor(name), Name: name}
+	for _, opt := range opts {
+		opt(&info)
+	}
+
+	actionNames.Lock()
+	defer actionNames.Unlock()
+	if existing, ok := actionNames.byAction[info.ID]; ok && existing != name {
+		panic(fmt.Sprintf("action %q collides with %q (id %d)", name, existing, info.ID))
+	}
+	if existing, ok := actionNames.byName[name]; ok && existing != info.ID {
+		panic(fmt.Sprintf("action name %q is already used by action %d", name, existing))
+	}
+	if existing, ok := actionNames.infos[info.ID]; ok && existing != info {
+		panic(fmt.Sprintf("action %q registered again with %s, first registered with %s", name, info.describe(), existing.describe()))
+	}
+	actionNames.byName[name] = info.ID
+	actionNames.byAction[info.ID] = name
+	actionNames.infos[info.ID] = info
+	return info.ID
+}
+
+// actionIDFor derives a stable action ID from a name
+func actionIDFor(name string) Action {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return Action(h.Sum32()) | registeredActionBit
+}
+
+// NameAction associates a name with an action so input profiles and logs can refer to it
+// Returns an error if the name is already taken by a different action, or if the action was
+// registered with RegisterAction, whose name is fixed by its ID
+func NameAction(action Action, name string) error {
+	actionNames.Lock()
+	defer actionNames.Unlock()
+	if info, ok := actionNames.infos[action]; ok {
+		return fmt.Errorf("action %d is registered as %q and cannot be renamed", action, info.Name)
+	}
+	if existing, ok := actionNames.byName[name]; ok && existing != action {
+		return fmt.Errorf("action name %q is already used by action %d", name, existing)
+	}
+	if previous, ok := actionNames.byAction[action]; ok {
+		delete(actionNames.byName, previous)
+	}
+	actionNames.byName[name] = action
+	actionNames.byAction[action] = name
+	return nil
+}
+
+// ActionByName returns the action registered under the name
+func ActionByName(name string) (Action, bool) {
+	actionNames.RLock()
+	defer actionNames.RUnlock()
+	action, ok := actionNames.byName[name]
+	return action, ok
+}
+
+// ActionName returns the name of the action, if it has one
+func ActionName(action Action) (string, bool) {
+	actionNames.RLock()
+	defer actionNames.RUnlock()
+	name, ok := actionNames.byAction[action]
+	return name, ok
+}
+
+// Info returns the metadata of an action registered with RegisterAction
+func (a Action) Info() (ActionInfo, bool) {
+	actionNames.RLock()
+	defer actionNames.RUnlock()
+	info, ok := actionNames.infos[a]
+	return info, ok
+}
+
+// String returns the action's name, or its number when it has none
+func (a Action) String() string {
+	if name, ok := ActionName(a); ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(a), 10)
+}
+
+// RegisteredActions returns the metadata of every action registered with RegisterAction, sorted by name
+func RegisteredActions() []ActionInfo {
+	actionNames.RLock()
+	defer actionNames.RUnlock()
+	infos := make([]ActionInfo, 0, len(actionNames.infos))
+	for _, info := range actionNames.infos {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+func (info ActionInfo) describe() string {
+	return fmt.Sprintf("id=%d kind=%s analog=%v", uint32(info.ID), info.Kind, info.Analog)
+}
+
+// CompareActions checks a remote registry (usually the server's) against the local one
+// Returns an error describing every action that is missing on either side or registered differently
+func CompareActions(remote []ActionInfo) error {
+	local := map[string]ActionInfo{}
+	for _, info := range RegisteredActions() {
+		local[info.Name] = info
+	}
+
+	problems := []string{}
+	for _, r := range remote {
+		l, ok := local[r.Name]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s missing locally", r.Name))
+		case l != r:
+			problems = append(problems, fmt.Sprintf("%s differs (local %s, remote %s)", r.Name, l.describe(), r.describe()))
+		}
+		delete(local, r.Name)
+	}
+	for name := range local {
+		problems = append(problems, fmt.Sprintf("%s missing remotely", name))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("action registries differ: %s", strings.Join(problems, "; "))
+}
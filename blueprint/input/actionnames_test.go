@@ -0,0 +1,172 @@
+package input
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// resetActionNames clears the registered and named actions for a test
+func resetActionNames(t *testing.T) {
+	t.Helper()
+	empty := func() {
+		actionNames.Lock()
+		defer actionNames.Unlock()
+		actionNames.byName = make(map[string]Action)
+		actionNames.byAction = make(map[Action]string)
+		actionNames.infos = make(map[Action]ActionInfo)
+	}
+	empty()
+	t.Cleanup(empty)
+}
+
+func TestRegisterAction(t *testing.T) {
+	resetActionNames(t)
+	jump := RegisterAction("jump", WithKind(ActionPressed))
+	move := RegisterAction("move", WithAnalog())
+
+	if jump == move {
+		t.Fatalf("jump and move share id %d", jump)
+	}
+	for _, action := range []Action{jump, move} {
+		if action&registeredActionBit == 0 {
+			t.Errorf("Action %d is missing the registered bit", action)
+		}
+	}
+	if again := RegisterAction("jump", WithKind(ActionPressed)); again != jump {
+		t.Errorf("Registering jump again = %d, want %d", again, jump)
+	}
+	if got, ok := ActionByName("move"); !ok || got != move {
+		t.Errorf("ActionByName(move) = %d, %v, want %d", got, ok, move)
+	}
+	if jump.String() != "jump" {
+		t.Errorf("String = %q, want jump", jump.String())
+	}
+
+	info, ok := move.Info()
+	if want := (ActionInfo{ID: move, Name: "move", Kind: ActionHeld, Analog: true}); !ok || info != want {
+		t.Errorf("Info = %+v, %v, want %+v", info, ok, want)
+	}
+	want := []ActionInfo{
+		{ID: jump, Name: "jump", Kind: ActionPressed},
+		{ID: move, Name: "move", Analog: true},
+	}
+	if got := RegisteredActions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RegisteredActions = %+v, want %+v", got, want)
+	}
+}
+
+// TestRegisterActionStableIDs tests that IDs depend only on the name, not on registration order
+func TestRegisterActionStableIDs(t *testing.T) {
+	resetActionNames(t)
+	first := []Action{RegisterAction("a"), RegisterAction("b")}
+	resetActionNames(t)
+	second := []Action{RegisterAction("b"), RegisterAction("a")}
+	if first[0] != second[1] || first[1] != second[0] {
+		t.Errorf("IDs changed with registration order: %v and %v", first, second)
+	}
+	if first[0] != actionIDFor("a") {
+		t.Errorf("RegisterAction(a) = %d, want %d", first[0], actionIDFor("a"))
+	}
+	// FNV-1a of "jump" with the registered bit, so the wire format does not change silently
+	if got, want := actionIDFor("jump"), Action(0xa73f5c0d); got != want {
+		t.Errorf("actionIDFor(jump) = %#x, want %#x", uint32(got), uint32(want))
+	}
+}
+
+func TestRegisterActionNameTaken(t *testing.T) {
+	resetActionNames(t)
+	if err := NameAction(NewAction(), "fire"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterAction with a name used by NameAction did not panic")
+		}
+	}()
+	RegisterAction("fire")
+}
+
+func TestRegisterActionConflictingOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ActionOption
+	}{
+		{"different kind", []ActionOption{WithKind(ActionReleased)}},
+		{"analog added", []ActionOption{WithKind(ActionPressed), WithAnalog()}},
+		{"options dropped", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetActionNames(t)
+			RegisterAction("jump", WithKind(ActionPressed))
+			defer func() {
+				if recover() == nil {
+					t.Error("RegisterAction with conflicting options did not panic")
+				}
+				if info, _ := actionIDFor("jump").Info(); info.Kind != ActionPressed || info.Analog {
+					t.Errorf("Conflicting registration changed the metadata to %+v", info)
+				}
+			}()
+			RegisterAction("jump", tt.opts...)
+		})
+	}
+}
+
+func TestNameAction(t *testing.T) {
+	resetActionNames(t)
+	action := NewAction()
+	if err := NameAction(action, "old"); err != nil {
+		t.Fatal(err)
+	}
+	if err := NameAction(action, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ActionByName("old"); ok {
+		t.Error("Renamed action is still found by its old name")
+	}
+	if name, _ := ActionName(action); name != "new" {
+		t.Errorf("ActionName = %q, want new", name)
+	}
+	if err := NameAction(NewAction(), "new"); err == nil {
+		t.Error("NameAction reused a taken name")
+	}
+
+	registered := RegisterAction("dash")
+	if err := NameAction(registered, "sprint"); err == nil {
+		t.Error("NameAction renamed a registered action")
+	}
+	if info, _ := registered.Info(); info.Name != "dash" || registered.String() != "dash" {
+		t.Errorf("Registered action became %q (%s), want dash", info.Name, registered)
+	}
+}
+
+func TestCompareActions(t *testing.T) {
+	resetActionNames(t)
+	jump := RegisterAction("jump", WithKind(ActionPressed))
+	RegisterAction("move", WithAnalog())
+
+	if err := CompareActions(RegisteredActions()); err != nil {
+		t.Errorf("CompareActions with the same registry = %v", err)
+	}
+
+	remote := []ActionInfo{
+		{ID: jump, Name: "jump", Kind: ActionReleased},
+		{ID: actionIDFor("dash"), Name: "dash"},
+	}
+	err := CompareActions(remote)
+	if err == nil {
+		t.Fatal("CompareActions with a different registry succeeded")
+	}
+	id := strconv.FormatUint(uint64(jump), 10)
+	for _, want := range []string{
+		"dash missing locally",
+		"move missing remotely",
+		"jump differs (local id=" + id + " kind=pressed analog=false, remote id=" + id + " kind=released analog=false)",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("CompareActions error %q does not contain %q", err, want)
+		}
+	}
+}
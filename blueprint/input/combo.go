@@ -0,0 +1,151 @@
+package input
+
+// ComboStep is a single action of a combo
+type ComboStep struct {
+	Action Action
+	// Window is the maximum number of ticks since the previous step, zero allows any gap
+	// Ignored for the first step
+	Window int
+}
+
+// Combo is an ordered sequence of actions that emits a synthetic action when completed
+// Other actions may occur between the steps, as long as every step lands within its window
+type Combo struct {
+	Steps  []ComboStep
+	Output Action
+}
+
+// NewCombo creates a combo emitting output once the steps occur in order
+func NewCombo(output Action, steps ...ComboStep) Combo {
+	return Combo{Steps: steps, Output: output}
+}
+
+// Step creates a combo step that must follow the previous step within window ticks
+func Step(action Action, window int) ComboStep {
+	return ComboStep{Action: action, Window: window}
+}
+
+// ComboBuffer keeps an ordered history of actions alongside the ActionBuffer and matches combos against it
+//
+// Unlike ActionBuffer, the history is not deduplicated, so motion inputs such as down, down-forward, forward
+// survive in order. Actions older than MaxAge ticks (relative to the newest action) are pruned
+type ComboBuffer struct {
+	Combos  []Combo
+	History []StampedAction
+	MaxAge  int
+}
+
+// DefaultComboHistoryAge is the MaxAge used when a ComboBuffer leaves it unset
+const DefaultComboHistoryAge = 60
+
+// Feed records actions into the history and returns the synthetic actions of every combo they complete
+//
+// Each incoming action completes at most one combo. When several match, the one with the most steps wins,
+// with earlier combos breaking ties. The actions used by a completed combo are removed from the history
+// so the same inputs cannot fire it twice
+func (cb *ComboBuffer) Feed(actions []StampedAction) []StampedAction {
+	result := []StampedAction{}
+	for _, action := range actions {
+		cb.record(action)
+
+		var best *Combo
+		var bestUsed []int
+		for i := range cb.Combos {
+			combo := &cb.Combos[i]
+			if best != nil && len(combo.Steps) <= len(best.Steps) {
+				continue
+			}
+			if used, ok := cb.match(combo); ok {
+				best, bestUsed = combo, used
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		// Stamp the synthetic action like the combo's last step, which differs from the incoming
+		// action when a late input fills in an earlier step
+		synthetic := cb.History[bestUsed[0]]
+		synthetic.Val = best.Output
+		cb.remove(bestUsed)
+		result = append(result, synthetic)
+	}
+	return result
+}
+
+// Clear removes all actions from the history
+func (cb *ComboBuffer) Clear() {
+	cb.History = make([]StampedAction, 0)
+}
+
+// record inserts the action in tick order and prunes expired actions
+func (cb *ComboBuffer) record(action StampedAction) {
+	i := len(cb.History)
+	for i > 0 && cb.History[i-1].Tick > action.Tick {
+		i--
+	}
+	cb.History = append(cb.History, StampedAction{})
+	copy(cb.History[i+1:], cb.History[i:])
+	cb.History[i] = action
+
+	maxAge := cb.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultComboHistoryAge
+	}
+	newest := cb.History[len(cb.History)-1].Tick
+	expired := 0
+	for expired < len(cb.History) && newest-cb.History[expired].Tick > maxAge {
+		expired++
+	}
+	cb.History = cb.History[expired:]
+}
+
+// match checks whether the combo ends with the newest action of the history
+// Steps are matched backwards, always taking the latest candidate since that leaves the widest window
+// for the step before it. Returns the history indices used
+func (cb *ComboBuffer) match(combo *Combo) ([]int, bool) {
+	if len(combo.Steps) == 0 || len(cb.History) == 0 {
+		return nil, false
+	}
+	last := len(cb.History) - 1
+	if cb.History[last].Val != combo.Steps[len(combo.Steps)-1].Action {
+		return nil, false
+	}
+
+	used := []int{last}
+	next := last
+	for s := len(combo.Steps) - 2; s >= 0; s-- {
+		window := combo.Steps[s+1].Window
+		found := -1
+		for i := next - 1; i >= 0; i-- {
+			if window > 0 && cb.History[next].Tick-cb.History[i].Tick > window {
+				break
+			}
+			if cb.History[i].Val == combo.Steps[s].Action {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			return nil, false
+		}
+		used = append(used, found)
+		next = found
+	}
+	return used, true
+}
+
+// remove deletes the history entries at the given indices
+func (cb *ComboBuffer) remove(indices []int) {
+	drop := make(map[int]bool, len(indices))
+	for _, i := range indices {
+		drop[i] = true
+	}
+	kept := make([]StampedAction, 0, len(cb.History))
+	for i, action := range cb.History {
+		if !drop[i] {
+			kept = append(kept, action)
+		}
+	}
+	cb.History = kept
+}
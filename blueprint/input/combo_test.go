@@ -0,0 +1,154 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComboBufferFeed(t *testing.T) {
+	down, forward, punch, kick := NewAction(), NewAction(), NewAction(), NewAction()
+	fireball, jab, uppercut := NewAction(), NewAction(), NewAction()
+
+	type input struct {
+		tick   int
+		action Action
+	}
+	tests := []struct {
+		name   string
+		combos []Combo
+		inputs []input
+		// want holds the synthetic actions emitted for each input, nil when none
+		want [][]Action
+	}{
+		{
+			name:   "steps within their windows",
+			combos: []Combo{NewCombo(fireball, Step(down, 0), Step(forward, 10), Step(punch, 5))},
+			inputs: []input{{0, down}, {10, forward}, {15, punch}},
+			want:   [][]Action{nil, nil, {fireball}},
+		},
+		{
+			name:   "step outside its window",
+			combos: []Combo{NewCombo(fireball, Step(down, 0), Step(forward, 10), Step(punch, 5))},
+			inputs: []input{{0, down}, {10, forward}, {16, punch}},
+			want:   [][]Action{nil, nil, nil},
+		},
+		{
+			name:   "earlier step outside its window",
+			combos: []Combo{NewCombo(fireball, Step(down, 0), Step(forward, 10), Step(punch, 5))},
+			inputs: []input{{0, down}, {11, forward}, {12, punch}},
+			want:   [][]Action{nil, nil, nil},
+		},
+		{
+			name:   "zero window allows any gap",
+			combos: []Combo{NewCombo(jab, Step(forward, 0), Step(punch, 0))},
+			inputs: []input{{0, forward}, {50, punch}},
+			want:   [][]Action{nil, {jab}},
+		},
+		{
+			name:   "other actions between steps",
+			combos: []Combo{NewCombo(jab, Step(forward, 0), Step(punch, 5))},
+			inputs: []input{{0, forward}, {2, kick}, {3, down}, {4, punch}},
+			want:   [][]Action{nil, nil, nil, {jab}},
+		},
+		{
+			name:   "a later repeat of a step restarts its window",
+			combos: []Combo{NewCombo(jab, Step(forward, 0), Step(punch, 5))},
+			inputs: []input{{0, forward}, {10, forward}, {14, punch}},
+			want:   [][]Action{nil, nil, {jab}},
+		},
+		{
+			name: "longest overlapping combo wins",
+			combos: []Combo{
+				NewCombo(jab, Step(forward, 0), Step(punch, 5)),
+				NewCombo(fireball, Step(down, 0), Step(forward, 5), Step(punch, 5)),
+			},
+			inputs: []input{{0, down}, {3, forward}, {6, punch}},
+			want:   [][]Action{nil, nil, {fireball}},
+		},
+		{
+			name: "shorter overlapping combo when the longer one misses its window",
+			combos: []Combo{
+				NewCombo(jab, Step(forward, 0), Step(punch, 5)),
+				NewCombo(fireball, Step(down, 0), Step(forward, 5), Step(punch, 5)),
+			},
+			inputs: []input{{0, down}, {6, forward}, {9, punch}},
+			want:   [][]Action{nil, nil, {jab}},
+		},
+		{
+			name: "earlier combo breaks ties",
+			combos: []Combo{
+				NewCombo(jab, Step(forward, 0), Step(punch, 5)),
+				NewCombo(uppercut, Step(down, 0), Step(punch, 5)),
+			},
+			inputs: []input{{0, down}, {1, forward}, {2, punch}},
+			want:   [][]Action{nil, nil, {jab}},
+		},
+		{
+			name:   "used inputs do not fire again",
+			combos: []Combo{NewCombo(jab, Step(forward, 0), Step(punch, 5))},
+			inputs: []input{{0, forward}, {1, punch}, {2, punch}, {3, forward}, {4, punch}},
+			want:   [][]Action{nil, {jab}, nil, nil, {jab}},
+		},
+		{
+			name:   "a late step completes the combo",
+			combos: []Combo{NewCombo(jab, Step(forward, 0), Step(punch, 5))},
+			inputs: []input{{4, punch}, {2, forward}, {5, punch}},
+			want:   [][]Action{nil, {jab}, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := ComboBuffer{Combos: tt.combos}
+			for i, in := range tt.inputs {
+				var got []Action
+				for _, synthetic := range cb.Feed([]StampedAction{{Tick: in.tick, Val: in.action}}) {
+					got = append(got, synthetic.Val)
+				}
+				if !reflect.DeepEqual(got, tt.want[i]) {
+					t.Errorf("input %d at tick %d: got %v, want %v", i, in.tick, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestComboBufferSynthetic tests that the synthetic action carries the completing action's data
+func TestComboBufferSynthetic(t *testing.T) {
+	forward, punch, jab := NewAction(), NewAction(), NewAction()
+	cb := ComboBuffer{Combos: []Combo{NewCombo(jab, Step(forward, 0), Step(punch, 5))}}
+
+	completing := StampedAction{Tick: 3, Val: punch, X: 10, Y: 20, LocalX: 1, LocalY: 2}
+	got := cb.Feed([]StampedAction{{Tick: 1, Val: forward}, completing})
+	want := completing
+	want.Val = jab
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("Feed = %+v, want %+v", got, want)
+	}
+	if len(cb.History) != 0 {
+		t.Errorf("History kept the used inputs: %+v", cb.History)
+	}
+
+	// A late first step completes the combo at the tick of its last step
+	got = cb.Feed([]StampedAction{completing, {Tick: 2, Val: forward}})
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("Feed with a late step = %+v, want %+v", got, want)
+	}
+}
+
+func TestComboBufferMaxAge(t *testing.T) {
+	forward, punch, jab := NewAction(), NewAction(), NewAction()
+	cb := ComboBuffer{Combos: []Combo{NewCombo(jab, Step(forward, 0), Step(punch, 0))}, MaxAge: 10}
+
+	if got := cb.Feed([]StampedAction{{Tick: 0, Val: forward}, {Tick: 11, Val: punch}}); len(got) != 0 {
+		t.Errorf("Combo fired with a step older than MaxAge: %+v", got)
+	}
+	if len(cb.History) != 1 || cb.History[0].Tick != 11 {
+		t.Errorf("History = %+v, want only the action at tick 11", cb.History)
+	}
+
+	cb = ComboBuffer{Combos: cb.Combos}
+	cb.Feed([]StampedAction{{Tick: 0, Val: forward}})
+	if got := cb.Feed([]StampedAction{{Tick: DefaultComboHistoryAge, Val: punch}}); len(got) != 1 {
+		t.Errorf("Combo within DefaultComboHistoryAge did not fire")
+	}
+}
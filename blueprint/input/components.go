@@ -4,8 +4,10 @@ import "github.com/TheBitDrifter/bappa/warehouse"
 
 type defaultComponents struct {
 	ActionBuffer warehouse.AccessibleComponent[ActionBuffer]
+	ComboBuffer  warehouse.AccessibleComponent[ComboBuffer]
 }
 
 var Components = defaultComponents{
 	ActionBuffer: warehouse.FactoryNewComponent[ActionBuffer](),
+	ComboBuffer:  warehouse.FactoryNewComponent[ComboBuffer](),
 }
@@ -0,0 +1,153 @@
+package locale
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/TheBitDrifter/bappa/environment"
+)
+
+// LoadFile reads a table from a .json or .po file
+// Like other loaders, it reads from the embedded filesystem in production and WASM builds
+func LoadFile(filename, path string, fs embed.FS) (Table, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if environment.IsProd() || environment.IsWASM() {
+		data, err = fs.ReadFile(filename)
+	} else {
+		data, err = os.ReadFile(path + filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read string table %s: %w", filename, err)
+	}
+
+	switch ext := filepath.Ext(filename); ext {
+	case ".json":
+		return ParseJSON(data)
+	case ".po":
+		return ParsePO(data)
+	default:
+		return nil, fmt.Errorf("unsupported string table format %q", ext)
+	}
+}
+
+// ParseJSON reads a table from a JSON object
+// Nested objects are flattened into dotted keys, so {"guard": {"halt": "Halt!"}} has the key "guard.halt"
+func ParseJSON(data []byte) (Table, error) {
+	raw := map[string]any{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal string table: %w", err)
+	}
+	table := Table{}
+	if err := flatten(table, "", raw); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+func flatten(table Table, prefix string, raw map[string]any) error {
+	for key, value := range raw {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case string:
+			table[key] = v
+		case map[string]any:
+			if err := flatten(table, key, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("string table key %s is %T, not a string", key, value)
+		}
+	}
+	return nil
+}
+
+// ParsePO reads a table from a gettext PO file
+//
+// The msgid is the key, prefixed by the msgctxt and a dot when present. Untranslated and fuzzy
+// entries are skipped so lookups fall back to other locales; plural entries use their first form
+func ParsePO(data []byte) (Table, error) {
+	table := Table{}
+	var (
+		ctxt, id, str string
+		field         *string
+		fuzzy, inStr  bool
+	)
+	flush := func() {
+		if id != "" && str != "" && !fuzzy {
+			key := id
+			if ctxt != "" {
+				key = ctxt + "." + id
+			}
+			table[key] = str
+		}
+		ctxt, id, str, field, fuzzy, inStr = "", "", "", nil, false, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#"):
+			// Comments belong to the entry after them, so one following a msgstr starts a new entry
+			if inStr {
+				flush()
+			}
+			if strings.HasPrefix(line, "#,") {
+				fuzzy = fuzzy || strings.Contains(line, "fuzzy")
+			}
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(line, " ")
+		switch {
+		case strings.HasPrefix(line, `"`):
+			rest = line
+		case keyword == "msgctxt":
+			if inStr {
+				flush()
+			}
+			field = &ctxt
+		case keyword == "msgid":
+			if inStr {
+				flush()
+			}
+			field = &id
+		case keyword == "msgid_plural":
+			field = nil
+		case keyword == "msgstr" || keyword == "msgstr[0]":
+			field, inStr = &str, true
+		case strings.HasPrefix(keyword, "msgstr["):
+			field = nil
+		default:
+			return nil, fmt.Errorf("po line %d: unknown keyword %q", n, keyword)
+		}
+
+		value, err := strconv.Unquote(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("po line %d: invalid string %s", n, rest)
+		}
+		if field != nil {
+			*field += value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read po file: %w", err)
+	}
+	flush()
+	return table, nil
+}
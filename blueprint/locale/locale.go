@@ -0,0 +1,120 @@
+// Package locale provides string tables keyed by IDs, with runtime locale switching and fallback locales
+package locale
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Table maps string IDs to the text of one locale
+type Table map[string]string
+
+// state holds the registered tables and the active locale
+var state = struct {
+	sync.RWMutex
+	tables    map[string]Table
+	current   string
+	fallbacks []string
+	version   int
+}{
+	tables: make(map[string]Table),
+}
+
+// normalize makes "pt_BR" and "PT-br" the same locale
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// Register adds the entries to the locale's table, replacing existing keys
+func Register(locale string, table Table) {
+	state.Lock()
+	defer state.Unlock()
+	locale = normalize(locale)
+	existing, ok := state.tables[locale]
+	if !ok {
+		existing = make(Table, len(table))
+		state.tables[locale] = existing
+	}
+	for key, text := range table {
+		existing[key] = text
+	}
+	if state.current == "" {
+		state.current = locale
+	}
+	state.version++
+}
+
+// SetLocale switches the active locale
+func SetLocale(locale string) {
+	state.Lock()
+	defer state.Unlock()
+	state.current = normalize(locale)
+	state.version++
+}
+
+// Current returns the active locale
+func Current() string {
+	state.RLock()
+	defer state.RUnlock()
+	return state.current
+}
+
+// SetFallbacks sets the locales tried, in order, when a key is missing from the active locale
+func SetFallbacks(locales ...string) {
+	state.Lock()
+	defer state.Unlock()
+	state.fallbacks = state.fallbacks[:0]
+	for _, locale := range locales {
+		state.fallbacks = append(state.fallbacks, normalize(locale))
+	}
+	state.version++
+}
+
+// Version changes whenever tables, the active locale or the fallbacks change,
+// so systems caching localized text (like wrapped dialogue) know to refresh it
+func Version() int {
+	state.RLock()
+	defer state.RUnlock()
+	return state.version
+}
+
+// Lookup returns the text for the key from the active locale, its base language ("pt" for "pt-br"),
+// then the fallbacks in order
+func Lookup(key string) (string, bool) {
+	state.RLock()
+	defer state.RUnlock()
+	if text, ok := lookupIn(state.current, key); ok {
+		return text, true
+	}
+	for _, locale := range state.fallbacks {
+		if text, ok := lookupIn(locale, key); ok {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+func lookupIn(locale, key string) (string, bool) {
+	if text, ok := state.tables[locale][key]; ok {
+		return text, true
+	}
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		text, ok := state.tables[base][key]
+		return text, ok
+	}
+	return "", false
+}
+
+// Get returns the text for the key, or the key itself when no locale has it
+func Get(key string) string {
+	if text, ok := Lookup(key); ok {
+		return text
+	}
+	return key
+}
+
+// Format returns the text for the key formatted with fmt.Sprintf
+func Format(key string, args ...any) string {
+	return fmt.Sprintf(Get(key), args...)
+}
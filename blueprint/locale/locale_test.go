@@ -0,0 +1,209 @@
+package locale
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// reset clears the registered tables and active locale for a test
+func reset(t *testing.T) {
+	t.Helper()
+	empty := func() {
+		state.Lock()
+		defer state.Unlock()
+		state.tables = make(map[string]Table)
+		state.current = ""
+		state.fallbacks = nil
+	}
+	empty()
+	t.Cleanup(empty)
+}
+
+func TestParsePO(t *testing.T) {
+	tests := []struct {
+		name string
+		po   string
+		want Table
+	}{
+		{
+			name: "entries, context and continuation lines",
+			po: `# translator comment
+msgid "halt"
+msgstr "Halt!"
+
+msgctxt "guard"
+msgid "greeting"
+msgstr ""
+"Who goes "
+"there?"
+`,
+			want: Table{"halt": "Halt!", "guard.greeting": "Who goes there?"},
+		},
+		{
+			name: "untranslated and header entries are skipped",
+			po: `msgid ""
+msgstr "Content-Type: text/plain; charset=UTF-8\n"
+
+msgid "missing"
+msgstr ""
+`,
+			want: Table{},
+		},
+		{
+			name: "fuzzy entries are skipped",
+			po: `#, fuzzy
+msgid "rough"
+msgstr "Rough"
+
+msgid "kept"
+msgstr "Kept"
+`,
+			want: Table{"kept": "Kept"},
+		},
+		{
+			name: "fuzzy flag directly after the previous entry",
+			po: `msgid "kept"
+msgstr "Kept"
+#, fuzzy
+msgid "rough"
+msgstr "Rough"
+msgid "also_kept"
+msgstr "Also kept"
+`,
+			want: Table{"kept": "Kept", "also_kept": "Also kept"},
+		},
+		{
+			name: "plurals use the first form",
+			po: `msgid "coin"
+msgid_plural "coins"
+msgstr[0] "Coin"
+msgstr[1] "Coins"
+`,
+			want: Table{"coin": "Coin"},
+		},
+		{
+			name: "escapes",
+			po: `msgid "quote"
+msgstr "She said \"hi\"\n"
+`,
+			want: Table{"quote": "She said \"hi\"\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePO([]byte(tt.po))
+			if err != nil {
+				t.Fatalf("ParsePO failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePO = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePOErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		po   string
+		want string
+	}{
+		{"unknown keyword", "msgid \"a\"\nmsgfoo \"b\"", "po line 2: unknown keyword"},
+		{"unquoted string", "msgid a", "po line 1: invalid string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePO([]byte(tt.po))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParsePO error = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseJSON(t *testing.T) {
+	got, err := ParseJSON([]byte(`{"title": "Bappa", "guard": {"halt": "Halt!", "ask": {"name": "Name?"}}}`))
+	if err != nil {
+		t.Fatalf("ParseJSON failed: %v", err)
+	}
+	want := Table{"title": "Bappa", "guard.halt": "Halt!", "guard.ask.name": "Name?"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseJSON = %v, want %v", got, want)
+	}
+
+	for _, data := range []string{`{"count": 3}`, `{"guard": {"list": ["a"]}}`, `["a"]`, `{`} {
+		if _, err := ParseJSON([]byte(data)); err == nil {
+			t.Errorf("ParseJSON(%s) succeeded, expected error", data)
+		}
+	}
+}
+
+func TestLookupFallbacks(t *testing.T) {
+	reset(t)
+	Register("en", Table{"hello": "Hello", "bye": "Bye", "only_en": "English"})
+	Register("pt", Table{"hello": "Olá", "bye": "Tchau"})
+	Register("pt_BR", Table{"hello": "Oi"})
+	Register("es", Table{"only_es": "Español"})
+
+	if Current() != "en" {
+		t.Errorf("Current = %q, want the first registered locale en", Current())
+	}
+
+	SetLocale("PT-br")
+	SetFallbacks("es", "en")
+	tests := []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"hello", "Oi", true},
+		{"bye", "Tchau", true},
+		{"only_es", "Español", true},
+		{"only_en", "English", true},
+		{"missing", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Lookup(tt.key)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Lookup(%q) = %q, %v, want %q, %v", tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+	if got := Get("missing"); got != "missing" {
+		t.Errorf("Get(missing) = %q, want the key", got)
+	}
+
+	// A regional fallback also falls back to its base language
+	SetLocale("es")
+	SetFallbacks("pt-pt")
+	if got, _ := Lookup("bye"); got != "Tchau" {
+		t.Errorf("Lookup(bye) = %q, want the pt base of the pt-pt fallback", got)
+	}
+}
+
+func TestVersionChanges(t *testing.T) {
+	reset(t)
+	v := Version()
+	Register("en", Table{"a": "A"})
+	if Version() == v {
+		t.Error("Register did not change the version")
+	}
+	v = Version()
+	SetLocale("en")
+	if Version() == v {
+		t.Error("SetLocale did not change the version")
+	}
+	v = Version()
+	SetFallbacks("de")
+	if Version() == v {
+		t.Error("SetFallbacks did not change the version")
+	}
+}
+
+func TestFormat(t *testing.T) {
+	reset(t)
+	Register("en", Table{"gold": "%d gold"})
+	if got := Format("gold", 3); got != "3 gold" {
+		t.Errorf("Format = %q, want 3 gold", got)
+	}
+}
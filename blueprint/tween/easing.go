@@ -0,0 +1,117 @@
+package tween
+
+import "math"
+
+// Ease selects the curve a step follows from its start to its end value
+type Ease int
+
+const (
+	EaseLinear Ease = iota
+	EaseInQuad
+	EaseOutQuad
+	EaseInOutQuad
+	EaseInCubic
+	EaseOutCubic
+	EaseInOutCubic
+	EaseInSine
+	EaseOutSine
+	EaseInOutSine
+	EaseInExpo
+	EaseOutExpo
+	EaseInOutExpo
+	EaseInBack
+	EaseOutBack
+	EaseInOutBack
+	EaseInElastic
+	EaseOutElastic
+	EaseInBounce
+	EaseOutBounce
+)
+
+const (
+	backOvershoot   = 1.70158
+	backInOutFactor = backOvershoot * 1.525
+	elasticPeriod   = 2 * math.Pi / 3
+)
+
+// Apply maps linear progress in [0, 1] onto the curve
+// Progress outside the range is clamped. Back and elastic curves overshoot in between, but always end on 0 and 1
+func (e Ease) Apply(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+
+	switch e {
+	case EaseInQuad:
+		return t * t
+	case EaseOutQuad:
+		return 1 - (1-t)*(1-t)
+	case EaseInOutQuad:
+		if t < 0.5 {
+			return 2 * t * t
+		}
+		return 1 - math.Pow(-2*t+2, 2)/2
+	case EaseInCubic:
+		return t * t * t
+	case EaseOutCubic:
+		return 1 - math.Pow(1-t, 3)
+	case EaseInOutCubic:
+		if t < 0.5 {
+			return 4 * t * t * t
+		}
+		return 1 - math.Pow(-2*t+2, 3)/2
+	case EaseInSine:
+		return 1 - math.Cos(t*math.Pi/2)
+	case EaseOutSine:
+		return math.Sin(t * math.Pi / 2)
+	case EaseInOutSine:
+		return -(math.Cos(math.Pi*t) - 1) / 2
+	case EaseInExpo:
+		return math.Pow(2, 10*t-10)
+	case EaseOutExpo:
+		return 1 - math.Pow(2, -10*t)
+	case EaseInOutExpo:
+		if t < 0.5 {
+			return math.Pow(2, 20*t-10) / 2
+		}
+		return (2 - math.Pow(2, -20*t+10)) / 2
+	case EaseInBack:
+		return (backOvershoot+1)*t*t*t - backOvershoot*t*t
+	case EaseOutBack:
+		u := t - 1
+		return 1 + (backOvershoot+1)*u*u*u + backOvershoot*u*u
+	case EaseInOutBack:
+		if t < 0.5 {
+			return math.Pow(2*t, 2) * ((backInOutFactor+1)*2*t - backInOutFactor) / 2
+		}
+		return (math.Pow(2*t-2, 2)*((backInOutFactor+1)*(t*2-2)+backInOutFactor) + 2) / 2
+	case EaseInElastic:
+		return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*elasticPeriod)
+	case EaseOutElastic:
+		return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*elasticPeriod) + 1
+	case EaseInBounce:
+		return 1 - bounceOut(1-t)
+	case EaseOutBounce:
+		return bounceOut(t)
+	}
+	return t
+}
+
+func bounceOut(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	}
+	t -= 2.625 / d
+	return n*t*t + 0.984375
+}
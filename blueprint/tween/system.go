@@ -0,0 +1,132 @@
+package tween
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/TheBitDrifter/bappa/blueprint"
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// System advances every tween by one tick per run, independent of the frame delta
+// It belongs in the core systems of both the server and clients
+type System struct{}
+
+// Run plays one tick of each active tween, then reports the tweens that completed
+func (System) Run(scene blueprint.Scene, dt float64) error {
+	completed := []warehouse.Entity{}
+
+	cursor := scene.NewCursor(warehouse.Factory.NewQuery().And(Components.Tween))
+	for range cursor.Next() {
+		tw := Components.Tween.GetFromCursor(cursor)
+		if tw.Done || tw.Paused {
+			continue
+		}
+		entity, err := cursor.CurrentEntity()
+		if err != nil {
+			return err
+		}
+		if len(tw.Steps) == 0 {
+			tw.Done = true
+			completed = append(completed, entity)
+			continue
+		}
+
+		step := tw.Steps[tw.StepIndex]
+		if tw.needsOrigin() {
+			current, err := readTarget(cursor, entity, step)
+			if err != nil {
+				return err
+			}
+			tw.setOrigin(current)
+		}
+
+		tick := min(tw.Tick+1, step.Ticks)
+		if err := writeTarget(cursor, entity, step, step.valueAt(tw.from(tw.StepIndex), tick, tw.Reverse)); err != nil {
+			return err
+		}
+		if tw.advance() {
+			completed = append(completed, entity)
+		}
+	}
+
+	// Callbacks may change the entity's components, so they run once iteration is over
+	for _, entity := range completed {
+		tw := Components.Tween.GetFromEntity(entity)
+		warehouse.Emit(scene.Storage(), Completed{Entity: entity, Tag: tw.Tag})
+		if callback, ok := CallbackRegistry[tw.Callback]; ok && tw.Callback != 0 {
+			if err := callback(scene, entity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// readTarget returns the current value of a step's target
+func readTarget(cursor *warehouse.Cursor, entity warehouse.Entity, step Step) (vector.Two, error) {
+	switch step.Target {
+	case TargetPosition:
+		if pos, ok := spatial.Components.Position.GetFromCursorSafe(cursor); ok {
+			return pos.Two, nil
+		}
+		return vector.Two{}, fmt.Errorf("tween on entity %d targets a missing Position", entity.ID())
+	case TargetScale:
+		if scale, ok := spatial.Components.Scale.GetFromCursorSafe(cursor); ok {
+			return scale.Two, nil
+		}
+		return vector.Two{}, fmt.Errorf("tween on entity %d targets a missing Scale", entity.ID())
+	case TargetRotation:
+		if rot, ok := spatial.Components.Rotation.GetFromCursorSafe(cursor); ok {
+			return vector.Two{X: float64(*rot)}, nil
+		}
+		return vector.Two{}, fmt.Errorf("tween on entity %d targets a missing Rotation", entity.ID())
+	case TargetField:
+		value, err := warehouse.GetField(entity, step.Path)
+		if err != nil {
+			return vector.Two{}, fmt.Errorf("tween on entity %d: %w", entity.ID(), err)
+		}
+		v := reflect.ValueOf(value)
+		switch {
+		case v.CanFloat():
+			return vector.Two{X: v.Float()}, nil
+		case v.CanInt():
+			return vector.Two{X: float64(v.Int())}, nil
+		case v.CanUint():
+			return vector.Two{X: float64(v.Uint())}, nil
+		}
+		return vector.Two{}, fmt.Errorf("tween on entity %d: field %s is %T, not numeric", entity.ID(), step.Path, value)
+	}
+	return step.From, nil
+}
+
+// writeTarget stores an interpolated value into a step's target
+func writeTarget(cursor *warehouse.Cursor, entity warehouse.Entity, step Step, value vector.Two) error {
+	switch step.Target {
+	case TargetPosition:
+		pos, ok := spatial.Components.Position.GetFromCursorSafe(cursor)
+		if !ok {
+			return fmt.Errorf("tween on entity %d targets a missing Position", entity.ID())
+		}
+		pos.Two = value
+	case TargetScale:
+		scale, ok := spatial.Components.Scale.GetFromCursorSafe(cursor)
+		if !ok {
+			return fmt.Errorf("tween on entity %d targets a missing Scale", entity.ID())
+		}
+		scale.Two = value
+	case TargetRotation:
+		rot, ok := spatial.Components.Rotation.GetFromCursorSafe(cursor)
+		if !ok {
+			return fmt.Errorf("tween on entity %d targets a missing Rotation", entity.ID())
+		}
+		*rot = spatial.Rotation(value.X)
+	case TargetField:
+		if err := warehouse.SetField(entity, step.Path, value.X); err != nil {
+			return fmt.Errorf("tween on entity %d: %w", entity.ID(), err)
+		}
+	}
+	return nil
+}
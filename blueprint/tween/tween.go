@@ -0,0 +1,237 @@
+package tween
+
+import (
+	"slices"
+
+	"github.com/TheBitDrifter/bappa/blueprint"
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// Target selects what a step writes to
+type Target int
+
+const (
+	// TargetNone writes nothing, the step only waits
+	TargetNone Target = iota
+	// TargetPosition writes spatial.Position
+	TargetPosition
+	// TargetScale writes spatial.Scale
+	TargetScale
+	// TargetRotation writes spatial.Rotation from the X component
+	TargetRotation
+	// TargetField writes the X component to the numeric field at Path (see warehouse.SetField)
+	TargetField
+)
+
+// LoopForever repeats a tween until it is removed
+const LoopForever = -1
+
+// Step interpolates one target from From to To over a number of ticks
+type Step struct {
+	Target Target
+	Path   string
+	From   vector.Two
+	To     vector.Two
+	Ticks  int
+	Ease   Ease
+
+	// FromCurrent replaces From with the target's value the first time the step starts
+	// The value is kept in the tween's Origins, so Steps can be shared between entities
+	FromCurrent bool
+}
+
+// Origin is the start value a FromCurrent step resolved when it first began
+type Origin struct {
+	Resolved bool
+	Value    vector.Two
+}
+
+// Tween plays its steps in sequence, one tick per system run
+//
+// Playback state is stored on the component so tweens snapshot and replay like any other state,
+// and advance identically on the server and on clients. Steps are never modified during playback
+type Tween struct {
+	Steps    []Step
+	Yoyo     bool
+	Loops    int
+	Callback CallbackEnum
+	Tag      string
+
+	StepIndex int
+	Tick      int
+	Reverse   bool
+	LoopsDone int
+	Done      bool
+	Paused    bool
+	// Origins holds the resolved start of each FromCurrent step, by step index
+	Origins []Origin
+}
+
+// CloneComponent returns a copy of the tween that shares no steps or origins with it,
+// so entities generated from the same tween play independently
+func (t Tween) CloneComponent() any {
+	t.Steps = slices.Clone(t.Steps)
+	t.Origins = slices.Clone(t.Origins)
+	return t
+}
+
+// CallbackEnum identifies a completion callback in CallbackRegistry, zero means none
+type CallbackEnum int
+
+// CallbackRegistry holds the functions tweens run when they complete
+var CallbackRegistry map[CallbackEnum]func(scene blueprint.Scene, entity warehouse.Entity) error = map[CallbackEnum]func(scene blueprint.Scene, entity warehouse.Entity) error{}
+
+// Completed is emitted on the storage's event bus when a tween finishes its last loop
+type Completed struct {
+	Entity warehouse.Entity
+	Tag    string
+}
+
+// New creates a tween playing the steps in order
+func New(steps ...Step) Tween {
+	return Tween{Steps: append([]Step(nil), steps...)}
+}
+
+// WithYoyo plays the steps backwards after each forward pass
+func (t Tween) WithYoyo() Tween {
+	t.Yoyo = true
+	return t
+}
+
+// WithLoops repeats the tween the given number of extra times, or forever with LoopForever
+func (t Tween) WithLoops(loops int) Tween {
+	t.Loops = loops
+	return t
+}
+
+// WithCallback runs the registered callback when the tween completes
+func (t Tween) WithCallback(callback CallbackEnum) Tween {
+	t.Callback = callback
+	return t
+}
+
+// WithTag sets the tag reported by the Completed event
+func (t Tween) WithTag(tag string) Tween {
+	t.Tag = tag
+	return t
+}
+
+// Position creates a step moving spatial.Position
+func Position(from, to vector.Two, ticks int, ease Ease) Step {
+	return Step{Target: TargetPosition, From: from, To: to, Ticks: ticks, Ease: ease}
+}
+
+// Scale creates a step resizing spatial.Scale
+func Scale(from, to vector.Two, ticks int, ease Ease) Step {
+	return Step{Target: TargetScale, From: from, To: to, Ticks: ticks, Ease: ease}
+}
+
+// Rotation creates a step turning spatial.Rotation, in radians
+func Rotation(from, to float64, ticks int, ease Ease) Step {
+	return Step{Target: TargetRotation, From: vector.Two{X: from}, To: vector.Two{X: to}, Ticks: ticks, Ease: ease}
+}
+
+// Field creates a step writing a numeric component field by path, e.g. "Dynamics.Vel.X"
+func Field(path string, from, to float64, ticks int, ease Ease) Step {
+	return Step{Target: TargetField, Path: path, From: vector.Two{X: from}, To: vector.Two{X: to}, Ticks: ticks, Ease: ease}
+}
+
+// Wait creates a step that only delays the sequence
+func Wait(ticks int) Step {
+	return Step{Target: TargetNone, Ticks: ticks}
+}
+
+// FromCurrentValue makes the step start from wherever its target is when the step begins
+func (s Step) FromCurrentValue() Step {
+	s.FromCurrent = true
+	return s
+}
+
+// valueAt returns the interpolated value from the given start after the given number of ticks
+func (s Step) valueAt(from vector.Two, tick int, reverse bool) vector.Two {
+	progress := 1.0
+	if s.Ticks > 0 {
+		progress = float64(tick) / float64(s.Ticks)
+	}
+	if reverse {
+		progress = 1 - progress
+	}
+	return from.Lerp(s.To, s.Ease.Apply(progress))
+}
+
+// needsOrigin reports whether the current step starts from its target's value and has not resolved it yet
+func (t *Tween) needsOrigin() bool {
+	step := t.Steps[t.StepIndex]
+	if !step.FromCurrent || t.Tick != 0 || t.Reverse {
+		return false
+	}
+	return t.StepIndex >= len(t.Origins) || !t.Origins[t.StepIndex].Resolved
+}
+
+// setOrigin records the resolved start of the current step
+func (t *Tween) setOrigin(value vector.Two) {
+	if len(t.Origins) < len(t.Steps) {
+		t.Origins = append(t.Origins, make([]Origin, len(t.Steps)-len(t.Origins))...)
+	}
+	t.Origins[t.StepIndex] = Origin{Resolved: true, Value: value}
+}
+
+// from returns the start of the step at the index
+func (t *Tween) from(index int) vector.Two {
+	if index < len(t.Origins) && t.Origins[index].Resolved {
+		return t.Origins[index].Value
+	}
+	return t.Steps[index].From
+}
+
+// advance moves playback to the next tick and reports whether the tween just completed
+func (t *Tween) advance() bool {
+	t.Tick++
+	if t.Tick < t.Steps[t.StepIndex].Ticks {
+		return false
+	}
+	t.Tick = 0
+
+	if !t.Reverse {
+		t.StepIndex++
+		if t.StepIndex < len(t.Steps) {
+			return false
+		}
+		if t.Yoyo {
+			t.Reverse = true
+			t.StepIndex = len(t.Steps) - 1
+			return false
+		}
+	} else {
+		t.StepIndex--
+		if t.StepIndex >= 0 {
+			return false
+		}
+		t.Reverse = false
+	}
+
+	t.StepIndex = 0
+	if t.Loops == LoopForever || t.LoopsDone < t.Loops {
+		t.LoopsDone++
+		return false
+	}
+	t.Done = true
+	return true
+}
+
+// Restart rewinds the tween to its first step
+// FromCurrent steps resolve their start again
+func (t *Tween) Restart() {
+	t.StepIndex, t.Tick, t.LoopsDone = 0, 0, 0
+	t.Reverse, t.Done = false, false
+	t.Origins = nil
+}
+
+type comps struct {
+	Tween warehouse.AccessibleComponent[Tween]
+}
+
+var Components = comps{
+	Tween: warehouse.FactoryNewComponent[Tween](),
+}
@@ -0,0 +1,208 @@
+package tween
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/table"
+	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// testScene is the smallest blueprint.Scene the system needs
+type testScene struct {
+	storage warehouse.Storage
+	tick    int
+}
+
+func (s *testScene) NewCursor(query warehouse.QueryNode) *warehouse.Cursor {
+	return warehouse.Factory.NewCursor(query, s.storage)
+}
+func (s *testScene) Height() int                { return 0 }
+func (s *testScene) Width() int                 { return 0 }
+func (s *testScene) CurrentTick() int           { return s.tick }
+func (s *testScene) Storage() warehouse.Storage { return s.storage }
+func (s *testScene) Name() string               { return "test" }
+
+type position struct {
+	step, tick int
+	reverse    bool
+}
+
+// playback records the position after each advance until the tween completes or the limit is hit
+func playback(tw Tween, limit int) ([]position, bool) {
+	var got []position
+	for range limit {
+		done := tw.advance()
+		got = append(got, position{tw.StepIndex, tw.Tick, tw.Reverse})
+		if done {
+			return got, true
+		}
+	}
+	return got, false
+}
+
+func TestAdvance(t *testing.T) {
+	tests := []struct {
+		name  string
+		tween Tween
+		want  []position
+		done  bool
+	}{
+		{
+			name:  "single pass",
+			tween: New(Wait(2), Wait(1)),
+			want:  []position{{0, 1, false}, {1, 0, false}, {0, 0, false}},
+			done:  true,
+		},
+		{
+			name:  "yoyo",
+			tween: New(Wait(1), Wait(1)).WithYoyo(),
+			want:  []position{{1, 0, false}, {1, 0, true}, {0, 0, true}, {0, 0, false}},
+			done:  true,
+		},
+		{
+			name:  "loops",
+			tween: New(Wait(2)).WithLoops(2),
+			want:  []position{{0, 1, false}, {0, 0, false}, {0, 1, false}, {0, 0, false}, {0, 1, false}, {0, 0, false}},
+			done:  true,
+		},
+		{
+			name:  "yoyo loops",
+			tween: New(Wait(1)).WithYoyo().WithLoops(1),
+			want:  []position{{0, 0, true}, {0, 0, false}, {0, 0, true}, {0, 0, false}},
+			done:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, done := playback(tt.tween, 100)
+			if done != tt.done {
+				t.Fatalf("done = %v, want %v", done, tt.done)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d ticks %v, want %d %v", len(got), got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("tick %d: got %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAdvanceLoopForever(t *testing.T) {
+	tw := New(Wait(1), Wait(2)).WithYoyo().WithLoops(LoopForever)
+	for i := range 1000 {
+		if tw.advance() {
+			t.Fatalf("LoopForever tween completed after %d ticks", i+1)
+		}
+	}
+	if tw.Done {
+		t.Error("LoopForever tween marked done")
+	}
+	if tw.LoopsDone == 0 {
+		t.Error("LoopForever tween never looped")
+	}
+}
+
+func TestRestart(t *testing.T) {
+	tw := New(Wait(1))
+	if !tw.advance() {
+		t.Fatal("expected the tween to complete")
+	}
+	tw.Origins = []Origin{{Resolved: true}}
+	tw.Restart()
+	if tw.Done || tw.StepIndex != 0 || tw.Tick != 0 || tw.LoopsDone != 0 || tw.Origins != nil {
+		t.Errorf("Restart left state behind: %+v", tw)
+	}
+}
+
+func TestEaseEndpoints(t *testing.T) {
+	for e := EaseLinear; e <= EaseOutBounce; e++ {
+		if got := e.Apply(0); got != 0 {
+			t.Errorf("ease %d: Apply(0) = %v, want 0", e, got)
+		}
+		if got := e.Apply(1); got != 1 {
+			t.Errorf("ease %d: Apply(1) = %v, want 1", e, got)
+		}
+		if got := e.Apply(-1); got != 0 {
+			t.Errorf("ease %d: Apply(-1) = %v, want 0", e, got)
+		}
+		if got := e.Apply(2); got != 1 {
+			t.Errorf("ease %d: Apply(2) = %v, want 1", e, got)
+		}
+		// Curves should approach their ends continuously
+		if got := e.Apply(1e-9); math.Abs(got) > 1e-3 {
+			t.Errorf("ease %d: Apply(1e-9) = %v, want ~0", e, got)
+		}
+		if got := e.Apply(1 - 1e-9); math.Abs(got-1) > 1e-3 {
+			t.Errorf("ease %d: Apply(1-1e-9) = %v, want ~1", e, got)
+		}
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	step := Position(vector.Two{}, vector.Two{X: 10, Y: 20}, 4, EaseLinear)
+	from := vector.Two{X: 2}
+	if got := step.valueAt(from, 0, false); got != from {
+		t.Errorf("valueAt(0) = %v, want %v", got, from)
+	}
+	if got := step.valueAt(from, 4, false); got != step.To {
+		t.Errorf("valueAt(4) = %v, want %v", got, step.To)
+	}
+	if got := step.valueAt(from, 4, true); got != from {
+		t.Errorf("reverse valueAt(4) = %v, want %v", got, from)
+	}
+	if got := step.valueAt(from, 2, false); got != (vector.Two{X: 6, Y: 10}) {
+		t.Errorf("valueAt(2) = %v, want {6 10}", got)
+	}
+}
+
+// TestFromCurrent generates several entities from one tween value and checks each starts from its own position
+func TestFromCurrent(t *testing.T) {
+	storage := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	scene := &testScene{storage: storage}
+
+	archetype, err := storage.NewOrExistingArchetype(Components.Tween, spatial.Components.Position)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared := New(Position(vector.Two{}, vector.Two{X: 100}, 2, EaseLinear).FromCurrentValue()).WithYoyo()
+	entities, err := archetype.GenerateAndReturnEntity(2, shared)
+	if err != nil {
+		t.Fatal(err)
+	}
+	starts := []vector.Two{{X: 0, Y: 10}, {X: 50, Y: 30}}
+	for i, en := range entities {
+		spatial.Components.Position.GetFromEntity(en).Two = starts[i]
+	}
+
+	// Forward pass, then the yoyo returns to where each entity started
+	for range 4 {
+		if err := (System{}).Run(scene, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, en := range entities {
+		got := spatial.Components.Position.GetFromEntity(en).Two
+		if got != starts[i] {
+			t.Errorf("entity %d ended at %v, want its start %v", i, got, starts[i])
+		}
+		tw := Components.Tween.GetFromEntity(en)
+		if !tw.Steps[0].FromCurrent || tw.Steps[0].From != (vector.Two{}) {
+			t.Errorf("entity %d: playback modified its steps: %+v", i, tw.Steps[0])
+		}
+	}
+	if !shared.Steps[0].FromCurrent || shared.Steps[0].From != (vector.Two{}) {
+		t.Errorf("playback modified the generating tween: %+v", shared.Steps[0])
+	}
+
+	first := Components.Tween.GetFromEntity(entities[0])
+	second := Components.Tween.GetFromEntity(entities[1])
+	if &first.Steps[0] == &second.Steps[0] || &first.Steps[0] == &shared.Steps[0] {
+		t.Error("generated tweens share their steps")
+	}
+}
@@ -0,0 +1,209 @@
+package vector
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Fixed is a signed Q32.32 fixed-point number
+//
+// All arithmetic is integer based, so results are bit identical on every platform (native, WASM)
+// unlike float64 math where fused multiply-add and transcendental implementations may differ
+type Fixed int64
+
+const (
+	fixedFracBits = 32
+
+	// FixedOne is 1.0 in fixed-point
+	FixedOne Fixed = 1 << fixedFracBits
+	// FixedHalf is 0.5 in fixed-point
+	FixedHalf Fixed = FixedOne >> 1
+
+	// FixedPi is π rounded to the nearest fixed-point value
+	FixedPi Fixed = 13493037705
+	// FixedHalfPi is π/2 rounded to the nearest fixed-point value
+	FixedHalfPi Fixed = 6746518852
+	// FixedTwoPi is 2π rounded to the nearest fixed-point value
+	FixedTwoPi Fixed = 26986075409
+
+	fixedMax Fixed = math.MaxInt64
+	fixedMin Fixed = math.MinInt64
+)
+
+// FixedFromFloat converts a float64 to the nearest fixed-point value
+// Values beyond the range (about ±2^31) saturate to the largest value of their sign and NaN becomes zero
+func FixedFromFloat(f float64) Fixed {
+	scaled := math.Round(f * float64(FixedOne))
+	switch {
+	case math.IsNaN(scaled):
+		return 0
+	case scaled >= float64(fixedMax):
+		return fixedMax
+	case scaled <= float64(fixedMin):
+		return fixedMin
+	}
+	return Fixed(scaled)
+}
+
+// FixedFromInt converts an integer to fixed-point
+func FixedFromInt(i int) Fixed {
+	return Fixed(i) << fixedFracBits
+}
+
+// Float converts the value to float64
+func (f Fixed) Float() float64 {
+	return float64(f) / float64(FixedOne)
+}
+
+// Int returns the integer part, truncated toward zero
+func (f Fixed) Int() int {
+	if f < 0 {
+		return -int(-f >> fixedFracBits)
+	}
+	return int(f >> fixedFracBits)
+}
+
+// Abs returns the absolute value
+func (f Fixed) Abs() Fixed {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+// Mul returns f*g, truncated toward zero
+func (f Fixed) Mul(g Fixed) Fixed {
+	neg := (f < 0) != (g < 0)
+	hi, lo := bits.Mul64(uint64(f.Abs()), uint64(g.Abs()))
+	if hi>>(fixedFracBits-1) != 0 {
+		return saturate(neg)
+	}
+	result := Fixed(hi<<fixedFracBits | lo>>fixedFracBits)
+	if neg {
+		return -result
+	}
+	return result
+}
+
+// Div returns f/g, truncated toward zero
+// Division by zero and overflow saturate to the largest value of the result's sign
+func (f Fixed) Div(g Fixed) Fixed {
+	neg := (f < 0) != (g < 0)
+	if g == 0 {
+		return saturate(f < 0)
+	}
+	num, den := uint64(f.Abs()), uint64(g.Abs())
+	hi, lo := num>>fixedFracBits, num<<fixedFracBits
+	if hi >= den {
+		return saturate(neg)
+	}
+	q, _ := bits.Div64(hi, lo, den)
+	if q > uint64(fixedMax) {
+		return saturate(neg)
+	}
+	if neg {
+		return -Fixed(q)
+	}
+	return Fixed(q)
+}
+
+// Sqrt returns the square root, zero for negative values
+func (f Fixed) Sqrt() Fixed {
+	if f <= 0 {
+		return 0
+	}
+	// sqrt(raw * 2^32) = sqrt(raw) * 2^16, start just above it and let Newton steps recover the dropped bits
+	y := Fixed((isqrt64(uint64(f)) + 1) << (fixedFracBits / 2))
+	for {
+		next := (y + f.Div(y)) / 2
+		if next >= y {
+			break
+		}
+		y = next
+	}
+	return y
+}
+
+// cordicAtan holds atan(2^-i) in fixed-point for each CORDIC iteration
+var cordicAtan = [32]Fixed{
+	3373259426, 1991351318, 1052175346, 534100635, 268086748, 134174063, 67103403, 33553749,
+	16777131, 8388597, 4194303, 2097152, 1048576, 524288, 262144, 131072,
+	65536, 32768, 16384, 8192, 4096, 2048, 1024, 512,
+	256, 128, 64, 32, 16, 8, 4, 2,
+}
+
+// cordicGain is the reciprocal of the CORDIC gain after all iterations
+const cordicGain Fixed = 2608131496
+
+// SinCos returns the sine and cosine of an angle in radians using CORDIC
+func (f Fixed) SinCos() (sin, cos Fixed) {
+	angle := f % FixedTwoPi
+	if angle > FixedPi {
+		angle -= FixedTwoPi
+	} else if angle < -FixedPi {
+		angle += FixedTwoPi
+	}
+
+	// CORDIC converges on [-π/2, π/2], fold the rest of the circle onto it
+	flip := false
+	if angle > FixedHalfPi {
+		angle -= FixedPi
+		flip = true
+	} else if angle < -FixedHalfPi {
+		angle += FixedPi
+		flip = true
+	}
+
+	x, y, z := cordicGain, Fixed(0), angle
+	for i, step := range cordicAtan {
+		if z >= 0 {
+			x, y = x-(y>>i), y+(x>>i)
+			z -= step
+		} else {
+			x, y = x+(y>>i), y-(x>>i)
+			z += step
+		}
+	}
+	if flip {
+		return -y, -x
+	}
+	return y, x
+}
+
+// Sin returns the sine of an angle in radians
+func (f Fixed) Sin() Fixed {
+	sin, _ := f.SinCos()
+	return sin
+}
+
+// Cos returns the cosine of an angle in radians
+func (f Fixed) Cos() Fixed {
+	_, cos := f.SinCos()
+	return cos
+}
+
+func saturate(negative bool) Fixed {
+	if negative {
+		return fixedMin
+	}
+	return fixedMax
+}
+
+// isqrt64 returns the floor of the square root of n
+func isqrt64(n uint64) uint64 {
+	var result uint64
+	bit := uint64(1) << 62
+	for bit > n {
+		bit >>= 2
+	}
+	for bit != 0 {
+		if n >= result+bit {
+			n -= result + bit
+			result = result>>1 + bit
+		} else {
+			result >>= 1
+		}
+		bit >>= 2
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+// fixedEpsilon allows for the truncation of a few fixed-point operations
+const fixedEpsilon = 1e-8
+
+func TestFixedFromFloatSaturates(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want Fixed
+	}{
+		{1.5, FixedOne + FixedHalf},
+		{-2, -2 * FixedOne},
+		{3e9, fixedMax},
+		{-3e9, fixedMin},
+		{math.Inf(1), fixedMax},
+		{math.Inf(-1), fixedMin},
+		{math.NaN(), 0},
+		{1e-12, 0},
+	}
+	for _, tt := range tests {
+		if got := FixedFromFloat(tt.in); got != tt.want {
+			t.Errorf("FixedFromFloat(%v) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFixedMulDiv(t *testing.T) {
+	checkProperty(t, func(a, b float64) bool {
+		fa, fb := FixedFromFloat(a), FixedFromFloat(b)
+		product := fa.Mul(fb).Float()
+		if !nearlyEqual(product, a*b, math.Abs(a*b)) {
+			return false
+		}
+		if math.Abs(b) < 1e-3 {
+			return true
+		}
+		return nearlyEqual(fa.Div(fb).Float(), a/b, math.Abs(a/b))
+	})
+
+	// Truncation is toward zero for both signs
+	third := FixedOne.Div(FixedFromInt(3))
+	if neg := (-FixedOne).Div(FixedFromInt(3)); neg != -third {
+		t.Errorf("-1/3 = %d, want %d", neg, -third)
+	}
+
+	// Overflow and division by zero saturate
+	big := FixedFromInt(1 << 20)
+	if got := big.Mul(big); got != fixedMax {
+		t.Errorf("2^20 * 2^20 = %d, want saturation to %d", got, fixedMax)
+	}
+	if got := big.Mul(-big); got != fixedMin {
+		t.Errorf("2^20 * -2^20 = %d, want saturation to %d", got, fixedMin)
+	}
+	if got := FixedOne.Div(0); got != fixedMax {
+		t.Errorf("1/0 = %d, want %d", got, fixedMax)
+	}
+	if got := (-FixedOne).Div(0); got != fixedMin {
+		t.Errorf("-1/0 = %d, want %d", got, fixedMin)
+	}
+	if got := big.Div(FixedOne >> 20); got != fixedMax {
+		t.Errorf("2^20 / 2^-20 = %d, want saturation to %d", got, fixedMax)
+	}
+}
+
+func TestFixedSqrt(t *testing.T) {
+	for _, x := range []float64{0, 1e-9, 0.25, 1, 2, 1000.5, 1 << 30, 2147483647} {
+		// Compare against the value actually stored, 1e-9 is only a few units of the last place
+		fx := FixedFromFloat(x)
+		got, want := fx.Sqrt().Float(), math.Sqrt(fx.Float())
+		if !nearlyEqual(got, want, want) {
+			t.Errorf("Sqrt(%v) = %v, want %v", x, got, want)
+		}
+	}
+	if got := FixedFromInt(-4).Sqrt(); got != 0 {
+		t.Errorf("Sqrt(-4) = %v, want 0", got.Float())
+	}
+	if got := FixedFromInt(4).Sqrt(); got != FixedFromInt(2) {
+		t.Errorf("Sqrt(4) = %v, want exactly 2", got.Float())
+	}
+}
+
+func TestFixedSinCos(t *testing.T) {
+	for deg := -720; deg <= 720; deg += 15 {
+		angle := float64(deg) * math.Pi / 180
+		sin, cos := FixedFromFloat(angle).SinCos()
+		if math.Abs(sin.Float()-math.Sin(angle)) > fixedEpsilon || math.Abs(cos.Float()-math.Cos(angle)) > fixedEpsilon {
+			t.Errorf("SinCos(%d°) = %v, %v, want %v, %v", deg, sin.Float(), cos.Float(), math.Sin(angle), math.Cos(angle))
+		}
+	}
+}
+
+func TestTwoFixedMagNorm(t *testing.T) {
+	checkProperty(t, func(v Two) bool {
+		fixed := v.ToFixed()
+		mag := fixed.Mag().Float()
+		if !nearlyEqual(mag, math.Hypot(v.X, v.Y), mag) {
+			return false
+		}
+		if mag == 0 {
+			return true
+		}
+		return nearlyEqual(fixed.Norm().Two().Mag(), 1, 1)
+	})
+
+	if got := NewTwoFixed(3, 4).Mag(); got != FixedFromInt(5) {
+		t.Errorf("Mag(3, 4) = %v, want exactly 5", got.Float())
+	}
+	if got := (TwoFixed{}).Norm(); got != (TwoFixed{}) {
+		t.Errorf("Norm of zero = %v, want zero", got)
+	}
+	// Components near the edge of the range still have a magnitude, saturated when it does not fit
+	huge := TwoFixed{X: fixedMax, Y: fixedMax}
+	if got := huge.Mag(); got != fixedMax {
+		t.Errorf("Mag of saturated components = %d, want %d", got, fixedMax)
+	}
+	// Squares that fit on their own but not summed saturate instead of wrapping negative
+	if got := NewTwoFixed(40000, 40000).MagSquared(); got != fixedMax {
+		t.Errorf("MagSquared(40000, 40000) = %d, want %d", got, fixedMax)
+	}
+	if got := NewTwoFixed(3, 4).MagSquared(); got != FixedFromInt(25) {
+		t.Errorf("MagSquared(3, 4) = %v, want exactly 25", got.Float())
+	}
+	edge := NewTwoFixed(1e9, 1e9)
+	if got := edge.Mag().Float(); !nearlyEqual(got, math.Sqrt2*1e9, 1e9) {
+		t.Errorf("Mag(1e9, 1e9) = %v, want %v", got, math.Sqrt2*1e9)
+	}
+	if got := edge.Norm().Two(); !nearlyEqualTwo(got, Two{X: math.Sqrt2 / 2, Y: math.Sqrt2 / 2}, 1) {
+		t.Errorf("Norm(1e9, 1e9) = %v", got)
+	}
+}
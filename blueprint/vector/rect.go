@@ -0,0 +1,99 @@
+package vector
+
+import "math"
+
+// Rect is an axis-aligned rectangle spanning Min to Max
+// A rect with Max smaller than Min on either axis is empty
+type Rect struct {
+	Min, Max Two
+}
+
+// NewRect creates a rect from its top left corner and size
+func NewRect(x, y, width, height float64) Rect {
+	return Rect{Min: Two{X: x, Y: y}, Max: Two{X: x + width, Y: y + height}}
+}
+
+// RectFromCenter creates a rect centered on a point
+func RectFromCenter(center Two, width, height float64) Rect {
+	half := Two{X: width / 2, Y: height / 2}
+	return Rect{Min: center.Sub(half), Max: center.Add(half)}
+}
+
+// Width returns the horizontal size of the rect
+func (r Rect) Width() float64 {
+	return r.Max.X - r.Min.X
+}
+
+// Height returns the vertical size of the rect
+func (r Rect) Height() float64 {
+	return r.Max.Y - r.Min.Y
+}
+
+// Size returns the width and height of the rect as a vector
+func (r Rect) Size() Two {
+	return r.Max.Sub(r.Min)
+}
+
+// Center returns the midpoint of the rect
+func (r Rect) Center() Two {
+	return r.Min.Lerp(r.Max, 0.5)
+}
+
+// Empty reports whether the rect has no area
+func (r Rect) Empty() bool {
+	return r.Max.X <= r.Min.X || r.Max.Y <= r.Min.Y
+}
+
+// Contains reports whether the point lies inside the rect, edges included
+func (r Rect) Contains(point Two) bool {
+	return point.X >= r.Min.X && point.X <= r.Max.X && point.Y >= r.Min.Y && point.Y <= r.Max.Y
+}
+
+// ContainsRect reports whether the other rect lies completely inside this one
+func (r Rect) ContainsRect(other Rect) bool {
+	return other.Min.X >= r.Min.X && other.Max.X <= r.Max.X && other.Min.Y >= r.Min.Y && other.Max.Y <= r.Max.Y
+}
+
+// Intersects reports whether the rects overlap with a positive area
+func (r Rect) Intersects(other Rect) bool {
+	return r.Min.X < other.Max.X && other.Min.X < r.Max.X && r.Min.Y < other.Max.Y && other.Min.Y < r.Max.Y
+}
+
+// Intersect returns the overlapping area of the rects
+// Returns false, and an empty rect, when they do not overlap
+func (r Rect) Intersect(other Rect) (Rect, bool) {
+	result := Rect{
+		Min: Two{X: math.Max(r.Min.X, other.Min.X), Y: math.Max(r.Min.Y, other.Min.Y)},
+		Max: Two{X: math.Min(r.Max.X, other.Max.X), Y: math.Min(r.Max.Y, other.Max.Y)},
+	}
+	if result.Empty() {
+		return Rect{}, false
+	}
+	return result, true
+}
+
+// Union returns the smallest rect containing both rects
+// Empty rects are ignored
+func (r Rect) Union(other Rect) Rect {
+	if r.Empty() {
+		return other
+	}
+	if other.Empty() {
+		return r
+	}
+	return Rect{
+		Min: Two{X: math.Min(r.Min.X, other.Min.X), Y: math.Min(r.Min.Y, other.Min.Y)},
+		Max: Two{X: math.Max(r.Max.X, other.Max.X), Y: math.Max(r.Max.Y, other.Max.Y)},
+	}
+}
+
+// Translate returns the rect moved by the offset
+func (r Rect) Translate(offset Two) Rect {
+	return Rect{Min: r.Min.Add(offset), Max: r.Max.Add(offset)}
+}
+
+// Expand returns the rect grown by the margin on every side, negative margins shrink it
+func (r Rect) Expand(margin float64) Rect {
+	m := Two{X: margin, Y: margin}
+	return Rect{Min: r.Min.Sub(m), Max: r.Max.Add(m)}
+}
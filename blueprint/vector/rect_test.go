@@ -0,0 +1,116 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRectIntersect(t *testing.T) {
+	checkProperty(t, func(a, b Rect, p Two) bool {
+		inter, ok := a.Intersect(b)
+		if ok != a.Intersects(b) {
+			return false
+		}
+		if !ok {
+			return inter == Rect{}
+		}
+		// The intersection lies in both rects and is symmetric
+		other, _ := b.Intersect(a)
+		return a.ContainsRect(inter) && b.ContainsRect(inter) && inter == other &&
+			(inter.Contains(p) == (a.Contains(p) && b.Contains(p)))
+	})
+}
+
+func TestRectUnion(t *testing.T) {
+	checkProperty(t, func(a, b Rect, p Two) bool {
+		u := a.Union(b)
+		if (a.Contains(p) || b.Contains(p)) && !a.Empty() && !b.Empty() && !u.Contains(p) {
+			return false
+		}
+		return u.ContainsRect(a) && u.ContainsRect(b) && u == b.Union(a)
+	})
+	r := NewRect(1, 2, 3, 4)
+	if got := r.Union(Rect{}); got != r {
+		t.Errorf("Union with empty rect = %v, want %v", got, r)
+	}
+}
+
+func TestRectIntersectDisjoint(t *testing.T) {
+	checkProperty(t, func(a Rect, p Two) bool {
+		// A copy moved past the rect's width or height never overlaps it
+		right := a.Translate(Two{X: a.Width() + 1 + math.Abs(p.X), Y: p.Y})
+		below := a.Translate(Two{X: p.X, Y: a.Height() + 1 + math.Abs(p.Y)})
+		_, okRight := a.Intersect(right)
+		_, okBelow := a.Intersect(below)
+		return !okRight && !okBelow && !a.Intersects(right) && !a.Intersects(below)
+	})
+}
+
+func TestRectContainsProperty(t *testing.T) {
+	checkProperty(t, func(r Rect, p Two, margin float64) bool {
+		// A point is contained exactly when clamping it to the bounds leaves it unchanged
+		if r.Contains(p) != (p.Clamp(r.Min, r.Max) == p) {
+			return false
+		}
+		if !r.Contains(p.Clamp(r.Min, r.Max)) {
+			return false
+		}
+		if !r.Contains(r.Min) || !r.Contains(r.Max) || !r.Contains(r.Center()) || !r.ContainsRect(r) {
+			return false
+		}
+		// Expanding keeps the original inside and only a zero margin keeps the same bounds
+		grown := r.Expand(math.Abs(margin))
+		return grown.ContainsRect(r) && (r.ContainsRect(grown) == (margin == 0))
+	})
+}
+
+func TestRectContains(t *testing.T) {
+	r := NewRect(0, 0, 10, 5)
+	tests := []struct {
+		point Two
+		want  bool
+	}{
+		{Two{X: 5, Y: 2}, true},
+		{Two{X: 0, Y: 0}, true},
+		{Two{X: 10, Y: 5}, true},
+		{Two{X: -0.1, Y: 2}, false},
+		{Two{X: 5, Y: 5.1}, false},
+	}
+	for _, tt := range tests {
+		if got := r.Contains(tt.point); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.point, got, tt.want)
+		}
+	}
+	if c := r.Center(); c != (Two{X: 5, Y: 2.5}) {
+		t.Errorf("Center = %v, want {5 2.5}", c)
+	}
+	if !RectFromCenter(Two{X: 5, Y: 2.5}, 10, 5).ContainsRect(r) {
+		t.Error("RectFromCenter does not cover the equivalent rect")
+	}
+}
+
+var (
+	benchRect Rect
+	benchOK   bool
+)
+
+func BenchmarkRectIntersect(b *testing.B) {
+	r1, r2 := NewRect(0, 0, 10, 10), NewRect(5, 5, 10, 10)
+	for i := 0; i < b.N; i++ {
+		benchRect, benchOK = r1.Intersect(r2)
+	}
+}
+
+func BenchmarkRectUnion(b *testing.B) {
+	r1, r2 := NewRect(0, 0, 10, 10), NewRect(5, 5, 10, 10)
+	for i := 0; i < b.N; i++ {
+		benchRect = r1.Union(r2)
+	}
+}
+
+func BenchmarkRectContains(b *testing.B) {
+	r := NewRect(0, 0, 10, 10)
+	for i := 0; i < b.N; i++ {
+		benchOK = r.Contains(benchA)
+	}
+}
@@ -0,0 +1,99 @@
+package vector
+
+import "math"
+
+// Transform is a 2x3 affine matrix mapping points as
+//
+//	| A C TX |   | x |
+//	| B D TY | * | y |
+//	             | 1 |
+type Transform struct {
+	A, B, C, D float64
+	TX, TY     float64
+}
+
+// IdentityTransform returns the transform that leaves points unchanged
+func IdentityTransform() Transform {
+	return Transform{A: 1, D: 1}
+}
+
+// NewTranslation returns a transform that moves points by the offset
+func NewTranslation(offset Two) Transform {
+	return Transform{A: 1, D: 1, TX: offset.X, TY: offset.Y}
+}
+
+// NewRotation returns a transform that rotates points around the origin
+func NewRotation(radians float64) Transform {
+	sin, cos := math.Sincos(radians)
+	return Transform{A: cos, B: sin, C: -sin, D: cos}
+}
+
+// NewScaling returns a transform that scales points from the origin
+func NewScaling(scale Two) Transform {
+	return Transform{A: scale.X, D: scale.Y}
+}
+
+// NewTransform returns a transform that scales, then rotates, then translates
+// This matches how position, rotation and scale components combine for rendering
+func NewTransform(position Two, radians float64, scale Two) Transform {
+	sin, cos := math.Sincos(radians)
+	return Transform{
+		A: cos * scale.X, B: sin * scale.X,
+		C: -sin * scale.Y, D: cos * scale.Y,
+		TX: position.X, TY: position.Y,
+	}
+}
+
+// Compose returns the transform that applies other first and then t
+func (t Transform) Compose(other Transform) Transform {
+	return Transform{
+		A:  t.A*other.A + t.C*other.B,
+		B:  t.B*other.A + t.D*other.B,
+		C:  t.A*other.C + t.C*other.D,
+		D:  t.B*other.C + t.D*other.D,
+		TX: t.A*other.TX + t.C*other.TY + t.TX,
+		TY: t.B*other.TX + t.D*other.TY + t.TY,
+	}
+}
+
+// Determinant returns the determinant of the linear part
+func (t Transform) Determinant() float64 {
+	return t.A*t.D - t.B*t.C
+}
+
+// Invert returns the inverse transform
+// Returns false when the transform is singular and cannot be inverted
+func (t Transform) Invert() (Transform, bool) {
+	det := t.Determinant()
+	if det == 0 {
+		return Transform{}, false
+	}
+	inv := 1 / det
+	a, b, c, d := t.D*inv, -t.B*inv, -t.C*inv, t.A*inv
+	return Transform{
+		A: a, B: b, C: c, D: d,
+		TX: -(a*t.TX + c*t.TY),
+		TY: -(b*t.TX + d*t.TY),
+	}, true
+}
+
+// Apply transforms a point, including translation
+func (t Transform) Apply(point Two) Two {
+	return Two{
+		X: t.A*point.X + t.C*point.Y + t.TX,
+		Y: t.B*point.X + t.D*point.Y + t.TY,
+	}
+}
+
+// ApplyVector transforms a direction, ignoring translation
+func (t Transform) ApplyVector(v Two) Two {
+	return Two{
+		X: t.A*v.X + t.C*v.Y,
+		Y: t.B*v.X + t.D*v.Y,
+	}
+}
+
+// Translation returns the translation part of the transform
+func (t Transform) Translation() Two {
+	return Two{X: t.TX, Y: t.TY}
+}
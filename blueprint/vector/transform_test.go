@@ -0,0 +1,76 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+// randomTransform builds a well conditioned transform from generated values
+func randomTransform(pos Two, angle float64, scale Two) Transform {
+	s := Two{X: 0.5 + math.Abs(math.Mod(scale.X, 4)), Y: 0.5 + math.Abs(math.Mod(scale.Y, 4))}
+	return NewTransform(pos, angle, s)
+}
+
+func TestTransformInvert(t *testing.T) {
+	checkProperty(t, func(pos Two, angle float64, scale, point Two) bool {
+		tr := randomTransform(pos, angle, scale)
+		inv, ok := tr.Invert()
+		if !ok {
+			return false
+		}
+		s := pos.Mag() + point.Mag()
+		return nearlyEqualTwo(inv.Apply(tr.Apply(point)), point, s) &&
+			nearlyEqualTwo(tr.Compose(inv).Apply(point), point, s)
+	})
+	if _, ok := NewScaling(Two{X: 0, Y: 1}).Invert(); ok {
+		t.Error("Invert succeeded on a singular transform")
+	}
+}
+
+func TestTransformCompose(t *testing.T) {
+	checkProperty(t, func(posA Two, angleA float64, posB Two, angleB float64, point Two) bool {
+		a := randomTransform(posA, angleA, Two{X: 1, Y: 2})
+		b := randomTransform(posB, angleB, Two{X: 3, Y: 1})
+		s := (posA.Mag() + posB.Mag() + point.Mag()) * 8
+		// Compose applies b first, then a
+		return nearlyEqualTwo(a.Compose(b).Apply(point), a.Apply(b.Apply(point)), s) &&
+			nearlyEqualTwo(IdentityTransform().Compose(a).Apply(point), a.Apply(point), s)
+	})
+}
+
+func TestTransformMatchesVectorOps(t *testing.T) {
+	checkProperty(t, func(pos Two, angle float64, point Two) bool {
+		scale := Two{X: 2, Y: 3}
+		got := NewTransform(pos, angle, scale).Apply(point)
+		want := Two{X: point.X * scale.X, Y: point.Y * scale.Y}.Rotate(angle).Add(pos)
+		rotated := NewRotation(angle).ApplyVector(point)
+		s := (pos.Mag() + point.Mag()) * 4
+		return nearlyEqualTwo(got, want, s) &&
+			nearlyEqualTwo(rotated, point.Rotate(angle), s) &&
+			NewTranslation(pos).ApplyVector(point) == point
+	})
+}
+
+var benchTransform Transform
+
+func BenchmarkTransformApply(b *testing.B) {
+	tr := NewTransform(Two{X: 10, Y: 20}, 0.5, Two{X: 2, Y: 2})
+	for i := 0; i < b.N; i++ {
+		benchSink = tr.Apply(benchA)
+	}
+}
+
+func BenchmarkTransformCompose(b *testing.B) {
+	a := NewTransform(Two{X: 10, Y: 20}, 0.5, Two{X: 2, Y: 2})
+	c := NewRotation(1.2)
+	for i := 0; i < b.N; i++ {
+		benchTransform = a.Compose(c)
+	}
+}
+
+func BenchmarkTransformInvert(b *testing.B) {
+	a := NewTransform(Two{X: 10, Y: 20}, 0.5, Two{X: 2, Y: 2})
+	for i := 0; i < b.N; i++ {
+		benchTransform, _ = a.Invert()
+	}
+}
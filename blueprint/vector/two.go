@@ -1,9 +1,5 @@
 package vector
 
-import (
-	"math"
-)
-
 // TwoFace provides a complete interface for 2D vector operations
 type TwoFace interface {
 	TwoWriter
@@ -69,35 +65,11 @@ func (v2 Two) Perpendicular() Two {
 	return v2
 }
 
-// Mag returns the magnitude (length) of the vector
-func (v2 Two) Mag() float64 {
-	return math.Sqrt((v2.X * v2.X) + (v2.Y * v2.Y))
-}
-
 // MagSquared returns the squared magnitude of the vector
 func (v2 Two) MagSquared() float64 {
 	return (v2.X * v2.X) + (v2.Y * v2.Y)
 }
 
-// Norm returns a normalized (unit length) version of the vector
-func (v2 Two) Norm() Two {
-	len := v2.Mag()
-	if len != 0 {
-		v2.X = v2.X / len
-		v2.Y = v2.Y / len
-	}
-	return v2
-}
-
-// Rotate rotates the vector by the specified angle in radians
-func (v2 Two) Rotate(radians float64) Two {
-	newX := v2.X*math.Cos(radians) - v2.Y*math.Sin(radians)
-	newY := v2.X*math.Sin(radians) + v2.Y*math.Cos(radians)
-	v2.X = newX
-	v2.Y = newY
-	return v2
-}
-
 // RotateAroundPoint rotates the vector around a specified point
 func (v2 Two) RotateAroundPoint(radians float64, point Two) Two {
 	origin := Two{X: 0, Y: 0}
@@ -0,0 +1,61 @@
+//go:build fixedpoint
+
+package vector
+
+import "math"
+
+// Built with -tags fixedpoint, the transcendental operations of Two (Mag, Norm and Rotate) run on TwoFixed,
+// so their results no longer depend on the platform's sqrt, sin and cos. Everything else stays float64
+
+// Mag returns the magnitude (length) of the vector
+func (v2 Two) Mag() float64 {
+	scaled, exp, ok := v2.fixedScaled()
+	if !ok {
+		return math.Hypot(v2.X, v2.Y)
+	}
+	return math.Ldexp(scaled.Mag().Float(), exp)
+}
+
+// Norm returns a normalized (unit length) version of the vector
+func (v2 Two) Norm() Two {
+	if v2.X == 0 && v2.Y == 0 {
+		return v2
+	}
+	scaled, _, ok := v2.fixedScaled()
+	if !ok {
+		mag := math.Hypot(v2.X, v2.Y)
+		return Two{X: v2.X / mag, Y: v2.Y / mag}
+	}
+	return scaled.Norm().Two()
+}
+
+// Rotate rotates the vector by the specified angle in radians
+func (v2 Two) Rotate(radians float64) Two {
+	scaled, exp, ok := v2.fixedScaled()
+	if !ok {
+		sin, cos := math.Sincos(radians)
+		return Two{X: v2.X*cos - v2.Y*sin, Y: v2.X*sin + v2.Y*cos}
+	}
+	rotated := scaled.Rotate(FixedFromFloat(radians)).Two()
+	return Two{X: math.Ldexp(rotated.X, exp), Y: math.Ldexp(rotated.Y, exp)}
+}
+
+// fixedScaled converts the vector to fixed-point, scaled by 2^-exp when its components are too large
+// for the fixed-point range or too small for its precision. Scaling by a power of two is exact,
+// so the results stay deterministic. Reports false for infinite and NaN components
+func (v2 Two) fixedScaled() (scaled TwoFixed, exp int, ok bool) {
+	largest := math.Max(math.Abs(v2.X), math.Abs(v2.Y))
+	if math.IsInf(largest, 0) || math.IsNaN(largest) {
+		return TwoFixed{}, 0, false
+	}
+	if largest != 0 {
+		_, e := math.Frexp(largest)
+		switch {
+		case e > 30:
+			exp = e - 30
+		case e < -8:
+			exp = e
+		}
+	}
+	return Two{X: math.Ldexp(v2.X, -exp), Y: math.Ldexp(v2.Y, -exp)}.ToFixed(), exp, true
+}
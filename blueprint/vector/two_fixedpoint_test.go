@@ -0,0 +1,26 @@
+//go:build fixedpoint
+
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTwoFixedPointRange(t *testing.T) {
+	if got := (Two{X: 3e9, Y: 4e9}).Mag(); !nearlyEqual(got, 5e9, 5e9) {
+		t.Errorf("Mag(3e9, 4e9) = %v, want 5e9", got)
+	}
+	if got := (Two{X: 1e-12, Y: 0}).Norm(); !nearlyEqualTwo(got, Two{X: 1}, 1) {
+		t.Errorf("Norm(1e-12, 0) = %v, want {1 0}", got)
+	}
+	if got := (Two{X: 3e-12, Y: 4e-12}).Mag(); !nearlyEqual(got, 5e-12, 1e-12) || got == 0 {
+		t.Errorf("Mag(3e-12, 4e-12) = %v, want 5e-12", got)
+	}
+	if got := (Two{X: 1e10, Y: 0}).Rotate(math.Pi / 2); !nearlyEqualTwo(got, Two{Y: 1e10}, 1e10) {
+		t.Errorf("Rotate(1e10, 0) by π/2 = %v, want {0 1e10}", got)
+	}
+	if got := (Two{X: math.Inf(1)}).Mag(); !math.IsInf(got, 1) {
+		t.Errorf("Mag(+Inf, 0) = %v, want +Inf", got)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !fixedpoint
+
+package vector
+
+import "math"
+
+// Mag returns the magnitude (length) of the vector
+func (v2 Two) Mag() float64 {
+	return math.Sqrt((v2.X * v2.X) + (v2.Y * v2.Y))
+}
+
+// Norm returns a normalized (unit length) version of the vector
+func (v2 Two) Norm() Two {
+	len := v2.Mag()
+	if len != 0 {
+		v2.X = v2.X / len
+		v2.Y = v2.Y / len
+	}
+	return v2
+}
+
+// Rotate rotates the vector by the specified angle in radians
+func (v2 Two) Rotate(radians float64) Two {
+	newX := v2.X*math.Cos(radians) - v2.Y*math.Sin(radians)
+	newY := v2.X*math.Sin(radians) + v2.Y*math.Cos(radians)
+	v2.X = newX
+	v2.Y = newY
+	return v2
+}
@@ -0,0 +1,187 @@
+package vector
+
+// TwoFixed represents a 2D vector with Q32.32 fixed-point components
+// Every operation is integer based, making it safe for lockstep simulation across platforms
+type TwoFixed struct {
+	X, Y Fixed
+}
+
+var _ TwoFace = &TwoFixed{}
+
+// NewTwoFixed creates a fixed-point vector from float components
+func NewTwoFixed(x, y float64) TwoFixed {
+	return TwoFixed{X: FixedFromFloat(x), Y: FixedFromFloat(y)}
+}
+
+// ToFixed converts the vector to fixed-point
+func (v2 Two) ToFixed() TwoFixed {
+	return TwoFixed{X: FixedFromFloat(v2.X), Y: FixedFromFloat(v2.Y)}
+}
+
+// Two converts the vector to float64 components
+func (v2 TwoFixed) Two() Two {
+	return Two{X: v2.X.Float(), Y: v2.Y.Float()}
+}
+
+// Scale multiplies the vector by a scalar value
+func (v2 TwoFixed) Scale(n Fixed) TwoFixed {
+	return TwoFixed{v2.X.Mul(n), v2.Y.Mul(n)}
+}
+
+// ScalarProduct calculates the dot product of two vectors
+func (v2a TwoFixed) ScalarProduct(v2b TwoFixed) Fixed {
+	return v2a.X.Mul(v2b.X) + v2a.Y.Mul(v2b.Y)
+}
+
+// CrossProduct calculates the cross product of two vectors
+func (v2a TwoFixed) CrossProduct(v2b TwoFixed) Fixed {
+	return v2a.X.Mul(v2b.Y) - v2a.Y.Mul(v2b.X)
+}
+
+// Add returns the sum of two vectors
+func (v2a TwoFixed) Add(v2b TwoFixed) TwoFixed {
+	return TwoFixed{X: v2a.X + v2b.X, Y: v2a.Y + v2b.Y}
+}
+
+// Sub returns the difference of two vectors
+func (v2a TwoFixed) Sub(v2b TwoFixed) TwoFixed {
+	return TwoFixed{X: v2a.X - v2b.X, Y: v2a.Y - v2b.Y}
+}
+
+// Perpendicular returns a vector perpendicular to this one
+func (v2 TwoFixed) Perpendicular() TwoFixed {
+	return TwoFixed{X: v2.Y, Y: -v2.X}
+}
+
+// Mag returns the magnitude (length) of the vector
+// Large components are scaled down before squaring so the intermediate values cannot overflow,
+// and a magnitude beyond the fixed-point range saturates
+func (v2 TwoFixed) Mag() Fixed {
+	shift := 0
+	for x, y := v2.X.Abs(), v2.Y.Abs(); x >= 1<<46 || y >= 1<<46; x, y = x>>1, y>>1 {
+		shift++
+	}
+	scaled := TwoFixed{X: v2.X >> shift, Y: v2.Y >> shift}
+	mag := scaled.MagSquared().Sqrt()
+	if mag > fixedMax>>shift {
+		return fixedMax
+	}
+	return mag << shift
+}
+
+// MagSquared returns the squared magnitude of the vector, saturating beyond the fixed-point range
+func (v2 TwoFixed) MagSquared() Fixed {
+	x, y := v2.X.Mul(v2.X), v2.Y.Mul(v2.Y)
+	if x > fixedMax-y {
+		return fixedMax
+	}
+	return x + y
+}
+
+// Norm returns a normalized (unit length) version of the vector
+func (v2 TwoFixed) Norm() TwoFixed {
+	mag := v2.Mag()
+	if mag == 0 {
+		return v2
+	}
+	return TwoFixed{X: v2.X.Div(mag), Y: v2.Y.Div(mag)}
+}
+
+// Rotate rotates the vector by the specified angle in radians
+func (v2 TwoFixed) Rotate(radians Fixed) TwoFixed {
+	sin, cos := radians.SinCos()
+	return TwoFixed{
+		X: v2.X.Mul(cos) - v2.Y.Mul(sin),
+		Y: v2.X.Mul(sin) + v2.Y.Mul(cos),
+	}
+}
+
+// RotateAroundPoint rotates the vector around a specified point
+func (v2 TwoFixed) RotateAroundPoint(radians Fixed, point TwoFixed) TwoFixed {
+	return v2.Sub(point).Rotate(radians).Add(point)
+}
+
+// Equal checks if two vectors have identical components
+func (v2 TwoFixed) Equal(v2b TwoFixed) bool {
+	return v2 == v2b
+}
+
+// Clone returns a copy of the vector
+func (v2 TwoFixed) Clone() TwoFixed {
+	return v2
+}
+
+// CloneAsInterface returns a copy of the vector as a TwoFace interface
+func (v2 TwoFixed) CloneAsInterface() TwoFace {
+	clone := v2
+	return &clone
+}
+
+// SetX sets the X component of the vector
+func (v2 *TwoFixed) SetX(x float64) {
+	v2.X = FixedFromFloat(x)
+}
+
+// SetY sets the Y component of the vector
+func (v2 *TwoFixed) SetY(y float64) {
+	v2.Y = FixedFromFloat(y)
+}
+
+// SetFromInterface sets vector components from a TwoReader interface
+// Fixed-point sources are copied exactly
+func (v2 *TwoFixed) SetFromInterface(tr TwoReader) {
+	switch src := tr.(type) {
+	case TwoFixed:
+		*v2 = src
+	case *TwoFixed:
+		*v2 = *src
+	default:
+		v2.SetX(tr.GetX())
+		v2.SetY(tr.GetY())
+	}
+}
+
+// GetX returns the X component of the vector
+func (v2 TwoFixed) GetX() float64 {
+	return v2.X.Float()
+}
+
+// GetY returns the Y component of the vector
+func (v2 TwoFixed) GetY() float64 {
+	return v2.Y.Float()
+}
+
+// RotateAsInterface rotates the vector and returns the result as a TwoFace interface
+func (v2 TwoFixed) RotateAsInterface(radians float64) TwoFace {
+	rotated := v2.Rotate(FixedFromFloat(radians))
+	return &rotated
+}
+
+// AddAsInterface adds another vector and returns the result as a TwoFace interface
+func (v2 TwoFixed) AddAsInterface(v2B TwoReader) TwoFace {
+	added := v2.Add(toTwoFixed(v2B))
+	return &added
+}
+
+// SubAsInterface subtracts another vector and returns the result as a TwoFace interface
+func (v2 TwoFixed) SubAsInterface(v2B TwoReader) TwoFace {
+	sub := v2.Sub(toTwoFixed(v2B))
+	return &sub
+}
+
+// CrossProductAsInterface calculates the cross product with another vector
+func (v2 TwoFixed) CrossProductAsInterface(v2B TwoReader) float64 {
+	return v2.CrossProduct(toTwoFixed(v2B)).Float()
+}
+
+// ScaleAsInterface scales the vector and returns the result as a TwoFace interface
+func (v2 TwoFixed) ScaleAsInterface(n float64) TwoFace {
+	scaled := v2.Scale(FixedFromFloat(n))
+	return &scaled
+}
+
+func toTwoFixed(tr TwoReader) TwoFixed {
+	var v TwoFixed
+	v.SetFromInterface(tr)
+	return v
+}
@@ -0,0 +1,78 @@
+package vector
+
+import "math"
+
+// Lerp linearly interpolates between the vector and the target
+// t is not clamped, values outside [0, 1] extrapolate
+func (v2 Two) Lerp(to Two, t float64) Two {
+	return Two{X: v2.X + (to.X-v2.X)*t, Y: v2.Y + (to.Y-v2.Y)*t}
+}
+
+// Slerp interpolates the direction along the shortest arc and the magnitude linearly
+// Falls back to Lerp when either vector has zero length
+func (v2 Two) Slerp(to Two, t float64) Two {
+	fromMag, toMag := v2.Mag(), to.Mag()
+	if fromMag == 0 || toMag == 0 {
+		return v2.Lerp(to, t)
+	}
+	angle := v2.AngleTo(to)
+	mag := fromMag + (toMag-fromMag)*t
+	return v2.Scale(1 / fromMag).Rotate(angle * t).Scale(mag)
+}
+
+// Project returns the projection of the vector onto another
+// Projecting onto a zero vector yields a zero vector
+func (v2 Two) Project(onto Two) Two {
+	magSq := onto.MagSquared()
+	if magSq == 0 {
+		return Two{}
+	}
+	return onto.Scale(v2.ScalarProduct(onto) / magSq)
+}
+
+// Reflect mirrors the vector across a surface with the given normal
+// The normal does not need to be unit length
+func (v2 Two) Reflect(normal Two) Two {
+	magSq := normal.MagSquared()
+	if magSq == 0 {
+		return v2
+	}
+	return v2.Sub(normal.Scale(2 * v2.ScalarProduct(normal) / magSq))
+}
+
+// Angle returns the angle of the vector from the positive X axis in radians, within [-π, π]
+func (v2 Two) Angle() float64 {
+	return math.Atan2(v2.Y, v2.X)
+}
+
+// AngleTo returns the signed angle that rotates this vector onto another, within [-π, π]
+func (v2a Two) AngleTo(v2b Two) float64 {
+	return math.Atan2(v2a.CrossProduct(v2b), v2a.ScalarProduct(v2b))
+}
+
+// Clamp limits each component to the range given by min and max
+func (v2 Two) Clamp(min, max Two) Two {
+	return Two{
+		X: math.Max(min.X, math.Min(max.X, v2.X)),
+		Y: math.Max(min.Y, math.Min(max.Y, v2.Y)),
+	}
+}
+
+// ClampMag limits the magnitude of the vector, keeping its direction
+func (v2 Two) ClampMag(max float64) Two {
+	magSq := v2.MagSquared()
+	if magSq <= max*max {
+		return v2
+	}
+	return v2.Scale(max / math.Sqrt(magSq))
+}
+
+// DistanceTo returns the distance between two points
+func (v2a Two) DistanceTo(v2b Two) float64 {
+	return v2b.Sub(v2a).Mag()
+}
+
+// DistanceSquaredTo returns the squared distance between two points
+func (v2a Two) DistanceSquaredTo(v2b Two) float64 {
+	return v2b.Sub(v2a).MagSquared()
+}
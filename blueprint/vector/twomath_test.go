@@ -0,0 +1,177 @@
+package vector
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+const propertyEpsilon = 1e-6
+
+// checkProperty runs a property with vectors, scalars and rects drawn from a game sized range
+// testing/quick's default floats span the whole float64 range and overflow every product
+func checkProperty(t *testing.T, property interface{}) {
+	t.Helper()
+	config := &quick.Config{
+		MaxCount: 2000,
+		Values: func(args []reflect.Value, r *rand.Rand) {
+			fn := reflect.TypeOf(property)
+			for i := range args {
+				args[i] = randomValue(fn.In(i), r)
+			}
+		},
+	}
+	if err := quick.Check(property, config); err != nil {
+		t.Error(err)
+	}
+}
+
+func randomValue(typ reflect.Type, r *rand.Rand) reflect.Value {
+	scalar := func() float64 { return (r.Float64()*2 - 1) * 1000 }
+	switch typ {
+	case reflect.TypeOf(Two{}):
+		return reflect.ValueOf(Two{X: scalar(), Y: scalar()})
+	case reflect.TypeOf(Rect{}):
+		return reflect.ValueOf(NewRect(scalar(), scalar(), r.Float64()*500, r.Float64()*500))
+	case reflect.TypeOf(float64(0)):
+		return reflect.ValueOf(scalar())
+	}
+	panic("no generator for " + typ.String())
+}
+
+func nearlyEqual(a, b, scale float64) bool {
+	return math.Abs(a-b) <= propertyEpsilon*math.Max(1, scale)
+}
+
+func nearlyEqualTwo(a, b Two, scale float64) bool {
+	return nearlyEqual(a.X, b.X, scale) && nearlyEqual(a.Y, b.Y, scale)
+}
+
+func TestLerpEndpoints(t *testing.T) {
+	checkProperty(t, func(a, b Two) bool {
+		scale := a.Mag() + b.Mag()
+		return nearlyEqualTwo(a.Lerp(b, 0), a, scale) &&
+			nearlyEqualTwo(a.Lerp(b, 1), b, scale) &&
+			nearlyEqualTwo(a.Lerp(b, 0.5), a.Add(b).Scale(0.5), scale)
+	})
+}
+
+func TestSlerpProperties(t *testing.T) {
+	checkProperty(t, func(a, b Two, t float64) bool {
+		scale := a.Mag() + b.Mag()
+		frac := math.Abs(math.Mod(t, 1))
+		mid := a.Slerp(b, frac)
+		wantMag := a.Mag() + (b.Mag()-a.Mag())*frac
+		return nearlyEqualTwo(a.Slerp(b, 0), a, scale) &&
+			nearlyEqualTwo(a.Slerp(b, 1), b, scale) &&
+			nearlyEqual(mid.Mag(), wantMag, scale)
+	})
+}
+
+func TestProjectProperties(t *testing.T) {
+	checkProperty(t, func(a, b Two) bool {
+		p := a.Project(b)
+		rejection := a.Sub(p)
+		scale := a.Mag() * b.Mag()
+		// The projection lies along b and what remains is perpendicular to b
+		return nearlyEqual(p.CrossProduct(b), 0, scale) && nearlyEqual(rejection.ScalarProduct(b), 0, scale)
+	})
+	if got := (Two{X: 3, Y: 4}).Project(Two{}); got != (Two{}) {
+		t.Errorf("Project onto zero = %v, want zero vector", got)
+	}
+}
+
+func TestReflectProperties(t *testing.T) {
+	checkProperty(t, func(v, normal Two) bool {
+		r := v.Reflect(normal)
+		scale := v.Mag() * math.Max(1, normal.Mag())
+		// Reflection keeps the length, flips the normal component and is its own inverse
+		return nearlyEqual(r.Mag(), v.Mag(), scale) &&
+			nearlyEqual(r.ScalarProduct(normal), -v.ScalarProduct(normal), scale) &&
+			nearlyEqualTwo(r.Reflect(normal), v, scale)
+	})
+}
+
+func TestAngleProperties(t *testing.T) {
+	checkProperty(t, func(a, b Two) bool {
+		angle := a.AngleTo(b)
+		rotated := a.Rotate(angle)
+		// Rotating a by AngleTo(b) points it along b
+		return math.Abs(angle) <= math.Pi &&
+			nearlyEqual(rotated.CrossProduct(b), 0, a.Mag()*b.Mag()) &&
+			rotated.ScalarProduct(b) >= -propertyEpsilon
+	})
+	if got := (Two{X: 0, Y: 1}).Angle(); !nearlyEqual(got, math.Pi/2, 1) {
+		t.Errorf("Angle of up vector = %v, want π/2", got)
+	}
+}
+
+func TestClampProperties(t *testing.T) {
+	checkProperty(t, func(v, a, b Two) bool {
+		min := Two{X: math.Min(a.X, b.X), Y: math.Min(a.Y, b.Y)}
+		max := Two{X: math.Max(a.X, b.X), Y: math.Max(a.Y, b.Y)}
+		c := v.Clamp(min, max)
+		inside := c.X >= min.X && c.X <= max.X && c.Y >= min.Y && c.Y <= max.Y
+		return inside && c.Clamp(min, max) == c
+	})
+	checkProperty(t, func(v Two, max float64) bool {
+		max = math.Abs(max)
+		c := v.ClampMag(max)
+		return c.Mag() <= max+propertyEpsilon*max && nearlyEqual(c.CrossProduct(v), 0, v.MagSquared())
+	})
+}
+
+func TestDistanceProperties(t *testing.T) {
+	checkProperty(t, func(a, b, c Two) bool {
+		scale := a.Mag() + b.Mag() + c.Mag()
+		return a.DistanceTo(b) == b.DistanceTo(a) &&
+			a.DistanceTo(a) == 0 &&
+			a.DistanceTo(c) <= a.DistanceTo(b)+b.DistanceTo(c)+propertyEpsilon*scale &&
+			nearlyEqual(a.DistanceSquaredTo(b), a.DistanceTo(b)*a.DistanceTo(b), scale*scale)
+	})
+}
+
+var (
+	benchA    = Two{X: 3, Y: 4}
+	benchB    = Two{X: -7, Y: 2}
+	benchSink Two
+	benchF    float64
+)
+
+func BenchmarkLerp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchSink = benchA.Lerp(benchB, 0.3)
+	}
+}
+
+func BenchmarkSlerp(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchSink = benchA.Slerp(benchB, 0.3)
+	}
+}
+
+func BenchmarkProject(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchSink = benchA.Project(benchB)
+	}
+}
+
+func BenchmarkReflect(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchSink = benchA.Reflect(benchB)
+	}
+}
+
+func BenchmarkAngleTo(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchF = benchA.AngleTo(benchB)
+	}
+}
+
+func BenchmarkDistanceTo(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		benchF = benchA.DistanceTo(benchB)
+	}
+}
@@ -124,6 +124,7 @@ func NewTestClient(baseResX, baseResY, maxSpritesCached, maxSoundsCached, maxSce
 	cli.inputManager = newInputManager(cli)
 	ClientConfig.maxSoundsCached.Store(uint32(maxSoundsCached))
 	ClientConfig.maxSpritesCached.Store(uint32(maxSpritesCached))
+	resetGlobalCaches()
 	ClientConfig.baseResolution.x = baseResX
 	ClientConfig.baseResolution.y = baseResY
 	ClientConfig.resolution.x = baseResX
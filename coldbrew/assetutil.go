@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/TheBitDrifter/bappa/blueprint/client"
+	"github.com/TheBitDrifter/bappa/warehouse"
 )
 
 // MaterializeSprites converts a bundle of sprite blueprints into concrete Sprite objects
@@ -15,20 +16,8 @@ func MaterializeSprites(spriteBundle *client.SpriteBundle) []Sprite {
 
 	var sprites []Sprite
 	for i := range spriteBundle.Blueprints {
-		spriteBlueprint := &spriteBundle.Blueprints[i]
-		if spriteBlueprint.Location.Index.Load() != 0 && !isCacheFull.Load() {
-			spr := globalSpriteCache.GetItem32(spriteBlueprint.Location.Index.Load())
+		if spr, ok := cachedItem(globalSpriteCache, &spriteBundle.Blueprints[i].Location); ok {
 			sprites = append(sprites, spr)
-
-			continue
-		}
-		if spriteBlueprint.Location.Key != "" {
-			if idx, ok := globalSpriteCache.GetIndex(spriteBlueprint.Location.Key); ok {
-				spriteBlueprint.Location.Index.Store(uint32(idx))
-				spr := globalSpriteCache.GetItem(idx)
-				sprites = append(sprites, spr)
-				continue
-			}
 		}
 	}
 	return sprites
@@ -40,17 +29,11 @@ func MaterializeSprite(spriteBundle *client.SpriteBundle, index int) (Sprite, er
 	cacheSwapMutex.RLock()
 	defer cacheSwapMutex.RUnlock()
 
-	spriteBlueprint := &spriteBundle.Blueprints[index]
-
-	if spriteBlueprint.Location.Index.Load() != 0 && !isCacheFull.Load() {
-		return globalSpriteCache.GetItem32(spriteBlueprint.Location.Index.Load()), nil
+	if index < 0 || index >= len(spriteBundle.Blueprints) {
+		return nil, fmt.Errorf("sprite index %d out of range for bundle of %d", index, len(spriteBundle.Blueprints))
 	}
-
-	if spriteBlueprint.Location.Key != "" {
-		if idx, ok := globalSpriteCache.GetIndex(spriteBlueprint.Location.Key); ok {
-			spriteBlueprint.Location.Index.Store(uint32(idx))
-			return globalSpriteCache.GetItem(idx), nil
-		}
+	if spr, ok := cachedItem(globalSpriteCache, &spriteBundle.Blueprints[index].Location); ok {
+		return spr, nil
 	}
 	return nil, errors.New("failed to materialize sprite")
 }
@@ -63,19 +46,8 @@ func MaterializeSounds(soundBundle *client.SoundBundle) []Sound {
 
 	var sounds []Sound
 	for i := range soundBundle.Blueprints {
-		soundBlueprint := &soundBundle.Blueprints[i]
-		if soundBlueprint.Location.Index.Load() != 0 && !isCacheFull.Load() {
-			snd := globalSoundCache.GetItem32(soundBlueprint.Location.Index.Load())
+		if snd, ok := cachedItem(globalSoundCache, &soundBundle.Blueprints[i].Location); ok {
 			sounds = append(sounds, snd)
-			continue
-		}
-		if soundBlueprint.Location.Key != "" {
-			if idx, ok := globalSoundCache.GetIndex(soundBlueprint.Location.Key); ok {
-				soundBlueprint.Location.Index.Store(uint32(idx))
-				snd := globalSoundCache.GetItem(idx)
-				sounds = append(sounds, snd)
-				continue
-			}
 		}
 	}
 	return sounds
@@ -88,19 +60,42 @@ func MaterializeSound(soundBundle *client.SoundBundle, sc client.SoundConfig) (S
 	defer cacheSwapMutex.RUnlock()
 
 	for i := range soundBundle.Blueprints {
-		soundBlueprint := &soundBundle.Blueprints[i]
-		location := soundBlueprint.Location
-
-		if location.Index.Load() != 0 && sc.Path == location.Key {
-			return globalSoundCache.GetItem32(location.Index.Load()), nil
+		location := &soundBundle.Blueprints[i].Location
+		if location.Key == "" || sc.Path != location.Key {
+			continue
 		}
-
-		if location.Key != "" && sc.Path == location.Key {
-			if idx, ok := globalSoundCache.GetIndex(location.Key); ok {
-				soundBlueprint.Location.Index.Store(uint32(idx))
-				return globalSoundCache.GetItem(idx), nil
-			}
+		if snd, ok := cachedItem(globalSoundCache, location); ok {
+			return snd, nil
 		}
 	}
 	return Sound{}, fmt.Errorf("sound at %v not found", sc)
 }
+
+// cachedItem returns the item at the location, re-resolving it by key when the stored index is unset
+// or stale. Evicting caches hand freed slots to other assets, so their indices are only trusted while
+// the slot still holds the location's key
+func cachedItem[T any](cache warehouse.Cache[T], location *warehouse.CacheLocation) (T, bool) {
+	rc, evicts := cache.(warehouse.RefCountedCache[T])
+	if index := location.Index.Load(); index != 0 && !isCacheFull.Load() {
+		if !evicts {
+			return cache.GetItem32(index), true
+		}
+		if item, ok := rc.GetItemIfKey(int(index), location.Key); ok {
+			return item, true
+		}
+	}
+	var zero T
+	if location.Key == "" {
+		return zero, false
+	}
+	index, ok := cache.GetIndex(location.Key)
+	if !ok {
+		return zero, false
+	}
+	location.Index.Store(uint32(index))
+	if !evicts {
+		return cache.GetItem(index), true
+	}
+	// The slot may have been reused since GetIndex
+	return rc.GetItemIfKey(index, location.Key)
+}
@@ -220,6 +220,52 @@ func TestSuccessfulCacheBust(t *testing.T) {
 	}
 }
 
+// TestCachedItemStaleIndex tests that an index whose slot was reused is re-resolved by key
+func TestCachedItemStaleIndex(t *testing.T) {
+	cache := warehouse.FactoryNewLRUCache[string](1)
+	cache.Register("a.png", "a")
+
+	location := &warehouse.CacheLocation{Key: "a.png"}
+	if item, ok := cachedItem[string](cache, location); !ok || item != "a" {
+		t.Fatalf("cachedItem = %q, %v, want a, true", item, ok)
+	}
+
+	// b takes a's slot, so the stored index now points at the wrong asset
+	cache.Register("b.png", "b")
+	if item, ok := cachedItem[string](cache, location); ok {
+		t.Errorf("cachedItem returned %q for an evicted key", item)
+	}
+
+	cache.Register("a.png", "a")
+	if item, ok := cachedItem[string](cache, &warehouse.CacheLocation{Key: "b.png"}); ok {
+		t.Errorf("cachedItem returned %q for an evicted key", item)
+	}
+	if item, ok := cachedItem[string](cache, location); !ok || item != "a" {
+		t.Errorf("cachedItem after reload = %q, %v, want a, true", item, ok)
+	}
+}
+
+// TestRetainingCachePinsOnLoad tests that assets are retained as soon as they are registered
+func TestRetainingCachePinsOnLoad(t *testing.T) {
+	cache := warehouse.FactoryNewLRUCache[string](2)
+	wrapped, retaining := retainingCacheFor[string](cache)
+
+	wrapped.Register("a.png", "a")
+	wrapped.Register("b.png", "b")
+	if _, err := wrapped.Register("c.png", "c"); err == nil {
+		t.Errorf("Expected registering past capacity to fail while the scene's assets are pinned")
+	}
+	if _, ok := wrapped.GetIndex("a.png"); !ok {
+		t.Fatalf("Pinned asset a was evicted")
+	}
+	if got := len(retaining.retained()); got != 3 {
+		t.Errorf("Retained %d keys, want 3 (two registers and one lookup)", got)
+	}
+	if cache.RefCount("a.png") != 2 {
+		t.Errorf("RefCount for a is %d, want 2", cache.RefCount("a.png"))
+	}
+}
+
 // Helper function to create a scene plan
 func createScenePlan(assets []string) blueprint.Plan {
 	return func(width, height int, storage warehouse.Storage) error {
@@ -231,23 +277,12 @@ func createScenePlan(assets []string) blueprint.Plan {
 			return err
 		}
 
-		spriteBundle := client.SpriteBundle{}
-		for i, assetName := range assets[:2] {
-			spriteBundle.Blueprints[i] = client.SpriteBlueprint{
-				Location: warehouse.CacheLocation{
-					Key: assetName,
-				},
-			}
-			spriteBundle.Blueprints[i].Config.Active = true
+		spriteBundle := client.NewSpriteBundle()
+		for _, assetName := range assets[:2] {
+			spriteBundle = spriteBundle.AddSprite(assetName, true)
 		}
 
-		soundBundle := client.SoundBundle{}
-		soundBundle.Blueprints[0] = client.SoundBlueprint{
-			Location: warehouse.CacheLocation{
-				Key: assets[2],
-			},
-			AudioPlayerCount: 1,
-		}
+		soundBundle := client.NewSoundBundle().AddSoundFromPath(assets[2])
 
 		return archetype.Generate(1, spriteBundle, soundBundle)
 	}
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
 	"github.com/TheBitDrifter/bark"
 	"github.com/TheBitDrifter/mask"
 	"github.com/hajimehoshi/ebiten/v2"
@@ -37,7 +38,8 @@ type stick struct {
 	Y float64
 }
 
-// stickState tracks analog stick positions for a gamepad
+// stickState tracks raw analog stick positions for a gamepad
+// Deadzones and response curves are applied per receiver, since each layout configures its own
 type stickState struct {
 	Left  stick
 	Right stick
@@ -212,14 +214,14 @@ func (h *gamepadCapturer) captureButtonState(id ebiten.GamepadID) {
 func (h *gamepadCapturer) captureAxesState(id ebiten.GamepadID) {
 	if ebiten.IsStandardGamepadLayoutAvailable(id) {
 		h.sticks[id] = stickState{
-			Left: processStickInput(
-				ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal),
-				ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical),
-			),
-			Right: processStickInput(
-				ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickHorizontal),
-				ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickVertical),
-			),
+			Left: stick{
+				X: ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal),
+				Y: ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical),
+			},
+			Right: stick{
+				X: ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickHorizontal),
+				Y: ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisRightStickVertical),
+			},
 		}
 		return
 	}
@@ -235,8 +237,8 @@ func (h *gamepadCapturer) captureAxesState(id ebiten.GamepadID) {
 
 	if len(axisValues) >= 4 {
 		h.sticks[id] = stickState{
-			Left:  processStickInput(axisValues[0], axisValues[1]),
-			Right: processStickInput(axisValues[2], axisValues[3]),
+			Left:  stick{X: axisValues[0], Y: axisValues[1]},
+			Right: stick{X: axisValues[2], Y: axisValues[3]},
 		}
 	} else {
 		h.logger.Debug("insufficient axes for stick mapping",
@@ -336,45 +338,48 @@ func (h *gamepadCapturer) processStickInputsForGamepad(receiverIndex int, receiv
 		return
 	}
 
-	// Process left stick if enabled
-	if (stickState.Left.X != 0 || stickState.Left.Y != 0) && h.client.receivers[receiverIndex].leftAxes {
-		h.client.receivers[receiverIndex].actions.pad = append(
-			h.client.receivers[receiverIndex].actions.pad,
-			input.StampedAction{
-				Tick: tick,
-				// Use stick X/Y values directly as the vector components
-				X:   int(stickState.Left.X * 100),  // Scale to a reasonable range if needed
-				Y:   int(-stickState.Left.Y * 100), // Invert Y and scale to a reasonable range
-				Val: h.client.receivers[receiverIndex].leftAxesInput,
-			},
-		)
-		h.logger.Debug("gamepad left stick processed",
-			"receiver", receiverIndex,
-			"gamepad_id", gamepadID,
-			"x", stickState.Left.X,
-			"y", -stickState.Left.Y, // Showing inverted Y value in logs
-			"val", h.client.receivers[receiverIndex].leftAxesInput,
-		)
+	sticks := []struct {
+		name    string
+		enabled bool
+		raw     stick
+		left    bool
+		action  input.Action
+	}{
+		{"left", receiver.leftAxes, stickState.Left, true, receiver.leftAxesInput},
+		{"right", receiver.rightAxes, stickState.Right, false, receiver.rightAxesInput},
 	}
 
-	// Process right stick if enabled
-	if (stickState.Right.X != 0 || stickState.Right.Y != 0) && h.client.receivers[receiverIndex].rightAxes {
+	for _, s := range sticks {
+		if !s.enabled {
+			continue
+		}
+		processed := processStickInput(s.raw.X, s.raw.Y, receiver.stickResponse(s.left))
+		if processed.X == 0 && processed.Y == 0 {
+			continue
+		}
+		// Invert Y so up is positive
+		vec := vector.Two{X: processed.X, Y: -processed.Y}
 		h.client.receivers[receiverIndex].actions.pad = append(
 			h.client.receivers[receiverIndex].actions.pad,
 			input.StampedAction{
 				Tick: tick,
-				// Use stick X/Y values directly as the vector components
-				X:   int(stickState.Right.X * 100),  // Scale to a reasonable range if needed
-				Y:   int(-stickState.Right.Y * 100), // Invert Y and scale to a reasonable range
-				Val: h.client.receivers[receiverIndex].rightAxesInput,
+				// Scaled integer stick values, kept for systems that predate the analog payload
+				X:   int(vec.X * 100),
+				Y:   int(vec.Y * 100),
+				Val: s.action,
+				Analog: input.Analog{
+					Value:  vec.Mag(),
+					Vector: vec,
+				},
 			},
 		)
-		h.logger.Debug("gamepad right stick processed",
+		h.logger.Debug("gamepad stick processed",
+			"stick", s.name,
 			"receiver", receiverIndex,
 			"gamepad_id", gamepadID,
-			"x", stickState.Right.X,
-			"y", -stickState.Right.Y, // Showing inverted Y value in logs
-			"val", h.client.receivers[receiverIndex].rightAxesInput,
+			"x", vec.X,
+			"y", vec.Y,
+			"val", s.action,
 		)
 	}
 }
@@ -402,23 +407,34 @@ func (h *gamepadCapturer) processStickInputs(receiverIndex int, receiver *receiv
 	h.processStickInputsForGamepad(receiverIndex, receiver, physicalID, x, y)
 }
 
-// processStickInput processes raw stick input with deadzone and normalization
-// Returns normalized stick position after deadzone processing
-func processStickInput(x, y float64) stick {
-	const deadzone = 0.25
+// processStickInput processes raw stick input with deadzones and the response curve
+// Returns the stick position with its direction preserved and its magnitude reshaped
+func processStickInput(x, y float64, response StickResponse) stick {
 	x = math.Max(math.Min(x, 1.0), -1.0)
 	y = math.Max(math.Min(y, 1.0), -1.0)
 
 	magnitude := math.Sqrt(x*x + y*y)
-	if magnitude < deadzone || magnitude == 0 {
+	if magnitude < response.Deadzone || magnitude == 0 {
 		return stick{X: 0, Y: 0}
 	}
 
 	normalizedX := x / magnitude
 	normalizedY := y / magnitude
 
-	adjustedMagnitude := (magnitude - deadzone) / (1 - deadzone)
+	outer := 1 - response.OuterDeadzone
+	if outer <= response.Deadzone {
+		outer = 1
+	}
+	adjustedMagnitude := (magnitude - response.Deadzone) / (outer - response.Deadzone)
 	adjustedMagnitude = math.Max(0, math.Min(1, adjustedMagnitude))
+
+	if response.Curve == CurveExponential {
+		exponent := response.Exponent
+		if exponent <= 0 {
+			exponent = 2
+		}
+		adjustedMagnitude = math.Pow(adjustedMagnitude, exponent)
+	}
 	return stick{
 		X: normalizedX * adjustedMagnitude,
 		Y: normalizedY * adjustedMagnitude,
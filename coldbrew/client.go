@@ -76,6 +76,7 @@ func newClientImplBase(baseResX, baseResY, maxSpritesCached, maxSoundsCached, ma
 
 	ClientConfig.maxSoundsCached.Store(uint32(maxSoundsCached))
 	ClientConfig.maxSpritesCached.Store(uint32(maxSpritesCached))
+	resetGlobalCaches()
 
 	// Store base resolution
 	ClientConfig.baseResolution.x = baseResX
@@ -194,7 +195,7 @@ func (cli *clientImpl) findAndLoadMissingAssetsForActiveScenesAsync() {
 	for scene := range cli.ActiveScenes() {
 		if !scene.IsLoaded() && !scene.IsLoading() {
 			if scene.TryStartLoading() {
-				go func(s Scene) {
+				load := func(s Scene) {
 					// Get read lock before accessing global caches
 					cacheSwapMutex.RLock()
 					defer cacheSwapMutex.RUnlock()
@@ -203,13 +204,25 @@ func (cli *clientImpl) findAndLoadMissingAssetsForActiveScenesAsync() {
 					if err != nil {
 						isCacheFull.Store(true)
 					}
-				}(scene)
+				}
+				// Replays load in step so scene systems start on the same tick every run
+				if cli.replay != nil {
+					load(scene)
+					continue
+				}
+				go load(scene)
 			}
 		}
 	}
 }
 
 func (cli *clientImpl) loadAssetsForScene(scene Scene, spriteCache warehouse.Cache[Sprite], soundCache warehouse.Cache[Sound]) error {
+	// Pin each asset as it loads so the rest of the load cannot evict it, replacing any previous pins
+	releaseSceneAssets(scene)
+	spriteCache, retainedSprites := retainingCacheFor(spriteCache)
+	soundCache, retainedSounds := retainingCacheFor(soundCache)
+	defer storeSceneAssets(scene, retainedSprites, retainedSounds)
+
 	sto := scene.Storage()
 	cursor := warehouse.Factory.NewCursor(blueprint.Queries.SpriteBundle, sto)
 	for range cursor.Next() {
@@ -247,8 +260,8 @@ func (cli *clientImpl) loadAssetsForScene(scene Scene, spriteCache warehouse.Cac
 
 func (cli *clientImpl) resolveCacheForActiveScenes() {
 	if isResolvingCache.CompareAndSwap(false, true) {
-		swapCacheSpr := warehouse.FactoryNewCache[Sprite](int(ClientConfig.maxSpritesCached.Load()))
-		swapCacheSnd := warehouse.FactoryNewCache[Sound](int(ClientConfig.maxSoundsCached.Load()))
+		swapCacheSpr := warehouse.FactoryNewLRUCache[Sprite](int(ClientConfig.maxSpritesCached.Load()))
+		swapCacheSnd := warehouse.FactoryNewLRUCache[Sound](int(ClientConfig.maxSoundsCached.Load()))
 
 		var wg sync.WaitGroup
 		done := make(chan struct{})
@@ -314,6 +327,14 @@ func (cli *clientImpl) onCacheResolveComplete(spriteCache warehouse.Cache[Sprite
 }
 
 func (cli *clientImpl) captureInputs() {
+	if cli.replay != nil {
+		cli.replay.inject(cli.receivers[:])
+		return
+	}
+	if cli.listener != nil {
+		cli.captureNextInput()
+		return
+	}
 	cli.capturers.keyboard.Capture()
 	cli.capturers.mouse.Capture()
 	cli.capturers.gamepad.Capture()
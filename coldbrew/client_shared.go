@@ -30,6 +30,7 @@ func sharedClientUpdate(cli Client, interpolateCoreSystemsForNetworked bool) err
 		if err != nil {
 			return err
 		}
+		advanceSceneEvents(clientAsStandard)
 
 		tick++
 	}
@@ -53,11 +54,19 @@ func sharedClientUpdate(cli Client, interpolateCoreSystemsForNetworked bool) err
 		if err != nil {
 			return err
 		}
+		advanceSceneEvents(clientAsNetworked)
 	}
 
 	return nil
 }
 
+// advanceSceneEvents swaps the event buffers of the active scenes once their systems have run
+func advanceSceneEvents(cli Client) {
+	for activeScene := range cli.ActiveScenes() {
+		activeScene.Storage().Events().Update()
+	}
+}
+
 func sharedDraw(cliFace Client, image *ebiten.Image) {
 	cli, _ := cliFace.(*clientImpl)
 	cliAsNet, isNet := cliFace.(*networkClientImpl)
@@ -11,6 +11,10 @@ import (
 type InputManager interface {
 	ActivateReceiver() (Receiver, error)
 	Receiver(index int) Receiver
+	RecordInputs(*InputRecorder)
+	ReplayInputs(*InputReplay)
+	ListenForNextInput(func(CapturedInput))
+	CancelListen()
 }
 
 // inputManager implements the InputManager interface with support for
@@ -18,6 +22,9 @@ type InputManager interface {
 type inputManager struct {
 	receivers [MaxSplit]*receiver
 	*capturers
+	recorder *InputRecorder
+	replay   *InputReplay
+	listener func(CapturedInput)
 }
 
 // capturers holds input capture mechanisms for different device types.
@@ -39,7 +46,7 @@ func newInputManager(cli *clientImpl) *inputManager {
 		},
 	}
 	for i := range m.receivers {
-		m.receivers[i] = &receiver{}
+		m.receivers[i] = &receiver{index: i}
 	}
 	return m
 }
@@ -169,6 +169,7 @@ func (m *sceneManager) DeactivateScene(target Scene) {
 				}
 			}
 
+			releaseSceneAssets(target)
 			target.SetLoaded(false)
 			break
 		}
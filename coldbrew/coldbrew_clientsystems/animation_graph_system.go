@@ -0,0 +1,40 @@
+package coldbrew_clientsystems
+
+import (
+	"fmt"
+
+	"github.com/TheBitDrifter/bappa/blueprint/client"
+	"github.com/TheBitDrifter/bappa/coldbrew"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// AnimationGraphSystem drives sprite animations from AnimationMachine components
+// Register it after the systems that update the fields and actions the graphs read
+type AnimationGraphSystem struct{}
+
+func (AnimationGraphSystem) Run(cli coldbrew.LocalClient, scene coldbrew.Scene) error {
+	query := warehouse.Factory.NewQuery().And(client.Components.SpriteBundle, client.Components.AnimationMachine)
+	cursor := scene.NewCursor(query)
+	currentTick := scene.CurrentTick()
+
+	for range cursor.Next() {
+		bundle := client.Components.SpriteBundle.GetFromCursor(cursor)
+		machine := client.Components.AnimationMachine.GetFromCursor(cursor)
+
+		graph, ok := client.AnimationGraphRegistry[machine.Graph]
+		if !ok {
+			return fmt.Errorf("unknown animation graph %q", machine.Graph)
+		}
+		if machine.BlueprintIndex < 0 || machine.BlueprintIndex >= len(bundle.Blueprints) {
+			return fmt.Errorf("animation graph %s: invalid blueprint index %d", machine.Graph, machine.BlueprintIndex)
+		}
+		entity, err := cursor.CurrentEntity()
+		if err != nil {
+			return err
+		}
+		if err := graph.Drive(machine, &bundle.Blueprints[machine.BlueprintIndex], entity, currentTick); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package coldbrew_clientsystems
+
+import (
+	"github.com/TheBitDrifter/bappa/blueprint/client"
+	"github.com/TheBitDrifter/bappa/coldbrew"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// AnimationSoundSystem plays the sounds tagged on animation frames
+// It reads the events of blueprint.AnimationEventSystem, so register it as a client system that runs after core systems
+type AnimationSoundSystem struct {
+	Volume  float64
+	readers map[warehouse.Storage]*warehouse.EventReader[client.AnimationEventFired]
+}
+
+func (sys *AnimationSoundSystem) Run(cli coldbrew.LocalClient, scene coldbrew.Scene) error {
+	if sys.readers == nil {
+		sys.readers = map[warehouse.Storage]*warehouse.EventReader[client.AnimationEventFired]{}
+	}
+	reader, ok := sys.readers[scene.Storage()]
+	if !ok {
+		reader = &warehouse.EventReader[client.AnimationEventFired]{}
+		sys.readers[scene.Storage()] = reader
+	}
+
+	for _, event := range warehouse.Read(scene.Storage(), reader) {
+		if event.Sound == "" || !event.Entity.Valid() || !event.Entity.Table().Contains(client.Components.SoundBundle) {
+			continue
+		}
+		soundBundle := client.Components.SoundBundle.GetFromEntity(event.Entity)
+		sound, err := coldbrew.MaterializeSound(soundBundle, client.SoundConfig{Path: event.Sound})
+		if err != nil {
+			continue // Not loaded yet
+		}
+		player := sound.GetAny()
+		player.SetVolume(sys.Volume)
+		player.Rewind()
+		player.Play()
+	}
+	return nil
+}
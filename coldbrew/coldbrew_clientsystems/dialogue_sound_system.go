@@ -24,32 +24,21 @@ func (sys DialogueSoundSystem) Run(cli coldbrew.LocalClient, scene coldbrew.Scen
 
 	for range cursor.Next() {
 		convo := dialogue.Components.Conversation.GetFromCursor(cursor)
-		slides := dialogue.SlidesRegistry[convo.SlidesID]
+		slide, ok := convo.ActiveSlide()
+		if !ok {
+			continue
+		}
 		tpc := sys.TEXT_REVEAL_DELAY_IN_TICKS
-		if slides[convo.ActiveSlideIndex].CustomSpeedTicks != 0 {
-			tpc = slides[convo.ActiveSlideIndex].CustomSpeedTicks
+		if slide.CustomSpeedTicks != 0 {
+			tpc = slide.CustomSpeedTicks
 		}
 
-		var play bool
+		// Sound once per newly revealed glyph (or word), following the markup's pauses and speeds
+		reveal := text.ShouldPlayRevealSound
 		if sys.SoundOnWord {
-			play = text.ShouldPlayRevealSoundForWord(convo.AnimationState.RevealStartTick, currentTick, tpc, convo.DisplayedText)
-		} else {
-			play = text.ShouldPlayRevealSound(convo.AnimationState.RevealStartTick, currentTick, tpc, convo.DisplayedText)
+			reveal = text.ShouldPlayRevealSoundForWord
 		}
-
-		if play {
-
-			revealedText := convo.AnimationState.DisplayedText
-			if len(revealedText) == 0 {
-				continue
-			}
-			runes := []rune(revealedText)
-			lastChar := runes[len(runes)-1]
-
-			if lastChar == ' ' {
-				continue
-			}
-
+		if reveal(convo.AnimationState.RevealStartTick, currentTick, tpc, convo.AnimationState.WrappedText) {
 			soundBundle := client.Components.SoundBundle.GetFromCursor(cursor)
 			sounds := coldbrew.MaterializeSounds(soundBundle)
 			if len(sounds) > 0 {
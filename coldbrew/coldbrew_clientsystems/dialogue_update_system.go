@@ -4,6 +4,8 @@ import (
 	"errors"
 
 	"github.com/TheBitDrifter/bappa/blueprint/dialogue"
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/blueprint/locale"
 	bptext "github.com/TheBitDrifter/bappa/coldbrew/text"
 
 	"github.com/TheBitDrifter/bappa/coldbrew"
@@ -16,6 +18,14 @@ type DialogueTextSystem struct {
 	FONT_SIZE                  int
 	FONT_FACE                  *text.GoTextFace
 	MAX_LINE_WIDTH             int
+
+	// Optional names of input actions, consumed from the scene's action buffers
+	// ADVANCE_ACTION reveals the whole line, then advances (picking the highlighted choice)
+	// NEXT_CHOICE_ACTION and PREV_CHOICE_ACTION move the highlight
+	// Choices with their own action are picked by it directly
+	ADVANCE_ACTION     string
+	NEXT_CHOICE_ACTION string
+	PREV_CHOICE_ACTION string
 }
 
 func (sys DialogueTextSystem) validate() error {
@@ -36,49 +46,142 @@ func (sys DialogueTextSystem) Run(cli coldbrew.LocalClient, scene coldbrew.Scene
 	if err != nil {
 		return err
 	}
+	buffers := sys.actionBuffers(scene)
 	query := warehouse.Factory.NewQuery().And(dialogue.Components.Conversation)
 	cursor := scene.NewCursor(query)
 	currentTick := scene.CurrentTick()
 
 	for range cursor.Next() {
 		convo := dialogue.Components.Conversation.GetFromCursor(cursor)
+		slide, ok := convo.ActiveSlide()
+		if !ok {
+			continue
+		}
+		if convo.AnimationState.LocaleVersion != locale.Version() {
+			// Rewrap for the new locale, the reveal continues where it was
+			if _, err := StartConversation(scene, convo, sys.FONT_FACE, float64(sys.MAX_LINE_WIDTH)); err != nil {
+				return err
+			}
+		}
 
 		if convo.AnimationState.RevealStartTick == 0 {
 			convo.AnimationState.RevealStartTick = currentTick
 		}
 
 		tpc := sys.TEXT_REVEAL_DELAY_IN_TICKS
-		slides := dialogue.SlidesRegistry[convo.SlidesID]
-
-		if slides[convo.ActiveSlideIndex].CustomSpeedTicks != 0 {
-			tpc = slides[convo.ActiveSlideIndex].CustomSpeedTicks
+		if slide.CustomSpeedTicks != 0 {
+			tpc = slide.CustomSpeedTicks
 		}
-		finished, count := bptext.CurrentIndexInTextReveal(
-			convo.AnimationState.RevealStartTick,
-			currentTick,
-			tpc,
-			convo.AnimationState.WrappedText,
-		)
+		rt := bptext.Parse(convo.AnimationState.WrappedText)
+		finished, count := rt.Reveal(convo.AnimationState.RevealStartTick, currentTick, tpc)
 
-		fullRunes := []rune(convo.AnimationState.WrappedText)
-		convo.AnimationState.DisplayedText = string(fullRunes[:count])
+		if !finished && consumeAction(buffers, sys.ADVANCE_ACTION) {
+			// Skip the reveal, the next tick picks up from the end of the line
+			convo.AnimationState.RevealStartTick = currentTick - rt.Duration(tpc)
+			finished, count = true, rt.Glyphs
+		}
+		convo.AnimationState.RevealedGlyphs = count
+		convo.AnimationState.DisplayedText = rt.Plain(count)
 
 		if finished && convo.AnimationState.FinalUpdateTick == 0 {
 			convo.AnimationState.FinalUpdateTick = currentTick
+			continue
+		}
+		if !finished {
+			continue
+		}
+
+		advanced, err := sys.handleChoices(scene, convo, buffers)
+		if err != nil {
+			return err
+		}
+		if !advanced {
+			continue
+		}
+		started, err := StartConversation(scene, convo, sys.FONT_FACE, float64(sys.MAX_LINE_WIDTH))
+		if err != nil {
+			return err
+		}
+		if started {
+			convo.AnimationState.RevealStartTick = 0
 		}
 	}
 	return nil
 }
 
-func InitConversation(scene coldbrew.Scene, convo *dialogue.Conversation, fontFace *text.GoTextFace, maxWidth float64) bool {
-	slides := dialogue.SlidesRegistry[convo.SlidesID]
-	if convo.ActiveSlideIndex >= len(slides) {
+// handleChoices consumes the dialogue actions of a finished line, reporting whether the conversation moved
+func (sys DialogueTextSystem) handleChoices(scene coldbrew.Scene, convo *dialogue.Conversation, buffers []*input.ActionBuffer) (bool, error) {
+	for i, choice := range convo.Choices() {
+		if consumeAction(buffers, choice.Action) {
+			_, err := convo.Choose(scene, i)
+			return true, err
+		}
+	}
+	switch {
+	case consumeAction(buffers, sys.NEXT_CHOICE_ACTION):
+		convo.SelectChoice(1)
+	case consumeAction(buffers, sys.PREV_CHOICE_ACTION):
+		convo.SelectChoice(-1)
+	case consumeAction(buffers, sys.ADVANCE_ACTION):
+		_, err := convo.Advance(scene)
+		return true, err
+	}
+	return false, nil
+}
+
+func (sys DialogueTextSystem) actionBuffers(scene coldbrew.Scene) []*input.ActionBuffer {
+	var buffers []*input.ActionBuffer
+	cursor := scene.NewCursor(warehouse.Factory.NewQuery().And(input.Components.ActionBuffer))
+	for range cursor.Next() {
+		buffers = append(buffers, input.Components.ActionBuffer.GetFromCursor(cursor))
+	}
+	return buffers
+}
+
+// consumeAction removes the named action from the first buffer holding it
+func consumeAction(buffers []*input.ActionBuffer, name string) bool {
+	if name == "" {
 		return false
 	}
+	action, ok := input.ActionByName(name)
+	if !ok {
+		return false
+	}
+	for _, buffer := range buffers {
+		if _, ok := buffer.ConsumeAction(action); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// InitConversation prepares the active line of the conversation for revealing
+//
+// Deprecated: errors starting a graph conversation are dropped, use StartConversation
+func InitConversation(scene coldbrew.Scene, convo *dialogue.Conversation, fontFace *text.GoTextFace, maxWidth float64) bool {
+	ok, _ := StartConversation(scene, convo, fontFace, maxWidth)
+	return ok
+}
+
+// StartConversation prepares the active line of the conversation for revealing, entering a graph
+// conversation first if needed
+// It reports false when there is no line to show
+func StartConversation(scene coldbrew.Scene, convo *dialogue.Conversation, fontFace *text.GoTextFace, maxWidth float64) (bool, error) {
+	if convo.Graph != "" && convo.NodeID == "" {
+		if err := convo.Start(scene); err != nil {
+			return false, err
+		}
+	}
+	slide, ok := convo.ActiveSlide()
+	if !ok {
+		return false, nil
+	}
 	convo.AnimationState.IsRevealing = true
-	convo.WrappedText = bptext.WrapText(slides[convo.ActiveSlideIndex].Text, fontFace, float64(maxWidth))
+	convo.WrappedText = bptext.WrapRichText(slide.Text, fontFace, float64(maxWidth))
 	convo.AnimationState.FinalUpdateTick = 0
+	convo.AnimationState.LocaleVersion = locale.Version()
 	convo.DisplayedText = ""
+	convo.RevealedGlyphs = 0
 
-	return true
+	return true, nil
 }
@@ -38,6 +38,11 @@ func (InputBufferSystem) Run(cli coldbrew.Client) error {
 					}
 				}
 			}
+			// Combos see the ordered actions before deduplication and feed their synthetic actions back in
+			if input.Components.ComboBuffer.CheckCursor(actionBufferCursor) {
+				combos := input.Components.ComboBuffer.GetFromCursor(actionBufferCursor)
+				poppedActions = append(poppedActions, combos.Feed(poppedActions)...)
+			}
 			buffer.AddBatch(poppedActions)
 		}
 	}
@@ -0,0 +1,28 @@
+package coldbrew_clientsystems
+
+import (
+	"github.com/TheBitDrifter/bappa/coldbrew"
+	"github.com/TheBitDrifter/bappa/warehouse"
+	"github.com/TheBitDrifter/bappa/warehouse/inspector"
+)
+
+// InspectorSystem exposes the active scene storages through a warehouse inspector
+// and services its pending requests on the update loop
+type InspectorSystem struct {
+	Inspector *inspector.Server
+}
+
+// Run exposes the active scenes and processes queued inspector requests
+func (sys InspectorSystem) Run(cli coldbrew.Client) error {
+	if sys.Inspector == nil {
+		return nil
+	}
+	storages := make(map[string]warehouse.Storage)
+	for scene := range cli.ActiveScenes() {
+		storages[scene.Name()] = scene.Storage()
+	}
+	// Scenes deactivated or unloaded since the last tick are dropped
+	sys.Inspector.RegisterActive(storages)
+	sys.Inspector.Process()
+	return nil
+}
@@ -2,13 +2,16 @@ package coldbrew_rendersystems
 
 import (
 	"log"
+	"strings"
 
 	"github.com/TheBitDrifter/bappa/blueprint/client"
 	"github.com/TheBitDrifter/bappa/blueprint/dialogue"
 	"github.com/TheBitDrifter/bappa/blueprint/vector"
 	"github.com/TheBitDrifter/bappa/coldbrew"
+	bptext "github.com/TheBitDrifter/bappa/coldbrew/text"
 	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
 	"github.com/TheBitDrifter/bappa/warehouse"
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
@@ -26,6 +29,9 @@ type DefaultDialogueRenderSystem struct {
 	NEXT_INDICATOR_PADDING_Y float64
 	NEXT_MIN_DELAY           float64
 	NEXT_LAST_SHOWN          float64
+	CHOICE_PADDING_X         int                      // Choice offset from the text
+	CHOICE_PADDING_Y         int                      // Choice offset below the last line of text
+	ICONS                    map[string]*ebiten.Image // Images for [icon=name] markup
 }
 
 func (sys DefaultDialogueRenderSystem) validate() {
@@ -49,11 +55,12 @@ func (sys DefaultDialogueRenderSystem) Render(scene coldbrew.Scene, screen coldb
 	for _, c := range cameras {
 		for range cursor.Next() {
 			convo := dialogue.Components.Conversation.GetFromCursor(cursor)
-			slides := dialogue.SlidesRegistry[convo.SlidesID]
-			if convo.ActiveSlideIndex >= len(slides) {
+			activeSlide, ok := convo.ActiveSlide()
+			if !ok {
 				return
 			}
-			activeSlide := slides[convo.ActiveSlideIndex]
+			revealed := convo.AnimationState.FinalUpdateTick != 0
+			choices := convo.Choices()
 
 			bundle := client.Components.SpriteBundle.GetFromCursor(cursor)
 
@@ -103,7 +110,7 @@ func (sys DefaultDialogueRenderSystem) Render(scene coldbrew.Scene, screen coldb
 
 			}
 
-			if len(convo.AnimationState.DisplayedText) >= len(activeSlide.Text) && scene.CurrentTick()-convo.AnimationState.FinalUpdateTick > int(sys.NEXT_MIN_DELAY) {
+			if revealed && len(choices) == 0 && scene.CurrentTick()-convo.AnimationState.FinalUpdateTick > int(sys.NEXT_MIN_DELAY) {
 				nextSheet, err := coldbrew.MaterializeSprite(bundle, 1)
 				if err != nil {
 					panic("missing next sprite")
@@ -128,22 +135,42 @@ func (sys DefaultDialogueRenderSystem) Render(scene coldbrew.Scene, screen coldb
 				)
 			}
 
-			if convo.AnimationState.DisplayedText != "" {
-				textOpts := &text.DrawOptions{}
-
-				textOpts.LineSpacing = float64(sys.FONT_FACE.Size + 2)
-
+			if convo.AnimationState.RevealedGlyphs > 0 {
 				texPos := vector.Two{X: pos.X + float64(sys.PADDING_X), Y: pos.Y + float64(sys.PADDING_Y)}
-				c.DrawTextStatic(
-					convo.AnimationState.DisplayedText,
-					textOpts,
+				RenderRichText(
+					bptext.Parse(convo.AnimationState.WrappedText),
+					convo.AnimationState.RevealedGlyphs,
 					sys.FONT_FACE,
+					float64(sys.FONT_FACE.Size+2),
+					sys.ICONS,
 					texPos,
+					c,
+					scene.CurrentTick(),
 				)
 			}
 
-			if slides[convo.ActiveSlideIndex].OwnerName != "" {
-				nameText := slides[convo.ActiveSlideIndex].OwnerName
+			if revealed && len(choices) > 0 {
+				textOpts := &text.DrawOptions{}
+				lineHeight := float64(sys.FONT_FACE.Size + 2)
+				textOpts.LineSpacing = lineHeight
+
+				lines := strings.Count(convo.AnimationState.WrappedText, "\n") + 1
+				choicePos := vector.Two{
+					X: pos.X + float64(sys.PADDING_X+sys.CHOICE_PADDING_X),
+					Y: pos.Y + float64(sys.PADDING_Y+sys.CHOICE_PADDING_Y) + float64(lines)*lineHeight,
+				}
+				for i, choice := range choices {
+					marker := "  "
+					if i == convo.ChoiceIndex {
+						marker = "> "
+					}
+					c.DrawTextStatic(marker+choice.Text, textOpts, sys.FONT_FACE, choicePos)
+					choicePos.Y += lineHeight
+				}
+			}
+
+			if activeSlide.OwnerName != "" {
+				nameText := activeSlide.OwnerName
 
 				textOpts := &text.DrawOptions{}
 				textOpts.LineSpacing = float64(sys.PORTRAIT_FONT_FACE.Size + 2)
@@ -16,8 +16,15 @@ import (
 	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
 )
 
+// CullPadding is added around the camera viewport when culling entities by their shape bounds
+// Sprites drawn further than this outside their shape may pop in at the screen edges
+var CullPadding = 128.0
+
 // GlobalRenderer is the default render system for the coldbrew package
 // It automatically handles sprites, sprite sheets, tiles, and parallax backgrounds
+//
+// When the scene storage has the shared spatial index (see tteo_coresystems.SpatialIndexSystem),
+// entities it tracks are skipped unless their shape is near the camera viewport
 type GlobalRenderer struct {
 	logger *slog.Logger
 	sorted [][]RenderItem
@@ -79,11 +86,17 @@ func (sys GlobalRenderer) Render(cli coldbrew.Client, screen coldbrew.Screen) {
 			}
 		}
 
+		index, visible := visibleEntities(scene, cam)
 		cursor = scene.NewCursor(blueprint.Queries.SpriteBundle)
 		for range cursor.Next() {
 			if client.Components.ParallaxBackground.CheckCursor(cursor) {
 				continue
 			}
+			if index != nil {
+				if en, err := cursor.CurrentEntity(); err == nil && index.Tracks(en) && !visible[int(en.ID())] {
+					continue
+				}
+			}
 
 			sprBundle := client.Components.SpriteBundle.GetFromCursor(cursor)
 			sprites := coldbrew.MaterializeSprites(sprBundle)
@@ -143,6 +156,28 @@ func (sys GlobalRenderer) Render(cli coldbrew.Client, screen coldbrew.Screen) {
 	}
 }
 
+// visibleEntities returns the scene's spatial index and the IDs of the entities it holds near the
+// camera viewport, or a nil index when there is nothing to cull with
+func visibleEntities(scene coldbrew.Scene, cam coldbrew.Camera) (warehouse.SpatialIndex, map[int]bool) {
+	index, ok := spatial.LookupIndex(scene.Storage())
+	if !ok || index.Len() == 0 {
+		return nil, nil
+	}
+	width, height := cam.Dimensions()
+	_, cameraPos := cam.Positions()
+	view := warehouse.AABB{
+		MinX: cameraPos.X - CullPadding,
+		MinY: cameraPos.Y - CullPadding,
+		MaxX: cameraPos.X + float64(width) + CullPadding,
+		MaxY: cameraPos.Y + float64(height) + CullPadding,
+	}
+	visible := make(map[int]bool)
+	for _, en := range index.QueryRegion(view, nil) {
+		visible[int(en.ID())] = true
+	}
+	return index, visible
+}
+
 // RenderTiles draws tiles from a tileset
 func RenderTiles(
 	sprite coldbrew.Sprite,
@@ -400,21 +435,22 @@ func RenderSpriteSheetAnimation(
 	logger *slog.Logger,
 	cm *colorm.ColorM,
 ) {
-	anim := &spriteBlueprint.Animations[index]
-	durationInTicks := anim.FrameCount * anim.Speed
-	if anim.StartTick == 0 {
-		anim.StartTick = tick
+	anim := spriteBlueprint.Animations.Get(index)
+	config := &spriteBlueprint.Config
+	durationInTicks := anim.Duration()
+	if config.StartTick == 0 {
+		config.StartTick = tick
 	}
-	animFinished := tick-durationInTicks >= anim.StartTick
+	animFinished := tick-durationInTicks >= config.StartTick
 
 	if animFinished && !anim.Freeze {
-		anim.StartTick = tick
+		config.StartTick = tick
 	}
 	var frameIndex int
 	if animFinished && anim.Freeze {
-		frameIndex = anim.FrameCount - 1
+		frameIndex = anim.Length() - 1
 	} else {
-		frameIndex = ((tick - anim.StartTick) / anim.Speed) % anim.FrameCount
+		frameIndex = anim.FrameAt(tick - config.StartTick)
 	}
 	if scale.X == 0 {
 		scale.X = 1
@@ -422,10 +458,12 @@ func RenderSpriteSheetAnimation(
 	if scale.Y == 0 {
 		scale.Y = 1
 	}
-	frame := GetAnimationFrame(sheet, *anim, frameIndex, logger)
+	frame := GetAnimationFrame(sheet, anim, frameIndex, logger)
 	opts := &ebiten.DrawImageOptions{}
 	opts.GeoM.Translate(offset.X, offset.Y)
 	opts.GeoM.Translate(anim.PositionOffset.X, anim.PositionOffset.Y)
+	frameOffset := anim.FrameOffset(frameIndex)
+	opts.GeoM.Translate(frameOffset.X, frameOffset.Y)
 
 	if direction.IsLeft() {
 		opts.GeoM.Scale(-1, 1)
@@ -455,5 +493,5 @@ func RenderSpriteSheetAnimation(
 
 // GetAnimationFrame extracts a single frame from a sprite sheet based on animation data
 func GetAnimationFrame(sheet coldbrew.Sprite, anim client.AnimationData, frameIndex int, logger *slog.Logger) *ebiten.Image {
-	return sheet.GetFrame(anim.RowIndex, frameIndex, anim.FrameWidth, anim.FrameHeight)
+	return sheet.GetFrameRect(anim.FrameRect(frameIndex))
 }
@@ -0,0 +1,111 @@
+package coldbrew_rendersystems
+
+import (
+	"math"
+	"strings"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/coldbrew"
+	bptext "github.com/TheBitDrifter/bappa/coldbrew/text"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	richTextWaveAmplitude = 2.0
+	richTextWaveSpeed     = 0.15
+	richTextWaveSpread    = 0.6
+	richTextShakeDistance = 1.0
+)
+
+// RenderRichText draws the first count glyphs of rich text without camera transformation
+//
+// Plain spans are drawn a line at a time, spans with effects a glyph at a time. Icons are looked up
+// by name and scaled to fit a square of the face size (missing icons leave a gap)
+func RenderRichText(
+	rt *bptext.RichText,
+	count int,
+	face *text.GoTextFace,
+	lineSpacing float64,
+	icons map[string]*ebiten.Image,
+	pos vector.Two,
+	cam coldbrew.Camera,
+	currentTick int,
+) {
+	x, y := pos.X, pos.Y
+	glyphIndex := 0
+
+	draw := func(content string, style bptext.Style, offset vector.Two) {
+		opts := &text.DrawOptions{}
+		opts.LineSpacing = lineSpacing
+		if style.Color != nil {
+			opts.ColorScale.ScaleWithColor(style.Color)
+		}
+		cam.DrawTextStatic(content, opts, face, vector.Two{X: x + offset.X, Y: y + offset.Y})
+	}
+
+	for _, span := range rt.Spans {
+		if count <= 0 {
+			return
+		}
+		glyphs := span.Glyphs[:min(count, len(span.Glyphs))]
+		count -= len(glyphs)
+
+		if span.Icon != "" {
+			if icon, ok := icons[span.Icon]; ok {
+				bounds := icon.Bounds()
+				scale := face.Size / float64(max(bounds.Dx(), bounds.Dy()))
+				offset := richTextOffset(span.Style, glyphIndex, currentTick)
+				opts := &ebiten.DrawImageOptions{}
+				opts.GeoM.Scale(scale, scale)
+				if span.Style.Color != nil {
+					opts.ColorScale.ScaleWithColor(span.Style.Color)
+				}
+				cam.DrawImageStatic(icon, opts, vector.Two{X: x + offset.X, Y: y + offset.Y})
+			}
+			x += face.Size
+			glyphIndex++
+			continue
+		}
+
+		if !span.Style.Wave && !span.Style.Shake {
+			for i, line := range strings.Split(strings.Join(glyphs, ""), "\n") {
+				if i > 0 {
+					x, y = pos.X, y+lineSpacing
+				}
+				if line != "" {
+					draw(line, span.Style, vector.Two{})
+					x += text.Advance(line, face)
+				}
+			}
+			glyphIndex += len(glyphs)
+			continue
+		}
+
+		for _, glyph := range glyphs {
+			if glyph == "\n" {
+				x, y = pos.X, y+lineSpacing
+			} else {
+				draw(glyph, span.Style, richTextOffset(span.Style, glyphIndex, currentTick))
+				x += text.Advance(glyph, face)
+			}
+			glyphIndex++
+		}
+	}
+}
+
+// richTextOffset returns where the effects of a style move a glyph on the current tick
+func richTextOffset(style bptext.Style, glyphIndex, currentTick int) vector.Two {
+	var offset vector.Two
+	if style.Wave {
+		offset.Y += math.Sin(float64(currentTick)*richTextWaveSpeed+float64(glyphIndex)*richTextWaveSpread) * richTextWaveAmplitude
+	}
+	if style.Shake {
+		// Cheap hash of tick and glyph so neighbours jitter independently
+		h := uint32(currentTick/2)*2654435761 ^ uint32(glyphIndex)*40503
+		h ^= h >> 13
+		offset.X += (float64(h%3) - 1) * richTextShakeDistance
+		offset.Y += (float64((h>>8)%3) - 1) * richTextShakeDistance
+	}
+	return offset
+}
@@ -8,12 +8,15 @@ import (
 )
 
 var (
-	globalSoundCache   = warehouse.FactoryNewCache[Sound](int(ClientConfig.maxSoundsCached.Load()))
-	globalSpriteCache  = warehouse.FactoryNewCache[Sprite](int(ClientConfig.maxSpritesCached.Load()))
+	globalSoundCache   warehouse.Cache[Sound]  = warehouse.FactoryNewLRUCache[Sound](int(ClientConfig.maxSoundsCached.Load()))
+	globalSpriteCache  warehouse.Cache[Sprite] = warehouse.FactoryNewLRUCache[Sprite](int(ClientConfig.maxSpritesCached.Load()))
 	cacheSwapMutex     sync.RWMutex
 	isCacheFull        atomic.Bool
 	cannotResolveCache atomic.Bool
 	isResolvingCache   atomic.Bool
+
+	sceneAssetRefsMutex sync.Mutex
+	sceneAssetRefs      = map[Scene]assetRefs{}
 )
 
 type CacheBustError struct{}
@@ -21,3 +24,111 @@ type CacheBustError struct{}
 func (*CacheBustError) Error() string {
 	return "cache bust failed active scenes require more assets than available for settings"
 }
+
+// assetRefs records the cache keys a loaded scene retains so they can be released on deactivation
+// A key appears once per retain, so duplicates are released as often as they were retained
+type assetRefs struct {
+	spriteCache warehouse.Cache[Sprite]
+	soundCache  warehouse.Cache[Sound]
+	sprites     []string
+	sounds      []string
+}
+
+// resetGlobalCaches recreates the global asset caches with the configured capacities
+func resetGlobalCaches() {
+	cacheSwapMutex.Lock()
+	defer cacheSwapMutex.Unlock()
+
+	globalSpriteCache = warehouse.FactoryNewLRUCache[Sprite](int(ClientConfig.maxSpritesCached.Load()))
+	globalSoundCache = warehouse.FactoryNewLRUCache[Sound](int(ClientConfig.maxSoundsCached.Load()))
+
+	sceneAssetRefsMutex.Lock()
+	defer sceneAssetRefsMutex.Unlock()
+	sceneAssetRefs = map[Scene]assetRefs{}
+}
+
+// retainingCache wraps a refcounted cache so every asset a scene looks up or registers is retained
+// on the spot, before loading the rest of the scene can evict it
+type retainingCache[T any] struct {
+	warehouse.RefCountedCache[T]
+	mu   sync.Mutex
+	keys []string
+}
+
+// GetIndex retains the key when it is cached
+func (c *retainingCache[T]) GetIndex(key string) (int, bool) {
+	index, ok := c.Retain(key)
+	if ok {
+		c.record(key)
+	}
+	return index, ok
+}
+
+// Register caches and retains the item in one step, so scenes loading in parallel cannot evict it first
+func (c *retainingCache[T]) Register(key string, item T) (int, error) {
+	index, err := c.RegisterAndRetain(key, item)
+	if err != nil {
+		return -1, err
+	}
+	c.record(key)
+	return index, nil
+}
+
+func (c *retainingCache[T]) record(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keys = append(c.keys, key)
+}
+
+func (c *retainingCache[T]) retained() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.keys
+}
+
+// retainingCacheFor wraps the cache in a retainingCache when it counts references
+func retainingCacheFor[T any](cache warehouse.Cache[T]) (warehouse.Cache[T], *retainingCache[T]) {
+	rc, ok := cache.(warehouse.RefCountedCache[T])
+	if !ok {
+		return cache, nil
+	}
+	retaining := &retainingCache[T]{RefCountedCache: rc}
+	return retaining, retaining
+}
+
+// storeSceneAssets records the keys retained while loading the scene so deactivation releases them
+func storeSceneAssets(scene Scene, spriteCache *retainingCache[Sprite], soundCache *retainingCache[Sound]) {
+	var refs assetRefs
+	if spriteCache != nil {
+		refs.spriteCache, refs.sprites = spriteCache.RefCountedCache, spriteCache.retained()
+	}
+	if soundCache != nil {
+		refs.soundCache, refs.sounds = soundCache.RefCountedCache, soundCache.retained()
+	}
+
+	sceneAssetRefsMutex.Lock()
+	defer sceneAssetRefsMutex.Unlock()
+	sceneAssetRefs[scene] = refs
+}
+
+// releaseSceneAssets releases the assets retained for the scene, making them evictable
+func releaseSceneAssets(scene Scene) {
+	sceneAssetRefsMutex.Lock()
+	refs, ok := sceneAssetRefs[scene]
+	delete(sceneAssetRefs, scene)
+	sceneAssetRefsMutex.Unlock()
+
+	if !ok {
+		return
+	}
+	if rc, ok := refs.spriteCache.(warehouse.RefCountedCache[Sprite]); ok {
+		for _, key := range refs.sprites {
+			rc.Release(key)
+		}
+	}
+	if rc, ok := refs.soundCache.(warehouse.RefCountedCache[Sound]); ok {
+		for _, key := range refs.sounds {
+			rc.Release(key)
+		}
+	}
+}
@@ -12,6 +12,7 @@ require (
 	github.com/TheBitDrifter/bappa/warehouse v0.0.0-20250827171242-3f6179875b16
 	github.com/TheBitDrifter/bark v0.0.0-20250302175939-26104a815ed9
 	github.com/TheBitDrifter/mask v0.0.1-early-alpha.1
+	github.com/go-text/typesetting v0.2.0
 	github.com/hajimehoshi/ebiten/v2 v2.8.8
 	golang.org/x/image v0.30.0
 )
@@ -22,7 +23,6 @@ require (
 	github.com/ebitengine/hideconsole v1.0.0 // indirect
 	github.com/ebitengine/oto/v3 v3.3.3 // indirect
 	github.com/ebitengine/purego v0.8.0 // indirect
-	github.com/go-text/typesetting v0.2.0 // indirect
 	github.com/jezek/xgb v1.1.1 // indirect
 	golang.org/x/sync v0.16.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
@@ -0,0 +1,274 @@
+package coldbrew
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// InputProfile is a serializable set of bindings, keyed by action name
+// Action names resolve through input.ActionByName
+type InputProfile struct {
+	Name     string                   `json:"name"`
+	Bindings map[string]ActionBinding `json:"bindings"`
+}
+
+// ActionBinding lists every input bound to a single action
+// Keys serialize by name ("Space", "ArrowLeft"), buttons by their ebiten number
+type ActionBinding struct {
+	Keys            []ebiten.Key `json:"keys,omitempty"`
+	JustPressedKeys []ebiten.Key `json:"just_pressed_keys,omitempty"`
+	ReleasedKeys    []ebiten.Key `json:"released_keys,omitempty"`
+
+	GamepadButtons            []ebiten.GamepadButton `json:"gamepad_buttons,omitempty"`
+	GamepadJustPressedButtons []ebiten.GamepadButton `json:"gamepad_just_pressed_buttons,omitempty"`
+	GamepadReleasedButtons    []ebiten.GamepadButton `json:"gamepad_released_buttons,omitempty"`
+	GamepadAxes               string                 `json:"gamepad_axes,omitempty"` // "left" or "right"
+
+	MouseButtons []ebiten.MouseButton `json:"mouse_buttons,omitempty"`
+	Touch        bool                 `json:"touch,omitempty"`
+}
+
+// ApplyProfile replaces the receiver's key, pad, mouse and touch bindings with the profile
+// The pad ID and stick responses are kept. Nothing changes if an action name is unknown
+func (r *receiver) ApplyProfile(profile InputProfile) error {
+	actions := make(map[string]input.Action, len(profile.Bindings))
+	for name, binding := range profile.Bindings {
+		action, ok := input.ActionByName(name)
+		if !ok {
+			return fmt.Errorf("input profile %s: unknown action %q", profile.Name, name)
+		}
+		if binding.GamepadAxes != "" && binding.GamepadAxes != "left" && binding.GamepadAxes != "right" {
+			return fmt.Errorf("input profile %s: invalid gamepad axes %q for %s", profile.Name, binding.GamepadAxes, name)
+		}
+		actions[name] = action
+	}
+
+	r.keyLayout = &keyLayout{}
+	r.mouseLayout = &mouseLayout{}
+	r.touchLayout = &touchLayout{}
+	if r.padLayout == nil {
+		r.padLayout = &padLayout{padID: -1}
+	}
+	r.ResetPadButtonMapping()
+	r.leftAxes, r.rightAxes = false, false
+
+	for name, binding := range profile.Bindings {
+		action := actions[name]
+		for _, key := range binding.Keys {
+			r.RegisterKey(key, action)
+		}
+		for _, key := range binding.JustPressedKeys {
+			r.RegisterJustPressedKey(key, action)
+		}
+		for _, key := range binding.ReleasedKeys {
+			r.RegisterReleasedKey(key, action)
+		}
+		for _, btn := range binding.GamepadButtons {
+			r.RegisterGamepadButton(btn, action)
+		}
+		for _, btn := range binding.GamepadJustPressedButtons {
+			r.RegisterGamepadJustPressedButton(btn, action)
+		}
+		for _, btn := range binding.GamepadReleasedButtons {
+			r.RegisterGamepadReleasedButton(btn, action)
+		}
+		if binding.GamepadAxes != "" {
+			r.RegisterGamepadAxes(binding.GamepadAxes == "left", action)
+		}
+		for _, btn := range binding.MouseButtons {
+			r.RegisterMouseButton(btn, action)
+		}
+		if binding.Touch {
+			r.RegisterTouch(action)
+		}
+	}
+	return nil
+}
+
+// Profile exports the receiver's current bindings under the given profile name
+// Bindings to actions without a name are skipped
+func (r *receiver) Profile(name string) InputProfile {
+	profile := InputProfile{Name: name, Bindings: map[string]ActionBinding{}}
+	bind := func(action input.Action, add func(*ActionBinding)) {
+		actionName, ok := input.ActionName(action)
+		if !ok {
+			return
+		}
+		binding := profile.Bindings[actionName]
+		add(&binding)
+		profile.Bindings[actionName] = binding
+	}
+
+	if r.keyLayout != nil {
+		for key, action := range r.keys {
+			if r.keyLayout.mask.Contains(uint32(key)) {
+				bind(action, func(b *ActionBinding) { b.Keys = append(b.Keys, ebiten.Key(key)) })
+			}
+		}
+		for key, action := range r.justPressedKeys {
+			if r.keyLayout.justPressedMask.Contains(uint32(key)) {
+				bind(action, func(b *ActionBinding) { b.JustPressedKeys = append(b.JustPressedKeys, ebiten.Key(key)) })
+			}
+		}
+		for key, action := range r.releasedKeys {
+			if r.keyLayout.releasedMask.Contains(uint32(key)) {
+				bind(action, func(b *ActionBinding) { b.ReleasedKeys = append(b.ReleasedKeys, ebiten.Key(key)) })
+			}
+		}
+	}
+	if r.padLayout != nil {
+		for btn, action := range r.buttons {
+			if r.padLayout.mask.Contains(uint32(btn)) {
+				bind(action, func(b *ActionBinding) { b.GamepadButtons = append(b.GamepadButtons, ebiten.GamepadButton(btn)) })
+			}
+		}
+		for btn, action := range r.pressed {
+			if r.padLayout.justPressedMask.Contains(uint32(btn)) {
+				bind(action, func(b *ActionBinding) {
+					b.GamepadJustPressedButtons = append(b.GamepadJustPressedButtons, ebiten.GamepadButton(btn))
+				})
+			}
+		}
+		for btn, action := range r.released {
+			if r.padLayout.releaseMask.Contains(uint32(btn)) {
+				bind(action, func(b *ActionBinding) {
+					b.GamepadReleasedButtons = append(b.GamepadReleasedButtons, ebiten.GamepadButton(btn))
+				})
+			}
+		}
+		if r.leftAxes {
+			bind(r.leftAxesInput, func(b *ActionBinding) { b.GamepadAxes = "left" })
+		}
+		if r.rightAxes {
+			bind(r.rightAxesInput, func(b *ActionBinding) { b.GamepadAxes = "right" })
+		}
+	}
+	if r.mouseLayout != nil {
+		for _, btn := range r.mouseButtonsRaw {
+			bind(r.mouseButtons[btn], func(b *ActionBinding) { b.MouseButtons = append(b.MouseButtons, btn) })
+		}
+	}
+	if r.touchLayout != nil && r.touchLayout.active {
+		bind(r.touchLayout.input, func(b *ActionBinding) { b.Touch = true })
+	}
+	return profile
+}
+
+// inputProfilesFile is the on disk format of SaveInputProfiles
+type inputProfilesFile struct {
+	Profiles []InputProfile `json:"profiles"`
+}
+
+// SaveInputProfiles writes the profiles to a JSON config file
+func SaveInputProfiles(path string, profiles ...InputProfile) error {
+	sorted := append([]InputProfile(nil), profiles...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	data, err := json.MarshalIndent(inputProfilesFile{Profiles: sorted}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal input profiles: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write input profiles: %w", err)
+	}
+	return nil
+}
+
+// LoadInputProfiles reads profiles saved with SaveInputProfiles, keyed by profile name
+func LoadInputProfiles(path string) (map[string]InputProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input profiles: %w", err)
+	}
+	file := inputProfilesFile{}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal input profiles: %w", err)
+	}
+	profiles := make(map[string]InputProfile, len(file.Profiles))
+	for _, profile := range file.Profiles {
+		profiles[profile.Name] = profile
+	}
+	return profiles, nil
+}
+
+// InputDevice identifies the device of a CapturedInput
+type InputDevice int
+
+const (
+	DeviceKeyboard InputDevice = iota
+	DeviceGamepad
+	DeviceMouse
+	DeviceTouch
+)
+
+// CapturedInput is the first input pressed while listening, as reported to a rebinding menu
+type CapturedInput struct {
+	Device        InputDevice
+	Key           ebiten.Key
+	GamepadID     ebiten.GamepadID
+	GamepadButton ebiten.GamepadButton
+	MouseButton   ebiten.MouseButton
+}
+
+// Bind adds the captured input to the binding as a held input
+func (c CapturedInput) Bind(binding *ActionBinding) {
+	switch c.Device {
+	case DeviceKeyboard:
+		binding.Keys = append(binding.Keys, c.Key)
+	case DeviceGamepad:
+		binding.GamepadButtons = append(binding.GamepadButtons, c.GamepadButton)
+	case DeviceMouse:
+		binding.MouseButtons = append(binding.MouseButtons, c.MouseButton)
+	case DeviceTouch:
+		binding.Touch = true
+	}
+}
+
+// ListenForNextInput suspends normal input capture until any key, gamepad button, mouse button or
+// touch is pressed, then reports it once to the callback and resumes capture
+func (m *inputManager) ListenForNextInput(callback func(CapturedInput)) {
+	m.listener = callback
+}
+
+// CancelListen stops a pending ListenForNextInput without reporting an input
+func (m *inputManager) CancelListen() {
+	m.listener = nil
+}
+
+// captureNextInput reports the first input pressed this tick to the pending listener
+func (m *inputManager) captureNextInput() {
+	var captured *CapturedInput
+	if keys := inpututil.AppendJustPressedKeys(nil); len(keys) > 0 {
+		captured = &CapturedInput{Device: DeviceKeyboard, Key: keys[0]}
+	}
+	if captured == nil {
+		for _, id := range ebiten.AppendGamepadIDs(nil) {
+			if buttons := inpututil.AppendJustPressedGamepadButtons(id, nil); len(buttons) > 0 {
+				captured = &CapturedInput{Device: DeviceGamepad, GamepadID: id, GamepadButton: buttons[0]}
+				break
+			}
+		}
+	}
+	if captured == nil {
+		for btn := ebiten.MouseButton0; btn <= ebiten.MouseButtonMax; btn++ {
+			if inpututil.IsMouseButtonJustPressed(btn) {
+				captured = &CapturedInput{Device: DeviceMouse, MouseButton: btn}
+				break
+			}
+		}
+	}
+	if captured == nil && len(inpututil.AppendJustPressedTouchIDs(nil)) > 0 {
+		captured = &CapturedInput{Device: DeviceTouch}
+	}
+	if captured == nil {
+		return
+	}
+	callback := m.listener
+	m.listener = nil
+	callback(*captured)
+}
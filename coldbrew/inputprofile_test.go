@@ -0,0 +1,66 @@
+package coldbrew
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// TestInputProfileRoundTrip tests exporting, saving, loading and applying a profile
+func TestInputProfileRoundTrip(t *testing.T) {
+	cli := NewTestClient(640, 480, 4, 2, 10)
+	rec, err := cli.ActivateReceiver()
+	if err != nil {
+		t.Fatalf("ActivateReceiver failed: %v", err)
+	}
+	jump, move := input.NewAction(), input.NewAction()
+	if err := input.NameAction(jump, "profile_test.jump"); err != nil {
+		t.Fatal(err)
+	}
+	if err := input.NameAction(move, "profile_test.move"); err != nil {
+		t.Fatal(err)
+	}
+
+	rec.RegisterKey(ebiten.KeySpace, jump)
+	rec.RegisterGamepadJustPressedButton(ebiten.GamepadButton0, jump)
+	rec.RegisterGamepadAxes(true, move)
+	rec.RegisterMouseButton(ebiten.MouseButtonLeft, jump)
+
+	exported := rec.Profile("default")
+	path := filepath.Join(t.TempDir(), "input.json")
+	if err := SaveInputProfiles(path, exported); err != nil {
+		t.Fatalf("SaveInputProfiles failed: %v", err)
+	}
+	profiles, err := LoadInputProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadInputProfiles failed: %v", err)
+	}
+	loaded := profiles["default"]
+	if !reflect.DeepEqual(loaded, exported) {
+		t.Fatalf("Loaded profile %+v, want %+v", loaded, exported)
+	}
+
+	// Rebind jump and apply the profile to a fresh receiver
+	binding := loaded.Bindings["profile_test.jump"]
+	binding.Keys = []ebiten.Key{ebiten.KeyW}
+	loaded.Bindings["profile_test.jump"] = binding
+
+	other, err := cli.ActivateReceiver()
+	if err != nil {
+		t.Fatalf("ActivateReceiver failed: %v", err)
+	}
+	if err := other.ApplyProfile(loaded); err != nil {
+		t.Fatalf("ApplyProfile failed: %v", err)
+	}
+	if got := other.Profile("default"); !reflect.DeepEqual(got, loaded) {
+		t.Errorf("Applied profile %+v, want %+v", got, loaded)
+	}
+
+	loaded.Bindings["profile_test.unknown"] = ActionBinding{}
+	if err := other.ApplyProfile(loaded); err == nil {
+		t.Errorf("ApplyProfile accepted an unknown action")
+	}
+}
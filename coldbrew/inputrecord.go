@@ -0,0 +1,199 @@
+package coldbrew
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// InputRecording is a captured input session that can be saved, loaded and replayed
+type InputRecording struct {
+	Seed      uint64          `json:"seed"`       // Seed the game used for its RNG while recording
+	StartTick int             `json:"start_tick"` // Tick the recording started on
+	EndTick   int             `json:"end_tick"`   // Last tick popped while recording
+	Frames    []RecordedFrame `json:"frames"`
+}
+
+// RecordedFrame holds the actions a receiver popped on a single tick
+type RecordedFrame struct {
+	Tick     int                   `json:"tick"`
+	Receiver int                   `json:"receiver"`
+	Actions  []input.StampedAction `json:"actions"`
+}
+
+// InputRecorder captures every action popped from the receivers of a client
+type InputRecorder struct {
+	mu        sync.Mutex
+	recording InputRecording
+}
+
+// NewInputRecorder creates a recorder starting at the current tick
+// The seed is stored with the recording so a replay can restore the same RNG state
+func NewInputRecorder(seed uint64) *InputRecorder {
+	return &InputRecorder{
+		recording: InputRecording{Seed: seed, StartTick: tick, EndTick: tick},
+	}
+}
+
+// record stores the actions popped by a receiver on the current tick
+func (r *InputRecorder) record(receiverIndex int, actions []input.StampedAction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recording.EndTick = tick
+	if len(actions) == 0 {
+		return
+	}
+	copied := make([]input.StampedAction, len(actions))
+	copy(copied, actions)
+	r.recording.Frames = append(r.recording.Frames, RecordedFrame{
+		Tick:     tick,
+		Receiver: receiverIndex,
+		Actions:  copied,
+	})
+}
+
+// Recording returns a snapshot of everything recorded so far
+func (r *InputRecorder) Recording() InputRecording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	snapshot := r.recording
+	snapshot.Frames = append([]RecordedFrame(nil), r.recording.Frames...)
+	return snapshot
+}
+
+// Save writes the recording to a JSON file
+func (r *InputRecorder) Save(path string) error {
+	rec := r.Recording()
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal input recording: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write input recording: %w", err)
+	}
+	return nil
+}
+
+// LoadInputRecording reads a recording saved with InputRecorder.Save
+func LoadInputRecording(path string) (*InputRecording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input recording: %w", err)
+	}
+	rec := &InputRecording{}
+	if err := json.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal input recording: %w", err)
+	}
+	return rec, nil
+}
+
+// InputReplay feeds a recording back into the receivers in place of the live capturers
+type InputReplay struct {
+	recording *InputRecording
+	next      int
+}
+
+// NewInputReplay creates a replay source for the recording
+func NewInputReplay(recording *InputRecording) *InputReplay {
+	return &InputReplay{recording: recording}
+}
+
+// Recording returns the recording being replayed
+func (r *InputReplay) Recording() *InputRecording {
+	return r.recording
+}
+
+// Done reports whether every recorded tick has been replayed
+func (r *InputReplay) Done() bool {
+	return r.next >= len(r.recording.Frames) && tick > r.recording.EndTick
+}
+
+// inject queues the frames due on the current tick onto their receivers
+func (r *InputReplay) inject(receivers []*receiver) {
+	for r.next < len(r.recording.Frames) && r.recording.Frames[r.next].Tick <= tick {
+		frame := r.recording.Frames[r.next]
+		r.next++
+		if frame.Receiver < 0 || frame.Receiver >= len(receivers) {
+			continue
+		}
+		target := receivers[frame.Receiver]
+		target.actions.replayed = append(target.actions.replayed, frame.Actions...)
+	}
+}
+
+// RecordInputs starts recording every action popped from the client's receivers
+// Passing nil stops recording
+func (m *inputManager) RecordInputs(recorder *InputRecorder) {
+	m.recorder = recorder
+	for _, r := range m.receivers {
+		r.recorder = recorder
+	}
+}
+
+// ReplayInputs replaces the live capturers with the replay and rewinds the tick to the recording start
+// Passing nil restores live input
+func (m *inputManager) ReplayInputs(replay *InputReplay) {
+	m.replay = replay
+	if replay != nil {
+		ForceSetTick(replay.recording.StartTick)
+	}
+}
+
+// RunReplay updates the client headlessly until the replay is exhausted
+// The game should seed its RNG with the recording's Seed before calling it
+// Only clients that advance the tick in Update can be replayed, networked clients take their tick from the server
+func RunReplay(cli Client, replay *InputReplay) error {
+	cli.ReplayInputs(replay)
+	defer cli.ReplayInputs(nil)
+	for !replay.Done() {
+		before := tick
+		if err := cli.Update(); err != nil {
+			return err
+		}
+		if tick == before {
+			return fmt.Errorf("replay stalled on tick %d, the client did not advance the tick", before)
+		}
+	}
+	return nil
+}
+
+// CompareGoldenStorage serializes the storage and compares it against the golden file at path
+// With update set, the golden file is (re)written instead
+func CompareGoldenStorage(sto warehouse.Storage, path string, update bool) error {
+	tmp, err := os.CreateTemp("", "coldbrew-golden-*.json")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpName)
+
+	if err := warehouse.SaveStorage(sto, tmpName, tick); err != nil {
+		return err
+	}
+	got, err := os.ReadFile(tmpName)
+	if err != nil {
+		return err
+	}
+
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, got, 0644)
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read golden file: %w", err)
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("storage does not match golden file %s", path)
+	}
+	return nil
+}
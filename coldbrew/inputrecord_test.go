@@ -0,0 +1,246 @@
+package coldbrew
+
+import (
+	"flag"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint"
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/table"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// replayScore is the component the replay test systems write to
+type replayScore struct {
+	Jumps, Fires, Ticks int
+}
+
+var replayScoreComp = warehouse.FactoryNewComponent[replayScore]()
+
+var replayJump, replayFire = input.NewAction(), input.NewAction()
+
+// scriptedInput stands in for an input device, queuing actions onto a receiver on set ticks
+type scriptedInput struct {
+	receiver *receiver
+	script   map[int][]input.Action
+}
+
+func (s scriptedInput) Run(Client) error {
+	for _, action := range s.script[tick] {
+		s.receiver.actions.kb = append(s.receiver.actions.kb, input.StampedAction{Tick: tick, Val: action})
+	}
+	return nil
+}
+
+// replayInputSystem scores the actions popped from the first receiver
+type replayInputSystem struct{}
+
+func (replayInputSystem) Run(cli LocalClient, scene Scene) error {
+	actions := cli.Receiver(0).PopActions()
+	cursor := scene.NewCursor(warehouse.Factory.NewQuery().And(replayScoreComp))
+	for range cursor.Next() {
+		score := replayScoreComp.GetFromCursor(cursor)
+		for _, action := range actions {
+			switch action.Val {
+			case replayJump:
+				score.Jumps++
+			case replayFire:
+				score.Fires++
+			}
+		}
+	}
+	return nil
+}
+
+// replayTickSystem counts the ticks the scene ran for
+type replayTickSystem struct{}
+
+func (replayTickSystem) Run(scene blueprint.Scene, dt float64) error {
+	cursor := scene.NewCursor(warehouse.Factory.NewQuery().And(replayScoreComp))
+	for range cursor.Next() {
+		replayScoreComp.GetFromCursor(cursor).Ticks++
+	}
+	return nil
+}
+
+// newReplayClient creates a client with a single scored scene and an active receiver
+func newReplayClient(t *testing.T) (Client, *receiver) {
+	// A fresh world keeps the scene's entity IDs independent of the tests that ran before
+	world := warehouse.DefaultWorld
+	warehouse.DefaultWorld = warehouse.NewWorld()
+	t.Cleanup(func() { warehouse.DefaultWorld = world })
+
+	cli := NewTestClient(640, 480, 4, 2, 10)
+	rec, err := cli.ActivateReceiver()
+	if err != nil {
+		t.Fatalf("ActivateReceiver failed: %v", err)
+	}
+	plan := func(width, height int, sto warehouse.Storage) error {
+		_, err := sto.NewEntities(1, replayScoreComp)
+		return err
+	}
+	err = cli.RegisterScene(
+		"replay", 640, 480, plan,
+		[]RenderSystem{},
+		[]ClientSystem{replayInputSystem{}},
+		[]blueprint.CoreSystem{replayTickSystem{}},
+	)
+	if err != nil {
+		t.Fatalf("RegisterScene failed: %v", err)
+	}
+	return cli, rec.(*receiver)
+}
+
+// replayStorage returns the storage of the client's only scene
+func replayStorage(cli Client) warehouse.Storage {
+	for scene := range cli.ActiveScenes() {
+		return scene.Storage()
+	}
+	return nil
+}
+
+// TestRunReplayGolden tests that replaying a recorded session reproduces the live storage
+// Run with -update to rewrite testdata/replay_golden.json
+func TestRunReplayGolden(t *testing.T) {
+	golden := filepath.Join("testdata", "replay_golden.json")
+
+	live, r := newReplayClient(t)
+	live.RegisterGlobalClientSystem(scriptedInput{
+		receiver: r,
+		script: map[int][]input.Action{
+			2: {replayJump},
+			5: {replayFire, replayJump},
+			9: {replayJump},
+		},
+	})
+	// Load the scene up front so the live session does not race the async asset loading
+	if err := live.PreExecAllPlans(); err != nil {
+		t.Fatalf("PreExecAllPlans failed: %v", err)
+	}
+	ForceSetTick(0)
+	recorder := NewInputRecorder(7)
+	live.RecordInputs(recorder)
+	for range 12 {
+		if err := live.Update(); err != nil {
+			t.Fatalf("Live update failed: %v", err)
+		}
+	}
+	live.RecordInputs(nil)
+	if err := CompareGoldenStorage(replayStorage(live), golden, *updateGolden); err != nil {
+		t.Fatalf("Live session: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	recording, err := LoadInputRecording(path)
+	if err != nil {
+		t.Fatalf("LoadInputRecording failed: %v", err)
+	}
+
+	replayed, _ := newReplayClient(t)
+	if err := RunReplay(replayed, NewInputReplay(recording)); err != nil {
+		t.Fatalf("RunReplay failed: %v", err)
+	}
+	if err := CompareGoldenStorage(replayStorage(replayed), golden, false); err != nil {
+		t.Errorf("Replay: %v", err)
+	}
+}
+
+// stalledClient never advances the tick, like a networked client waiting on its server
+type stalledClient struct {
+	Client
+}
+
+func (stalledClient) Update() error { return nil }
+
+// TestRunReplayStalled tests that RunReplay fails instead of spinning when the tick does not move
+func TestRunReplayStalled(t *testing.T) {
+	cli, _ := newReplayClient(t)
+	recording := &InputRecording{StartTick: 3, EndTick: 5}
+	err := RunReplay(stalledClient{cli}, NewInputReplay(recording))
+	if err == nil || !strings.Contains(err.Error(), "stalled on tick 3") {
+		t.Errorf("RunReplay error = %v, want a stall on tick 3", err)
+	}
+}
+
+// TestInputRecordAndReplay tests that replayed actions match the recorded ones tick for tick
+func TestInputRecordAndReplay(t *testing.T) {
+	cli := NewTestClient(640, 480, 4, 2, 10)
+	rec, err := cli.ActivateReceiver()
+	if err != nil {
+		t.Fatalf("ActivateReceiver failed: %v", err)
+	}
+	r := rec.(*receiver)
+	jump, fire := input.NewAction(), input.NewAction()
+
+	ForceSetTick(10)
+	recorder := NewInputRecorder(42)
+	cli.RecordInputs(recorder)
+
+	live := map[int][]input.StampedAction{}
+	for i, action := range []input.Action{jump, fire, jump} {
+		r.actions.kb = append(r.actions.kb, input.StampedAction{Tick: tick, Val: action, X: i})
+		live[tick] = r.PopActions()
+		tick++
+	}
+	cli.RecordInputs(nil)
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadInputRecording(path)
+	if err != nil {
+		t.Fatalf("LoadInputRecording failed: %v", err)
+	}
+	if loaded.Seed != 42 || loaded.StartTick != 10 || len(loaded.Frames) != 3 {
+		t.Fatalf("Unexpected recording: %+v", loaded)
+	}
+
+	replay := NewInputReplay(loaded)
+	cli.ReplayInputs(replay)
+	defer cli.ReplayInputs(nil)
+	if tick != 10 {
+		t.Fatalf("ReplayInputs left tick at %d, want 10", tick)
+	}
+	for range live {
+		replay.inject(cli.(*clientImpl).receivers[:])
+		if got := r.PopActions(); !reflect.DeepEqual(got, live[tick]) {
+			t.Errorf("Tick %d replayed %v, want %v", tick, got, live[tick])
+		}
+		tick++
+	}
+	if !replay.Done() {
+		t.Errorf("Replay not done after every recorded tick")
+	}
+}
+
+// TestCompareGoldenStorage tests writing and checking a golden storage snapshot
+func TestCompareGoldenStorage(t *testing.T) {
+	type counter struct{ Value int }
+	counterComp := warehouse.FactoryNewComponent[counter]()
+	sto := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := sto.NewEntities(1, counterComp)
+	if err != nil {
+		t.Fatalf("NewEntities failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "golden", "storage.json")
+	if err := CompareGoldenStorage(sto, path, true); err != nil {
+		t.Fatalf("Updating golden file failed: %v", err)
+	}
+	if err := CompareGoldenStorage(sto, path, false); err != nil {
+		t.Errorf("Unchanged storage does not match: %v", err)
+	}
+	counterComp.GetFromEntity(entities[0]).Value = 3
+	if err := CompareGoldenStorage(sto, path, false); err == nil {
+		t.Errorf("Changed storage matched the golden file")
+	}
+}
@@ -15,6 +15,7 @@ type PadLayout interface {
 	RegisterGamepadReleasedButton(ebiten.GamepadButton, input.Action)
 
 	RegisterGamepadAxes(left bool, input input.Action)
+	SetStickResponse(left bool, response StickResponse)
 
 	PadActive() bool
 
@@ -36,8 +37,34 @@ type padLayout struct {
 	rightAxes      bool
 	leftAxesInput  input.Action
 	rightAxesInput input.Action
+	leftResponse   StickResponse
+	rightResponse  StickResponse
+	// A set response is used as is, even the zero value (no deadzone, linear)
+	leftResponseSet  bool
+	rightResponseSet bool
 }
 
+// ResponseCurve shapes stick magnitude between the deadzones
+type ResponseCurve int
+
+const (
+	// CurveLinear maps magnitude linearly
+	CurveLinear ResponseCurve = iota
+	// CurveExponential raises magnitude to StickResponse.Exponent for finer control near the center
+	CurveExponential
+)
+
+// StickResponse configures how raw stick positions become analog values
+type StickResponse struct {
+	Deadzone      float64 // Magnitudes below this read as zero
+	OuterDeadzone float64 // Magnitudes above 1-OuterDeadzone read as full tilt
+	Curve         ResponseCurve
+	Exponent      float64 // Used by CurveExponential, defaults to 2
+}
+
+// DefaultStickResponse is used by layouts that never call SetStickResponse
+var DefaultStickResponse = StickResponse{Deadzone: 0.25}
+
 // RegisterPad sets the gamepad identifier
 func (layout *padLayout) RegisterPad(padID int) {
 	layout.padID = padID
@@ -92,7 +119,28 @@ func (layout *padLayout) RegisterGamepadAxes(left bool, localInput input.Action)
 	layout.rightAxesInput = localInput
 }
 
-// RegisterGamepadAxes maps an analog stick to an input action
+// SetStickResponse configures the deadzones and response curve of an analog stick
+// The zero StickResponse is a valid setting: no deadzones and a linear curve
+func (layout *padLayout) SetStickResponse(left bool, response StickResponse) {
+	if left {
+		layout.leftResponse, layout.leftResponseSet = response, true
+		return
+	}
+	layout.rightResponse, layout.rightResponseSet = response, true
+}
+
+// stickResponse returns the configured response of a stick, falling back to DefaultStickResponse
+func (layout *padLayout) stickResponse(left bool) StickResponse {
+	if left && layout.leftResponseSet {
+		return layout.leftResponse
+	}
+	if !left && layout.rightResponseSet {
+		return layout.rightResponse
+	}
+	return DefaultStickResponse
+}
+
+// PadActive reports whether a gamepad was registered for the layout
 func (layout *padLayout) PadActive() bool {
 	return layout.active
 }
@@ -0,0 +1,64 @@
+package coldbrew
+
+import (
+	"math"
+	"testing"
+)
+
+// TestProcessStickInput tests deadzones and response curves on raw stick positions
+func TestProcessStickInput(t *testing.T) {
+	const eps = 1e-9
+	diagonal := 1 / math.Sqrt2
+
+	tests := []struct {
+		name     string
+		x, y     float64
+		response StickResponse
+		want     stick
+	}{
+		{"inside deadzone", 0.2, 0, StickResponse{Deadzone: 0.25}, stick{}},
+		{"at rest without deadzone", 0, 0, StickResponse{}, stick{}},
+		{"no deadzone passes through", 0.3, -0.4, StickResponse{}, stick{X: 0.3, Y: -0.4}},
+		{"deadzone rescales to start at zero", 0.625, 0, StickResponse{Deadzone: 0.25}, stick{X: 0.5}},
+		{"full tilt", 1, 0, StickResponse{Deadzone: 0.25}, stick{X: 1}},
+		{"out of range is clamped", 0, -3, StickResponse{}, stick{Y: -1}},
+		{"outer deadzone reaches full tilt early", 0.9, 0, StickResponse{OuterDeadzone: 0.1}, stick{X: 1}},
+		{"outer deadzone rescales", 0.45, 0, StickResponse{OuterDeadzone: 0.1}, stick{X: 0.5}},
+		{"overlapping deadzones fall back to full range", 0.5, 0, StickResponse{Deadzone: 0.5, OuterDeadzone: 0.6}, stick{}},
+		{"diagonal keeps direction", diagonal, diagonal, StickResponse{}, stick{X: diagonal, Y: diagonal}},
+		{"exponential defaults to square", 0.5, 0, StickResponse{Curve: CurveExponential}, stick{X: 0.25}},
+		{"exponential exponent", 0.5, 0, StickResponse{Curve: CurveExponential, Exponent: 3}, stick{X: 0.125}},
+		{"exponential after deadzone", 0, 0.625, StickResponse{Deadzone: 0.25, Curve: CurveExponential}, stick{Y: 0.25}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := processStickInput(tt.x, tt.y, tt.response)
+			if math.Abs(got.X-tt.want.X) > eps || math.Abs(got.Y-tt.want.Y) > eps {
+				t.Errorf("processStickInput(%v, %v) = %+v, want %+v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestStickResponseFallback tests that only sticks without a response use the default,
+// including sticks explicitly set to the zero response
+func TestStickResponseFallback(t *testing.T) {
+	layout := &padLayout{}
+	if got := layout.stickResponse(true); got != DefaultStickResponse {
+		t.Errorf("Unset left stick = %+v, want the default %+v", got, DefaultStickResponse)
+	}
+
+	layout.SetStickResponse(true, StickResponse{})
+	if got := layout.stickResponse(true); got != (StickResponse{}) {
+		t.Errorf("Left stick set to zero = %+v, want the zero response", got)
+	}
+	if got := layout.stickResponse(false); got != DefaultStickResponse {
+		t.Errorf("Unset right stick = %+v, want the default %+v", got, DefaultStickResponse)
+	}
+
+	custom := StickResponse{Deadzone: 0.1, Curve: CurveExponential, Exponent: 1.5}
+	layout.SetStickResponse(false, custom)
+	if got := layout.stickResponse(false); got != custom {
+		t.Errorf("Right stick = %+v, want %+v", got, custom)
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"math/rand/v2"
 	"sync"
 
+	"github.com/TheBitDrifter/bappa/blueprint/input"
 	"github.com/TheBitDrifter/bappa/drip"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
-var errNotAssignEntityIDMessage = errors.New("message is not AssignEntityIDMessage")
+var (
+	errNotAssignEntityIDMessage = errors.New("message is not AssignEntityIDMessage")
+	errNotEventsMessage         = errors.New("message is not EventsMessage")
+)
 
 // NetworkClient extends the base Client interface with networking capabilities.
 type NetworkClient interface {
@@ -33,6 +37,12 @@ type NetworkClient interface {
 	SetDeserCallback(func(NetworkClient, []byte) error)
 
 	AssociatedEntityID() (int, bool)
+
+	// ActionRegistryError reports how the server's action registry differs from the client's,
+	// nil when they match or before the server assigned an entity.
+	// A mismatch is only logged and the connection stays up, so games that cannot play with
+	// differing actions should check this once AssociatedEntityID is set and Disconnect.
+	ActionRegistryError() error
 }
 
 // networkClientImpl is the concrete implementation of NetworkClient.
@@ -50,6 +60,7 @@ type networkClientImpl struct {
 
 	associatedEntityID    int // Server-assigned entity ID.
 	hasAssociatedEntityID bool
+	actionRegistryErr     error // Mismatch between server and client action registries.
 	assocEntityIDMutex    sync.RWMutex
 }
 
@@ -165,6 +176,12 @@ func (nc *networkClientImpl) Update() error {
 		if err == nil {
 			continue
 		} else if errors.Is(err, errNotAssignEntityIDMessage) {
+			if evErr := nc.tryProcessEvents(msgData); evErr == nil {
+				continue
+			} else if !errors.Is(evErr, errNotEventsMessage) {
+				log.Printf("NetworkClient Update: Error processing events: %v", evErr)
+				continue
+			}
 			latestStateData = msgData // Not the ID message, keep as potential state.
 		} else {
 			log.Printf("NetworkClient Update: Error checking for AssignEntityID type: %v", err)
@@ -266,8 +283,16 @@ func (nc *networkClientImpl) AssociatedEntityID() (int, bool) {
 	return int(nc.associatedEntityID), nc.hasAssociatedEntityID
 }
 
+// ActionRegistryError reports how the server's action registry differs from the client's.
+func (nc *networkClientImpl) ActionRegistryError() error {
+	nc.assocEntityIDMutex.RLock()
+	defer nc.assocEntityIDMutex.RUnlock()
+	return nc.actionRegistryErr
+}
+
 // tryProcessAssignEntityID attempts to decode data as an AssignEntityIDMessage.
 // If successful, it stores the ID and returns nil. Otherwise, returns an error.
+// An action registry mismatch does not fail the message, it is logged and kept for ActionRegistryError.
 func (nc *networkClientImpl) tryProcessAssignEntityID(data []byte) error {
 	var msg drip.AssignEntityIDMessage
 	// Attempt to unmarshal using the specific message structure.
@@ -278,14 +303,43 @@ func (nc *networkClientImpl) tryProcessAssignEntityID(data []byte) error {
 		return errNotAssignEntityIDMessage // Correct structure, wrong type.
 	}
 
+	registryErr := input.CompareActions(msg.Actions)
+	if registryErr != nil {
+		log.Printf("NetworkClient: %v", registryErr)
+	}
+
 	nc.assocEntityIDMutex.Lock()
 	nc.associatedEntityID = int(msg.EntityID)
 	nc.hasAssociatedEntityID = true
+	nc.actionRegistryErr = registryErr
 	nc.assocEntityIDMutex.Unlock()
 	log.Printf("NetworkClient: Stored associated Entity ID: %d", msg.EntityID)
 	return nil
 }
 
+// tryProcessEvents attempts to decode data as a drip.EventsMessage.
+// If successful, it emits the events once, on the storage of the active scene matching the server's scene
+// (the first active scene when the server sent no name). Events for scenes not active on the client are dropped.
+func (nc *networkClientImpl) tryProcessEvents(data []byte) error {
+	var msg drip.EventsMessage
+	if err := json.Unmarshal(data, &msg); err != nil || msg.Type != drip.EventsMessageType {
+		return errNotEventsMessage
+	}
+	for activeScene := range nc.ActiveScenes() {
+		if msg.Scene != "" && activeScene.Name() != msg.Scene {
+			continue
+		}
+		bus := activeScene.Storage().Events()
+		for _, event := range msg.Events {
+			if err := bus.EmitSerialized(event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
 func (nc *networkClientImpl) Draw(image *ebiten.Image) {
 	sharedDraw(nc, image)
 }
@@ -10,6 +10,8 @@ type Receiver interface {
 	RegisterPad(padID int)
 	Active() bool
 	PopActions() []input.StampedAction
+	ApplyProfile(InputProfile) error
+	Profile(name string) InputProfile
 	PadLayout
 	KeyLayout
 	MouseLayout
@@ -17,7 +19,9 @@ type Receiver interface {
 }
 
 type receiver struct {
-	active bool
+	active   bool
+	index    int
+	recorder *InputRecorder
 	actions
 	*keyLayout
 	*padLayout
@@ -30,6 +34,8 @@ type actions struct {
 	pad     []input.StampedAction
 	mouse   []input.StampedAction
 	kb      []input.StampedAction
+
+	replayed []input.StampedAction
 }
 
 // Active returns whether the receiver is accepting input
@@ -52,7 +58,12 @@ func (receiver *receiver) PopActions() []input.StampedAction {
 	for _, input := range receiver.actions.touches {
 		removed = append(removed, input)
 	}
+	removed = append(removed, receiver.actions.replayed...)
 	receiver.actions = actions{}
 
+	if receiver.recorder != nil {
+		receiver.recorder.record(receiver.index, removed)
+	}
+
 	return removed
 }
@@ -19,6 +19,8 @@ type Sprite interface {
 	Draw(*ebiten.Image, *ebiten.DrawImageOptions)
 	// GetFrame retrieves a specific frame from a sprite sheet
 	GetFrame(rowIndex, frameIndex, frameWidth, frameHeight int) *ebiten.Image
+	// GetFrameRect retrieves a frame from an arbitrary rect of a packed sprite sheet
+	GetFrameRect(rect image.Rectangle) *ebiten.Image
 	// GetTile retrieves a specific tile from a tileset
 	GetTile(tileX, tileY, tileWidth, tileHeight int) *ebiten.Image
 }
@@ -44,9 +46,9 @@ func (s *sprite) Draw(target *ebiten.Image, opts *ebiten.DrawImageOptions) {
 	target.DrawImage(s.image, opts)
 }
 
-// generateFrameKey creates a unique key for an animation frame
-func generateFrameKey(rowIndex, frameIndex, frameWidth, frameHeight int) string {
-	return fmt.Sprintf("r%d_f%d_w%d_h%d", rowIndex, frameIndex, frameWidth, frameHeight)
+// generateFrameKey creates a unique key for an animation frame rect
+func generateFrameKey(rect image.Rectangle) string {
+	return fmt.Sprintf("x%d_y%d_w%d_h%d", rect.Min.X, rect.Min.Y, rect.Dx(), rect.Dy())
 }
 
 // generateTileKey creates a unique key for a tileset tile
@@ -54,9 +56,16 @@ func generateTileKey(tileX, tileY, tileWidth, tileHeight int) string {
 	return fmt.Sprintf("tx%d_ty%d_tw%d_th%d", tileX, tileY, tileWidth, tileHeight)
 }
 
-// GetFrame retrieves a frame from the sprite sheet, using the cache if available
+// GetFrame retrieves a frame from a sprite sheet laid out in uniform rows
 func (s *sprite) GetFrame(rowIndex, frameIndex, frameWidth, frameHeight int) *ebiten.Image {
-	frameKey := generateFrameKey(rowIndex, frameIndex, frameWidth, frameHeight)
+	sx := frameIndex * frameWidth
+	sy := rowIndex * frameHeight
+	return s.GetFrameRect(image.Rect(sx, sy, sx+frameWidth, sy+frameHeight))
+}
+
+// GetFrameRect retrieves a frame from the sprite sheet, using the cache if available
+func (s *sprite) GetFrameRect(rect image.Rectangle) *ebiten.Image {
+	frameKey := generateFrameKey(rect)
 
 	// Try to get from cache first (with read lock)
 	s.mutex.RLock()
@@ -76,9 +85,7 @@ func (s *sprite) GetFrame(rowIndex, frameIndex, frameWidth, frameHeight int) *eb
 	}
 
 	// Create the frame
-	sx := frameIndex * frameWidth
-	sy := rowIndex * frameHeight
-	frame := s.image.SubImage(image.Rect(sx, sy, sx+frameWidth, sy+frameHeight)).(*ebiten.Image)
+	frame := s.image.SubImage(rect).(*ebiten.Image)
 
 	// Initialize the frames map if needed
 	if s.frames == nil {
@@ -0,0 +1,386 @@
+package text
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/go-text/typesetting/segmenter"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+// IconGlyph stands in for inline icons in plain text
+const IconGlyph = "￼"
+
+// RichText is dialogue text parsed from its markup
+//
+// Markup tags are written in brackets and "[[" is a literal bracket:
+//
+//	[color=#ff4040]...[/color]  color by hex (#rgb, #rrggbb, #rrggbbaa) or name
+//	[wave]...[/wave]            glyphs bob up and down
+//	[shake]...[/shake]          glyphs jitter
+//	[speed=N]...[/speed]        reveal N ticks per glyph
+//	[pause=N]                   wait N ticks before revealing on
+//	[icon=name]                 inline icon, revealed as a single glyph
+//
+// Unknown tags are kept as text
+type RichText struct {
+	Spans []Span
+	// Glyphs is the number of visible glyphs across the spans
+	Glyphs int
+}
+
+// Span is a run of glyphs sharing a style
+type Span struct {
+	Style Style
+	// Glyphs are grapheme clusters, "\n" for line breaks
+	Glyphs []string
+	// Icon names an inline icon, in which case Glyphs holds IconGlyph
+	Icon string
+	// Pause is the number of ticks waited before the span starts revealing
+	Pause int
+
+	// sources are the byte ranges of the glyphs in the markup
+	sources [][2]int
+}
+
+// Style is the look and reveal speed of a span
+type Style struct {
+	// Color is nil to keep the color of the draw options
+	Color color.Color
+	Wave  bool
+	Shake bool
+	// Speed is the number of ticks per glyph, 0 for the reveal default
+	Speed int
+}
+
+var namedColors = map[string]color.RGBA{
+	"white":  {0xff, 0xff, 0xff, 0xff},
+	"black":  {0x00, 0x00, 0x00, 0xff},
+	"gray":   {0x80, 0x80, 0x80, 0xff},
+	"red":    {0xff, 0x40, 0x40, 0xff},
+	"green":  {0x40, 0xd0, 0x40, 0xff},
+	"blue":   {0x40, 0x80, 0xff, 0xff},
+	"yellow": {0xff, 0xe0, 0x40, 0xff},
+	"orange": {0xff, 0xa0, 0x30, 0xff},
+	"purple": {0xb0, 0x60, 0xff, 0xff},
+}
+
+// parsed caches Parse results by markup, dropped wholesale when it grows past parsedLimit
+var parsed = struct {
+	sync.Mutex
+	texts map[string]*RichText
+}{
+	texts: make(map[string]*RichText),
+}
+
+const parsedLimit = 1024
+
+// Parse returns the rich text for the markup
+// Results are cached and shared, so they must not be modified
+func Parse(markup string) *RichText {
+	parsed.Lock()
+	defer parsed.Unlock()
+	if rt, ok := parsed.texts[markup]; ok {
+		return rt
+	}
+	if len(parsed.texts) >= parsedLimit {
+		clear(parsed.texts)
+	}
+	rt := parse(markup)
+	parsed.texts[markup] = rt
+	return rt
+}
+
+func parse(markup string) *RichText {
+	var (
+		rt     = &RichText{}
+		colors []color.Color
+		speeds []int
+		wave   int
+		shake  int
+		pause  int
+		runes  []rune
+		starts []int
+	)
+	style := func() Style {
+		s := Style{Wave: wave > 0, Shake: shake > 0}
+		if len(colors) > 0 {
+			s.Color = colors[len(colors)-1]
+		}
+		if len(speeds) > 0 {
+			s.Speed = speeds[len(speeds)-1]
+		}
+		return s
+	}
+	// flush splits the pending text into glyphs, mapping each back to its source
+	flush := func(end int) {
+		if len(runes) == 0 {
+			return
+		}
+		span := Span{Style: style(), Pause: pause}
+		var seg segmenter.Segmenter
+		seg.Init(runes)
+		graphemes := seg.GraphemeIterator()
+		for graphemes.Next() {
+			grapheme := graphemes.Grapheme()
+			last := grapheme.Offset + len(grapheme.Text)
+			sourceEnd := end
+			if last < len(starts) {
+				sourceEnd = starts[last]
+			}
+			span.Glyphs = append(span.Glyphs, string(grapheme.Text))
+			span.sources = append(span.sources, [2]int{starts[grapheme.Offset], sourceEnd})
+		}
+		rt.Spans = append(rt.Spans, span)
+		rt.Glyphs += len(span.Glyphs)
+		runes, starts, pause = runes[:0], starts[:0], 0
+	}
+
+	for i := 0; i < len(markup); {
+		if strings.HasPrefix(markup[i:], "[[") {
+			runes = append(runes, '[')
+			starts = append(starts, i)
+			i += 2
+			continue
+		}
+		if markup[i] == '[' {
+			if end := strings.IndexByte(markup[i:], ']'); end > 0 {
+				name, value, _ := strings.Cut(markup[i+1:i+end], "=")
+				known := true
+				// Closing tags end the text before them, opening tags the text after them
+				switch name {
+				case "/color", "/wave", "/shake", "/speed":
+					flush(i)
+				}
+				switch name {
+				case "color":
+					c, ok := parseColor(value)
+					if known = ok; ok {
+						flush(i)
+						colors = append(colors, c)
+					}
+				case "/color":
+					colors = colors[:max(len(colors)-1, 0)]
+				case "wave":
+					flush(i)
+					wave++
+				case "/wave":
+					wave = max(wave-1, 0)
+				case "shake":
+					flush(i)
+					shake++
+				case "/shake":
+					shake = max(shake-1, 0)
+				case "speed":
+					n, err := strconv.Atoi(value)
+					if known = err == nil && n >= 0; known {
+						flush(i)
+						speeds = append(speeds, n)
+					}
+				case "/speed":
+					speeds = speeds[:max(len(speeds)-1, 0)]
+				case "pause":
+					n, err := strconv.Atoi(value)
+					if known = err == nil && n >= 0; known {
+						flush(i)
+						pause += n
+					}
+				case "icon":
+					if known = value != ""; known {
+						flush(i)
+						rt.Spans = append(rt.Spans, Span{
+							Style:   style(),
+							Glyphs:  []string{IconGlyph},
+							Icon:    value,
+							Pause:   pause,
+							sources: [][2]int{{i, i + end + 1}},
+						})
+						rt.Glyphs++
+						pause = 0
+					}
+				default:
+					known = false
+				}
+				if known {
+					i += end + 1
+					continue
+				}
+			}
+		}
+		r, size := utf8.DecodeRuneInString(markup[i:])
+		runes = append(runes, r)
+		starts = append(starts, i)
+		i += size
+	}
+	flush(len(markup))
+	return rt
+}
+
+func parseColor(value string) (color.Color, bool) {
+	if c, ok := namedColors[strings.ToLower(value)]; ok {
+		return c, true
+	}
+	hex, ok := strings.CutPrefix(value, "#")
+	if !ok {
+		return nil, false
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	if len(hex) != 8 {
+		return nil, false
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	return color.NRGBA{R: uint8(n >> 24), G: uint8(n >> 16), B: uint8(n >> 8), A: uint8(n)}, true
+}
+
+// Plain returns the first count glyphs without markup, icons as IconGlyph
+func (rt *RichText) Plain(count int) string {
+	var b strings.Builder
+	for _, span := range rt.Spans {
+		for _, glyph := range span.Glyphs {
+			if count <= 0 {
+				return b.String()
+			}
+			b.WriteString(glyph)
+			count--
+		}
+	}
+	return b.String()
+}
+
+// Glyph returns the glyph at the index and its span, counting across spans
+func (rt *RichText) Glyph(index int) (string, *Span, bool) {
+	if index < 0 {
+		return "", nil, false
+	}
+	for i := range rt.Spans {
+		span := &rt.Spans[i]
+		if index < len(span.Glyphs) {
+			return span.Glyphs[index], span, true
+		}
+		index -= len(span.Glyphs)
+	}
+	return "", nil, false
+}
+
+// Reveal returns how many glyphs are visible after revealing from startTick, one glyph every
+// ticksPerGlyph unless a span sets its own speed. Pauses delay the glyphs after them and line
+// breaks reveal immediately
+func (rt *RichText) Reveal(startTick, currentTick, ticksPerGlyph int) (finished bool, count int) {
+	if currentTick < startTick {
+		return false, 0
+	}
+	elapsed := currentTick - startTick
+	at := 0
+	for _, span := range rt.Spans {
+		at += span.Pause
+		speed := span.Style.Speed
+		if speed == 0 {
+			speed = max(ticksPerGlyph, 0)
+		}
+		for _, glyph := range span.Glyphs {
+			if at > elapsed {
+				return false, count
+			}
+			count++
+			if glyph != "\n" {
+				at += speed
+			}
+		}
+	}
+	return true, count
+}
+
+// Duration returns the number of ticks after which Reveal shows every glyph
+func (rt *RichText) Duration(ticksPerGlyph int) int {
+	at, last := 0, 0
+	for _, span := range rt.Spans {
+		at += span.Pause
+		speed := span.Style.Speed
+		if speed == 0 {
+			speed = max(ticksPerGlyph, 0)
+		}
+		for _, glyph := range span.Glyphs {
+			last = at
+			if glyph != "\n" {
+				at += speed
+			}
+		}
+	}
+	return last
+}
+
+// revealSound reports whether a visible glyph, or the first glyph of a word, is revealed on currentTick
+func (rt *RichText) revealSound(startTick, currentTick, ticksPerGlyph int, word bool) bool {
+	_, now := rt.Reveal(startTick, currentTick, ticksPerGlyph)
+	_, prev := rt.Reveal(startTick, currentTick-1, ticksPerGlyph)
+	for i := prev; i < now; i++ {
+		glyph, _, _ := rt.Glyph(i)
+		if strings.TrimSpace(glyph) == "" {
+			continue
+		}
+		if !word {
+			return true
+		}
+		if before, _, _ := rt.Glyph(i - 1); strings.TrimSpace(before) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WrapRichText breaks markup into lines no wider than maxWidth like WrapText, measuring only
+// visible glyphs. Icons are measured as squares of the face size. The result is still markup
+func WrapRichText(markup string, face *text.GoTextFace, maxWidth float64) string {
+	return wrapRichText(markup, func(line string) float64 {
+		icons := strings.Count(line, IconGlyph)
+		advance, _ := text.Measure(strings.ReplaceAll(line, IconGlyph, ""), face, face.Metrics().HAscent)
+		return advance + float64(icons)*face.Size
+	}, maxWidth)
+}
+
+// wrapRichText wraps the plain text, then carries the changes back to the markup:
+// whitespace the wrap dropped is removed and new line breaks go before the glyph that starts a line
+func wrapRichText(markup string, measure func(string) float64, maxWidth float64) string {
+	rt := parse(markup)
+	wrapped := wrapText(rt.Plain(rt.Glyphs), measure, maxWidth)
+
+	var (
+		result strings.Builder
+		copied int
+		w      int
+	)
+	for _, span := range rt.Spans {
+		for i, glyph := range span.Glyphs {
+			source := span.sources[i]
+			if strings.HasPrefix(wrapped[w:], glyph) {
+				w += len(glyph)
+				continue
+			}
+			if strings.TrimSpace(glyph) == "" {
+				result.WriteString(markup[copied:source[0]])
+				copied = source[1]
+				continue
+			}
+			if strings.HasPrefix(wrapped[w:], "\n") {
+				result.WriteString(markup[copied:source[0]])
+				result.WriteString("\n")
+				copied = source[0]
+				w++
+			}
+			w = min(w+len(glyph), len(wrapped))
+		}
+	}
+	result.WriteString(markup[copied:])
+	return result.String()
+}
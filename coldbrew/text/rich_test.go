@@ -0,0 +1,260 @@
+package text
+
+import (
+	"image/color"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// spanSummary is the comparable part of a span
+type spanSummary struct {
+	Text  string
+	Style Style
+	Icon  string
+	Pause int
+}
+
+func summarize(rt *RichText) []spanSummary {
+	var got []spanSummary
+	for _, span := range rt.Spans {
+		got = append(got, spanSummary{strings.Join(span.Glyphs, ""), span.Style, span.Icon, span.Pause})
+	}
+	return got
+}
+
+func TestParse(t *testing.T) {
+	red := namedColors["red"]
+	tests := []struct {
+		name   string
+		markup string
+		want   []spanSummary
+		glyphs int
+	}{
+		{
+			name:   "plain multi-byte text counts graphemes",
+			markup: "né 你好",
+			want:   []spanSummary{{Text: "né 你好"}},
+			glyphs: 5,
+		},
+		{
+			name:   "escaped brackets",
+			markup: "a [[b] [[[wave]c[/wave]",
+			want:   []spanSummary{{Text: "a [b] ["}, {Text: "c", Style: Style{Wave: true}}},
+			glyphs: 8,
+		},
+		{
+			name:   "unknown and invalid tags stay text",
+			markup: "[foo]x[/foo] [color=nope]y [speed=-1]z [pause=a][icon=]",
+			want:   []spanSummary{{Text: "[foo]x[/foo] [color=nope]y [speed=-1]z [pause=a][icon=]"}},
+			glyphs: 55,
+		},
+		{
+			name:   "unclosed tag stays text",
+			markup: "a[wave",
+			want:   []spanSummary{{Text: "a[wave"}},
+			glyphs: 6,
+		},
+		{
+			name:   "nested styles",
+			markup: "[color=red]a[wave]b[shake]c[/shake][/wave]d[/color]e",
+			want: []spanSummary{
+				{Text: "a", Style: Style{Color: red}},
+				{Text: "b", Style: Style{Color: red, Wave: true}},
+				{Text: "c", Style: Style{Color: red, Wave: true, Shake: true}},
+				{Text: "d", Style: Style{Color: red}},
+				{Text: "e"},
+			},
+			glyphs: 5,
+		},
+		{
+			name:   "nested colors and speeds restore the outer value",
+			markup: "[color=#f00][speed=2]a[color=#00ff0080][speed=5]b[/speed][/color]c[/speed][/color]",
+			want: []spanSummary{
+				{Text: "a", Style: Style{Color: color.NRGBA{R: 0xff, A: 0xff}, Speed: 2}},
+				{Text: "b", Style: Style{Color: color.NRGBA{G: 0xff, A: 0x80}, Speed: 5}},
+				{Text: "c", Style: Style{Color: color.NRGBA{R: 0xff, A: 0xff}, Speed: 2}},
+			},
+			glyphs: 3,
+		},
+		{
+			name:   "unbalanced closing tags are ignored",
+			markup: "a[/wave][/color]b",
+			want:   []spanSummary{{Text: "a"}, {Text: "b"}},
+			glyphs: 2,
+		},
+		{
+			name:   "pauses and icons",
+			markup: "a[pause=3][pause=2]b[icon=coin]",
+			want: []spanSummary{
+				{Text: "a"},
+				{Text: "b", Pause: 5},
+				{Text: IconGlyph, Icon: "coin"},
+			},
+			glyphs: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := parse(tt.markup)
+			if got := summarize(rt); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parse(%q) = %+v, want %+v", tt.markup, got, tt.want)
+			}
+			if rt.Glyphs != tt.glyphs {
+				t.Errorf("Glyphs = %d, want %d", rt.Glyphs, tt.glyphs)
+			}
+		})
+	}
+}
+
+// TestParseSources tests that every glyph maps back to the markup it came from
+func TestParseSources(t *testing.T) {
+	markup := "[wave]é[/wave][[[icon=coin]你"
+	want := []string{"é", "[[", "[icon=coin]", "你"}
+	var got []string
+	for _, span := range parse(markup).Spans {
+		for _, source := range span.sources {
+			got = append(got, markup[source[0]:source[1]])
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sources = %q, want %q", got, want)
+	}
+}
+
+func TestReveal(t *testing.T) {
+	tests := []struct {
+		name          string
+		markup        string
+		ticksPerGlyph int
+		// want is the count at each tick from the start
+		want []int
+	}{
+		{"one glyph every tick", "abc", 1, []int{1, 2, 3, 3}},
+		{"ticks per glyph", "abc", 2, []int{1, 1, 2, 2, 3}},
+		{"pause delays the glyphs after it", "a[pause=2]b", 1, []int{1, 1, 1, 2}},
+		{"span speed", "a[speed=3]bc[/speed]d", 1, []int{1, 2, 2, 2, 3, 3, 3, 4}},
+		{"line breaks reveal with the next glyph", "a\nb", 2, []int{1, 1, 3}},
+		{"zero ticks reveals everything", "abc", 0, []int{3}},
+		{"icons are one glyph", "[icon=coin]a", 1, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := parse(tt.markup)
+			for tick, want := range tt.want {
+				if _, got := rt.Reveal(10, 10+tick, tt.ticksPerGlyph); got != want {
+					t.Errorf("tick %d: count = %d, want %d", tick, got, want)
+				}
+			}
+			if finished, count := rt.Reveal(10, 9, tt.ticksPerGlyph); finished || count != 0 {
+				t.Errorf("Reveal before the start = %v, %d, want false, 0", finished, count)
+			}
+		})
+	}
+}
+
+// TestRevealDuration tests that Reveal finishes exactly at Duration
+func TestRevealDuration(t *testing.T) {
+	markups := []string{
+		"",
+		"abc",
+		"a\nb\n",
+		"[pause=4]a[speed=3]bc[/speed][pause=2]d",
+		"[speed=0]ab[/speed]c[icon=coin]",
+		"trailing pause[pause=5]",
+	}
+	for _, markup := range markups {
+		for _, ticksPerGlyph := range []int{0, 1, 3} {
+			rt := parse(markup)
+			duration := rt.Duration(ticksPerGlyph)
+			if finished, count := rt.Reveal(0, duration, ticksPerGlyph); !finished || count != rt.Glyphs {
+				t.Errorf("%q at %d ticks per glyph: Reveal(Duration %d) = %v, %d, want true, %d",
+					markup, ticksPerGlyph, duration, finished, count, rt.Glyphs)
+			}
+			if duration == 0 {
+				continue
+			}
+			if finished, count := rt.Reveal(0, duration-1, ticksPerGlyph); finished || count == rt.Glyphs {
+				t.Errorf("%q at %d ticks per glyph: Reveal(Duration-1) = %v, %d, want unfinished",
+					markup, ticksPerGlyph, finished, count)
+			}
+		}
+	}
+}
+
+func TestRevealSound(t *testing.T) {
+	tests := []struct {
+		name   string
+		markup string
+		glyph  []bool
+		word   []bool
+	}{
+		{
+			name:   "skips spaces",
+			markup: "ab c",
+			glyph:  []bool{true, true, false, true, false},
+			word:   []bool{true, false, false, true, false},
+		},
+		{
+			name:   "follows pauses",
+			markup: "a[pause=2]b",
+			glyph:  []bool{true, false, false, true},
+			word:   []bool{true, false, false, false},
+		},
+		{
+			name:   "follows speeds",
+			markup: "a [speed=2]bc",
+			glyph:  []bool{true, false, true, false, true, false},
+			word:   []bool{true, false, true, false, false, false},
+		},
+		{
+			name:   "line breaks start words",
+			markup: "a\nb",
+			glyph:  []bool{true, true, false},
+			word:   []bool{true, true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for tick := range tt.glyph {
+				if got := ShouldPlayRevealSound(5, 5+tick, 1, tt.markup); got != tt.glyph[tick] {
+					t.Errorf("tick %d: ShouldPlayRevealSound = %v, want %v", tick, got, tt.glyph[tick])
+				}
+				if got := ShouldPlayRevealSoundForWord(5, 5+tick, 1, tt.markup); got != tt.word[tick] {
+					t.Errorf("tick %d: ShouldPlayRevealSoundForWord = %v, want %v", tick, got, tt.word[tick])
+				}
+			}
+		})
+	}
+}
+
+// TestWrapRichText tests that wrapping the visible text carries line breaks back into the markup
+func TestWrapRichText(t *testing.T) {
+	tests := []struct {
+		name     string
+		markup   string
+		maxWidth float64
+		want     string
+	}{
+		{"fits", "[wave]hi[/wave] there", 10, "[wave]hi[/wave] there"},
+		{"tags are not measured", "[color=red]hello world[/color]", 5, "[color=red]hello\nworld[/color]"},
+		{"break before a tag", "hello [wave]world[/wave]", 5, "hello[wave]\nworld[/wave]"},
+		{"icons are measured", "go [icon=coin] now", 4, "go [icon=coin]\nnow"},
+		{"escaped brackets", "[[a] [[b]", 3, "[[a]\n[[b]"},
+		{"multi-byte", "[shake]你好世界再见[/shake]", 4, "[shake]你好世界\n再见[/shake]"},
+		{"keeps line breaks", "a\n[wave]b[/wave]", 10, "a\n[wave]b[/wave]"},
+		{"pauses stay in place", "one[pause=5] two", 3, "one[pause=5]\ntwo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapRichText(tt.markup, runeWidth, tt.maxWidth)
+			if got != tt.want {
+				t.Errorf("wrapRichText(%q, %v) = %q, want %q", tt.markup, tt.maxWidth, got, tt.want)
+			}
+			rt, wrapped := parse(got), parse(tt.markup)
+			if plain, want := rt.Plain(rt.Glyphs), wrapText(wrapped.Plain(wrapped.Glyphs), runeWidth, tt.maxWidth); plain != want {
+				t.Errorf("wrapped markup shows %q, want the wrapped text %q", plain, want)
+			}
+		})
+	}
+}
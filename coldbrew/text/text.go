@@ -2,124 +2,110 @@ package text
 
 import (
 	"strings"
+	"unicode"
 
+	"github.com/go-text/typesetting/segmenter"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 )
 
+// CurrentIndexInTextReveal returns how many glyphs of the markup are visible, see RichText.Reveal
+//
+// The count is in glyphs (grapheme clusters, icons count as one) rather than bytes of text,
+// so use RichText.Plain to get the visible text instead of slicing the markup
 func CurrentIndexInTextReveal(startTick, currentTick, ticksPerCharacter int, text string) (finished bool, count int) {
-	if currentTick < startTick {
-		return false, 0
-	}
-
-	if ticksPerCharacter <= 0 {
-		return true, len(text)
-	}
-
-	elapsedTicks := currentTick - startTick
-	count = (elapsedTicks / ticksPerCharacter) + 1
-
-	if count >= len(text) {
-		return true, len(text)
-	}
-
-	return false, count
+	return Parse(text).Reveal(startTick, currentTick, ticksPerCharacter)
 }
 
+// WrapText breaks text into lines no wider than maxWidth
+//
+// Break opportunities follow the Unicode line breaking algorithm, so text without spaces (CJK)
+// wraps between characters and punctuation stays attached. A segment wider than a whole line is
+// split between grapheme clusters. Existing newlines are kept and surrounding spaces are trimmed
 func WrapText(s string, face *text.GoTextFace, maxWidth float64) string {
-	var result strings.Builder
-	var currentLine strings.Builder
+	return wrapText(s, func(line string) float64 {
+		advance, _ := text.Measure(line, face, face.Metrics().HAscent)
+		return advance
+	}, maxWidth)
+}
 
-	words := strings.Fields(s)
-	if len(words) == 0 {
-		return ""
+func wrapText(s string, measure func(string) float64, maxWidth float64) string {
+	var (
+		seg    segmenter.Segmenter
+		result strings.Builder
+		line   []rune
+	)
+	fits := func(runes []rune) bool {
+		return measure(strings.TrimRightFunc(string(runes), unicode.IsSpace)) <= maxWidth
 	}
-
-	currentLine.WriteString(words[0])
-
-	for i := 1; i < len(words); i++ {
-		word := words[i]
-		potentialLine := currentLine.String() + " " + word
-
-		advance, _ := text.Measure(potentialLine, face, face.Metrics().HAscent)
-
-		if advance > maxWidth {
-			result.WriteString(currentLine.String())
+	endLine := func() {
+		if result.Len() > 0 {
 			result.WriteString("\n")
-			currentLine.Reset()
-			currentLine.WriteString(word)
-		} else {
-			currentLine.WriteString(" ")
-			currentLine.WriteString(word)
 		}
+		result.WriteString(strings.TrimRightFunc(string(line), unicode.IsSpace))
+		line = line[:0]
 	}
 
-	result.WriteString(currentLine.String())
+	seg.Init([]rune(strings.TrimSpace(s)))
+	lines := seg.LineIterator()
+	for lines.Next() {
+		segment := lines.Line()
+		if len(line) > 0 && !fits(append(line, segment.Text...)) {
+			endLine()
+		}
+		if len(line) == 0 {
+			segment.Text = []rune(strings.TrimLeftFunc(string(segment.Text), unicode.IsSpace))
+		}
+		if len(line) == 0 && !fits(segment.Text) {
+			full, rest := splitGraphemes(segment.Text, fits)
+			for _, l := range full {
+				line = l
+				endLine()
+			}
+			line = rest
+		} else {
+			line = append(line, segment.Text...)
+		}
+		if segment.IsMandatoryBreak {
+			endLine()
+		}
+	}
+	if len(line) > 0 || result.Len() == 0 {
+		endLine()
+	}
 	return result.String()
 }
 
-func ShouldPlayRevealSound(startTick, currentTick, ticksPerCharacter int, text string) bool {
-	if currentTick < startTick || ticksPerCharacter <= 0 {
-		return false
-	}
-
-	elapsedTicksNow := currentTick - startTick
-	countNow := (elapsedTicksNow / ticksPerCharacter)
-
-	elapsedTicksPrev := (currentTick - 1) - startTick
-	if elapsedTicksPrev < 0 {
-		return true
+// splitGraphemes cuts a segment too wide for a line between grapheme clusters,
+// returning the full lines and the remainder
+func splitGraphemes(runes []rune, fits func([]rune) bool) (full [][]rune, rest []rune) {
+	var seg segmenter.Segmenter
+	seg.Init(runes)
+	graphemes := seg.GraphemeIterator()
+	for graphemes.Next() {
+		grapheme := graphemes.Grapheme().Text
+		if len(rest) > 0 && !fits(append(rest[:len(rest):len(rest)], grapheme...)) {
+			full = append(full, rest)
+			rest = nil
+		}
+		rest = append(rest, grapheme...)
 	}
-	countPrev := (elapsedTicksPrev / ticksPerCharacter)
+	return full, rest
+}
 
-	return countNow > countPrev && countNow < len(text)
+// ShouldPlayRevealSound reports whether a visible glyph of the markup is revealed on currentTick,
+// following the markup's pauses and speeds like RichText.Reveal
+func ShouldPlayRevealSound(startTick, currentTick, ticksPerCharacter int, text string) bool {
+	return Parse(text).revealSound(startTick, currentTick, ticksPerCharacter, false)
 }
 
+// ShouldPlayRevealSoundWord is ShouldPlayRevealSound
+//
+// Deprecated: use ShouldPlayRevealSound, or ShouldPlayRevealSoundForWord to sound once per word
 func ShouldPlayRevealSoundWord(startTick, currentTick, ticksPerCharacter int, text string) bool {
-	if currentTick < startTick || ticksPerCharacter <= 0 {
-		return false
-	}
-
-	elapsedTicksNow := currentTick - startTick
-	countNow := (elapsedTicksNow / ticksPerCharacter)
-
-	elapsedTicksPrev := (currentTick - 1) - startTick
-	if elapsedTicksPrev < 0 {
-		return true
-	}
-	countPrev := (elapsedTicksPrev / ticksPerCharacter)
-
-	return countNow > countPrev && countNow < len(text)
+	return ShouldPlayRevealSound(startTick, currentTick, ticksPerCharacter, text)
 }
 
+// ShouldPlayRevealSoundForWord reports whether the first glyph of a word is revealed on currentTick
 func ShouldPlayRevealSoundForWord(startTick, currentTick, ticksPerCharacter int, text string) bool {
-	if currentTick < startTick || ticksPerCharacter <= 0 || len(text) == 0 {
-		return false
-	}
-
-	elapsedTicksNow := currentTick - startTick
-	countNow := (elapsedTicksNow / ticksPerCharacter)
-
-	elapsedTicksPrev := (currentTick - 1) - startTick
-	countPrev := 0
-	if elapsedTicksPrev >= 0 {
-		countPrev = (elapsedTicksPrev / ticksPerCharacter)
-	}
-
-	if countNow > countPrev {
-		charIndex := countPrev
-
-		if charIndex >= len(text) {
-			return false
-		}
-
-		if charIndex == 0 {
-			return true
-		}
-
-		if text[charIndex-1] == ' ' {
-			return true
-		}
-	}
-
-	return false
+	return Parse(text).revealSound(startTick, currentTick, ticksPerCharacter, true)
 }
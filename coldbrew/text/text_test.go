@@ -0,0 +1,44 @@
+package text
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// runeWidth measures text as one unit per rune
+func runeWidth(s string) float64 {
+	return float64(utf8.RuneCountInString(s))
+}
+
+func TestWrapText(t *testing.T) {
+	// a followed by a combining acute accent, two runes in one grapheme
+	accented := "a\u0301"
+	tests := []struct {
+		name     string
+		text     string
+		maxWidth float64
+		want     string
+	}{
+		{"fits", "hello world", 11, "hello world"},
+		{"breaks at spaces", "hello world", 5, "hello\nworld"},
+		{"fills lines greedily", "a bb ccc dddd", 6, "a bb\nccc\ndddd"},
+		{"trims surrounding spaces", "  hi  ", 10, "hi"},
+		{"empty", "", 10, ""},
+		{"keeps mandatory breaks", "one two\nthree", 5, "one\ntwo\nthree"},
+		{"keeps blank lines", "a\n\nb", 10, "a\n\nb"},
+		{"wraps CJK between characters", "你好世界再见", 4, "你好世界\n再见"},
+		{"keeps CJK punctuation attached", "你好，世界", 2, "你\n好，\n世界"},
+		{"splits over-long words", "abcdefghij", 4, "abcd\nefgh\nij"},
+		{"splits over-long words after a break", "see abcdefghij", 4, "see\nabcd\nefgh\nij"},
+		{"never splits a grapheme", strings.Repeat(accented, 3), 3, accented + "\n" + accented + "\n" + accented},
+		{"graphemes wider than a line", "ab", 0.5, "a\nb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, runeWidth, tt.maxWidth); got != tt.want {
+				t.Errorf("wrapText(%q, %v) = %q, want %q", tt.text, tt.maxWidth, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package combat
 
+import "github.com/TheBitDrifter/bappa/blueprint/input"
+
 type AttackSequence struct {
 	Attacks []Attack
 }
@@ -24,3 +26,22 @@ func (aSeq AttackSequence) Next(current Attack) (Attack, bool) {
 	}
 	return Attack{}, false
 }
+
+// ComboAttacks maps the synthetic actions emitted by input combos to the attack sequences they start
+type ComboAttacks map[input.Action]*AttackSequence
+
+// Consume removes the most recent combo action from the buffer and returns the sequence it starts
+func (ca ComboAttacks) Consume(buffer *input.ActionBuffer) (*AttackSequence, input.StampedAction, bool) {
+	var latest input.StampedAction
+	found := false
+	for _, stamped := range buffer.Values {
+		if _, ok := ca[stamped.Val]; ok && (!found || stamped.Tick > latest.Tick) {
+			latest, found = stamped, true
+		}
+	}
+	if !found {
+		return nil, input.StampedAction{}, false
+	}
+	buffer.ConsumeAction(latest.Val)
+	return ca[latest.Val], latest, true
+}
@@ -0,0 +1,95 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+)
+
+func TestComboAttacksConsume(t *testing.T) {
+	forward, punch, kick := input.NewAction(), input.NewAction(), input.NewAction()
+	jab, spinKick := input.NewAction(), input.NewAction()
+
+	jabSeq := NewAttackSeq(Attack{Name: "jab", ID: 1})
+	spinSeq := NewAttackSeq(Attack{Name: "spin kick", ID: 2})
+	attacks := ComboAttacks{jab: jabSeq, spinKick: spinSeq}
+	combos := []input.Combo{
+		input.NewCombo(jab, input.Step(forward, 0), input.Step(punch, 5)),
+		input.NewCombo(spinKick, input.Step(forward, 0), input.Step(kick, 10)),
+	}
+
+	tests := []struct {
+		name   string
+		inputs []input.StampedAction
+		want   *AttackSequence
+		tick   int
+	}{
+		{
+			name:   "combo within its window",
+			inputs: []input.StampedAction{{Tick: 0, Val: forward}, {Tick: 5, Val: punch}},
+			want:   jabSeq,
+			tick:   5,
+		},
+		{
+			name:   "combo outside its window",
+			inputs: []input.StampedAction{{Tick: 0, Val: forward}, {Tick: 6, Val: punch}},
+		},
+		{
+			name: "latest of overlapping combos",
+			inputs: []input.StampedAction{
+				{Tick: 0, Val: forward}, {Tick: 1, Val: forward},
+				{Tick: 4, Val: kick}, {Tick: 5, Val: punch},
+			},
+			want: jabSeq,
+			tick: 5,
+		},
+		{
+			name: "latest of overlapping combos fed out of order",
+			inputs: []input.StampedAction{
+				{Tick: 0, Val: forward}, {Tick: 1, Val: forward},
+				{Tick: 5, Val: kick}, {Tick: 4, Val: punch},
+			},
+			want: spinSeq,
+			tick: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := input.ComboBuffer{Combos: combos}
+			buffer := input.ActionBuffer{}
+			for _, stamped := range tt.inputs {
+				buffer.Add(stamped)
+				buffer.AddBatch(cb.Feed([]input.StampedAction{stamped}))
+			}
+
+			seq, stamped, ok := attacks.Consume(&buffer)
+			if ok != (tt.want != nil) || seq != tt.want {
+				t.Fatalf("Consume = %v, %v, want %v", seq, ok, tt.want)
+			}
+			if !ok {
+				return
+			}
+			if stamped.Tick != tt.tick {
+				t.Errorf("Consumed action at tick %d, want %d", stamped.Tick, tt.tick)
+			}
+			for _, left := range buffer.Values {
+				if left.Val == stamped.Val {
+					t.Errorf("Consume left %v in the buffer", left)
+				}
+			}
+		})
+	}
+}
+
+func TestComboAttacksConsumeIgnoresOtherActions(t *testing.T) {
+	punch, jab := input.NewAction(), input.NewAction()
+	attacks := ComboAttacks{jab: NewAttackSeq(Attack{Name: "jab", ID: 1})}
+
+	buffer := input.ActionBuffer{Values: []input.StampedAction{{Tick: 3, Val: punch}}}
+	if _, _, ok := attacks.Consume(&buffer); ok {
+		t.Error("Consume matched an action without an attack sequence")
+	}
+	if len(buffer.Values) != 1 {
+		t.Errorf("Consume changed the buffer: %+v", buffer.Values)
+	}
+}
@@ -55,8 +55,18 @@ func (ActionBufferSystem) Run(s drip.Server) error {
 			}
 
 			// Add the received actions to the entity's standard ActionBuffer.
-			actionBuffer.AddBatch(item.Actions)
+			bufferActions(targetEntity, actionBuffer, item.Actions)
 		}
 	}
 	return nil
 }
+
+// bufferActions adds received actions to the buffer, feeding the entity's ComboBuffer first when it has one
+// Clients send their raw actions, so combos are matched here like the client's InputBufferSystem does locally
+func bufferActions(entity warehouse.Entity, actionBuffer *input.ActionBuffer, actions []input.StampedAction) {
+	if entity.Table().Contains(input.Components.ComboBuffer) {
+		combos := input.Components.ComboBuffer.GetFromEntity(entity)
+		actions = append(actions, combos.Feed(actions)...)
+	}
+	actionBuffer.AddBatch(actions)
+}
@@ -0,0 +1,96 @@
+package drip_seversystems
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/table"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// TestBufferActionsFeedsCombos tests that combos complete from actions received in client messages
+func TestBufferActionsFeedsCombos(t *testing.T) {
+	forward, punch, jab := input.NewAction(), input.NewAction(), input.NewAction()
+	combo := input.NewCombo(jab, input.Step(forward, 0), input.Step(punch, 5))
+
+	tests := []struct {
+		name     string
+		messages []input.ClientActionMessage
+		want     bool
+	}{
+		{
+			name: "sequence across messages",
+			messages: []input.ClientActionMessage{
+				{Actions: []input.StampedAction{{Tick: 0, Val: forward}}},
+				{Actions: []input.StampedAction{{Tick: 5, Val: punch}}},
+			},
+			want: true,
+		},
+		{
+			name: "sequence in one message",
+			messages: []input.ClientActionMessage{
+				{Actions: []input.StampedAction{{Tick: 0, Val: forward}, {Tick: 3, Val: punch}}},
+			},
+			want: true,
+		},
+		{
+			name: "sequence outside its window",
+			messages: []input.ClientActionMessage{
+				{Actions: []input.StampedAction{{Tick: 0, Val: forward}}},
+				{Actions: []input.StampedAction{{Tick: 6, Val: punch}}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+			entities, err := storage.NewEntities(1, input.Components.ActionBuffer, input.Components.ComboBuffer)
+			if err != nil {
+				t.Fatalf("Failed to create entity: %v", err)
+			}
+			en := entities[0]
+			input.Components.ComboBuffer.GetFromEntity(en).Combos = []input.Combo{combo}
+			buffer := input.Components.ActionBuffer.GetFromEntity(en)
+
+			for _, msg := range tt.messages {
+				// Decoded the way the server decodes client messages
+				data, err := json.Marshal(msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var received input.ClientActionMessage
+				if err := json.Unmarshal(data, &received); err != nil {
+					t.Fatal(err)
+				}
+				bufferActions(en, buffer, received.Actions)
+			}
+
+			found := false
+			for _, stamped := range buffer.Values {
+				if stamped.Val == jab {
+					found = true
+				}
+			}
+			if found != tt.want {
+				t.Errorf("Combo output buffered = %v, want %v: %+v", found, tt.want, buffer.Values)
+			}
+		})
+	}
+}
+
+// TestBufferActionsWithoutCombos tests that entities without a ComboBuffer buffer actions unchanged
+func TestBufferActionsWithoutCombos(t *testing.T) {
+	storage := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(1, input.Components.ActionBuffer)
+	if err != nil {
+		t.Fatalf("Failed to create entity: %v", err)
+	}
+	buffer := input.Components.ActionBuffer.GetFromEntity(entities[0])
+	action := input.NewAction()
+
+	bufferActions(entities[0], buffer, []input.StampedAction{{Tick: 2, Val: action}})
+	if len(buffer.Values) != 1 || buffer.Values[0].Val != action {
+		t.Errorf("Buffered %+v, want the received action", buffer.Values)
+	}
+}
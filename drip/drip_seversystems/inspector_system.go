@@ -0,0 +1,27 @@
+package drip_seversystems
+
+import (
+	"github.com/TheBitDrifter/bappa/drip"
+	"github.com/TheBitDrifter/bappa/warehouse"
+	"github.com/TheBitDrifter/bappa/warehouse/inspector"
+)
+
+// InspectorSystem exposes the active scene storages through a warehouse inspector
+// and services its pending requests once per tick, while the ECS lock is held
+type InspectorSystem struct {
+	Inspector *inspector.Server
+}
+
+func (sys InspectorSystem) Run(s drip.Server) error {
+	if sys.Inspector == nil {
+		return nil
+	}
+	storages := make(map[string]warehouse.Storage)
+	for _, scene := range s.ActiveScenes() {
+		storages[scene.Name()] = scene.Storage()
+	}
+	// Scenes deactivated or unloaded since the last tick are dropped
+	sys.Inspector.RegisterActive(storages)
+	sys.Inspector.Process()
+	return nil
+}
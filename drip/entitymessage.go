@@ -1,12 +1,29 @@
 package drip
 
-import "github.com/TheBitDrifter/bappa/table"
+import (
+	"github.com/TheBitDrifter/bappa/blueprint/input"
+	"github.com/TheBitDrifter/bappa/table"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
 
 // AssignEntityIDMessage informs a client of its server-side entity ID.
 type AssignEntityIDMessage struct {
 	Type     string        `json:"type"`
 	EntityID table.EntryID `json:"entity_id"`
+	// Actions lists the server's registered actions so the client can detect a mismatched registry.
+	Actions []input.ActionInfo `json:"actions"`
 }
 
 // AssignEntityIDMessageType identifies the AssignEntityIDMessage type.
 const AssignEntityIDMessageType = "assign_entity_id"
+
+// EventsMessage forwards the serializable storage events emitted during a server tick.
+// Scene is the name of the server scene that emitted the events.
+type EventsMessage struct {
+	Type   string                      `json:"type"`
+	Scene  string                      `json:"scene,omitempty"`
+	Events []warehouse.SerializedEvent `json:"events"`
+}
+
+// EventsMessageType identifies the EventsMessage type.
+const EventsMessageType = "events"
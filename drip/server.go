@@ -387,6 +387,7 @@ DrainCreationChannel:
 			assignMsg := AssignEntityIDMessage{
 				Type:     AssignEntityIDMessageType,
 				EntityID: createdEntity.ID(),
+				Actions:  input.RegisteredActions(),
 			}
 			jsonData, err := json.Marshal(assignMsg)
 			if err != nil {
@@ -460,8 +461,34 @@ DrainDeletionChannel:
 		}
 		scene.IncrementTick()
 	}
+
+	// Collect the tick's forwardable events before the buffers swap
+	var (
+		events      []warehouse.SerializedEvent
+		eventsScene string
+	)
+	if len(activeScenes) > 0 {
+		eventsScene = activeScenes[0].Name()
+		var eventsErr error
+		events, eventsErr = activeScenes[0].Storage().Events().SerializePending()
+		if eventsErr != nil {
+			log.Printf("[Update] Error serializing events: %v", eventsErr)
+		}
+	}
+	for _, scene := range activeScenes {
+		scene.Storage().Events().Update()
+	}
 	s.ecsMutex.Unlock()
 
+	if len(events) > 0 {
+		eventsData, err := json.Marshal(EventsMessage{Type: EventsMessageType, Scene: eventsScene, Events: events})
+		if err != nil {
+			log.Printf("[Update] Error marshalling events: %v", err)
+		} else if err := s.Broadcast(eventsData); err != nil {
+			log.Printf("[Update] Error occurred during events broadcast: %v", err)
+		}
+	}
+
 	// Serialize State
 	var state []byte
 	var stateErr error
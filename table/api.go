@@ -33,6 +33,11 @@ type EntryIndex interface {
 	Gen() int
 
 	IncGen()
+
+	SetAllocationMode(AllocationMode)
+	AllocationMode() AllocationMode
+	ReserveRange(IDRange) error
+	NewEntriesInRange(count, previousTableLength int, tbl Table, r IDRange) ([]Entry, error)
 }
 
 type Schema interface {
@@ -76,6 +81,7 @@ type TableWriter interface {
 	TransferEntries(Table, ...int) error
 	Clear() error
 	ForceNewEntry(id, recycled int) error
+	SetIDRange(IDRange)
 }
 
 type TableQuerier interface {
@@ -2,9 +2,11 @@ package table
 
 import (
 	"reflect"
+	"sync/atomic"
 )
 
-var nextElementTypeID ElementTypeID = 1
+// lastElementTypeID holds the most recently assigned element type ID, IDs start at 1
+var lastElementTypeID atomic.Uint32
 
 var _ ElementType = elementType{}
 
@@ -17,10 +19,9 @@ func newElementType[T any]() elementType {
 	var t T
 	typ := reflect.TypeOf(t)
 	elementType := elementType{
-		id:  nextElementTypeID,
+		id:  ElementTypeID(lastElementTypeID.Add(1)),
 		typ: typ,
 	}
-	nextElementTypeID++
 	return elementType
 }
 
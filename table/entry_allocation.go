@@ -0,0 +1,100 @@
+package table
+
+import "sort"
+
+// AllocationMode controls the order in which an EntryIndex hands out recycled IDs
+type AllocationMode int
+
+const (
+	// AllocateFIFO reuses recycled IDs in the order they were recycled
+	AllocateFIFO AllocationMode = iota
+	// AllocateLowestFirst always hands out the lowest free ID, independent of recycling order
+	AllocateLowestFirst
+)
+
+// IDRange is an inclusive range of entry IDs
+// The zero value is no range
+type IDRange struct {
+	Min, Max EntryID
+}
+
+// IsZero reports whether the range is unset
+func (r IDRange) IsZero() bool {
+	return r.Min == 0 && r.Max == 0
+}
+
+// Contains reports whether the ID lies within the range
+func (r IDRange) Contains(id EntryID) bool {
+	return id >= r.Min && id <= r.Max
+}
+
+// Overlaps reports whether two ranges share any ID
+func (r IDRange) Overlaps(other IDRange) bool {
+	return r.Min <= other.Max && other.Min <= r.Max
+}
+
+// idPool tracks the IDs of a reserved range
+type idPool struct {
+	rng  IDRange
+	next uint64 // Next never issued ID, wider than EntryID so it can pass rng.Max
+	free []EntryID
+}
+
+func newIDPool(rng IDRange) *idPool {
+	return &idPool{rng: rng, next: uint64(rng.Min)}
+}
+
+// take removes and returns the next ID according to the mode, preferring freed IDs over fresh ones
+// Returns whether the ID was freed before, and false for ok when the pool is exhausted
+func (p *idPool) take(mode AllocationMode) (id EntryID, reused, ok bool) {
+	if len(p.free) > 0 {
+		if mode == AllocateLowestFirst {
+			sortEntryIDs(p.free)
+		}
+		id = p.free[0]
+		p.free = p.free[1:]
+		return id, true, true
+	}
+	if p.next > uint64(p.rng.Max) {
+		return 0, false, false
+	}
+	id = EntryID(p.next)
+	p.next++
+	return id, false, true
+}
+
+// available returns how many IDs the pool can still hand out
+func (p *idPool) available() int {
+	fresh := 0
+	if p.next <= uint64(p.rng.Max) {
+		fresh = int(uint64(p.rng.Max) - p.next + 1)
+	}
+	return len(p.free) + fresh
+}
+
+// claim marks a specific ID of the pool as used
+// Returns the never issued IDs it skipped over, which become free
+func (p *idPool) claim(id EntryID) (skipped []EntryID) {
+	p.free = removeEntryID(p.free, id)
+	for p.next <= uint64(id) {
+		if p.next != uint64(id) {
+			p.free = append(p.free, EntryID(p.next))
+			skipped = append(skipped, EntryID(p.next))
+		}
+		p.next++
+	}
+	return skipped
+}
+
+func sortEntryIDs(ids []EntryID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+func removeEntryID(ids []EntryID, id EntryID) []EntryID {
+	for i, candidate := range ids {
+		if candidate == id {
+			return append(ids[:i], ids[i+1:]...)
+		}
+	}
+	return ids
+}
@@ -2,6 +2,7 @@ package table
 
 import (
 	"fmt"
+	"sort"
 
 	numbers_util "github.com/TheBitDrifter/util/numbers"
 )
@@ -13,12 +14,17 @@ type entryIndex struct {
 	entries     []entry
 	recyclable  []entry
 	gen         int
+	mode        AllocationMode
+	reserved    []*idPool
 }
 
 func (ei *entryIndex) NewEntries(n, start int, tbl Table) ([]Entry, error) {
 	if n <= 0 {
 		return nil, BatchOperationError{Count: n}
 	}
+	if ei.mode == AllocateLowestFirst {
+		sort.Slice(ei.recyclable, func(i, j int) bool { return ei.recyclable[i].id < ei.recyclable[j].id })
+	}
 	amountRecyclable := min(len(ei.recyclable), n)
 	newEntries := []Entry{}
 
@@ -46,14 +52,14 @@ func (ei *entryIndex) NewEntries(n, start int, tbl Table) ([]Entry, error) {
 	// Create new entries for the remaining
 	leftover := n - amountRecyclable
 	for i := 0; i < leftover; i++ {
-		ei.currEntryID++
+		ei.currEntryID = ei.nextUnreservedID(ei.currEntryID + 1)
 		entry := entry{
 			id:       ei.currEntryID,
 			recycled: 0,
 			table:    tbl,
 			index:    start + i + amountRecyclable,
 		}
-		ei.entries = append(ei.entries, entry)
+		ei.place(entry)
 		newEntries = append(newEntries, entry)
 	}
 
@@ -110,6 +116,11 @@ func (ei *entryIndex) RecycleEntries(ids ...EntryID) error {
 			recycled: ei.entries[index].Recycled(),
 			index:    0,
 		}
+		if pool := ei.poolFor(entryID); pool != nil {
+			pool.free = append(pool.free, entryID)
+			ei.entries[index] = zeroEntry
+			continue
+		}
 		recycledEntry := entry{
 			id:       entryID,
 			recycled: ei.entries[index].Recycled(),
@@ -128,6 +139,9 @@ func (ei *entryIndex) Reset() error {
 	ei.entries = ei.entries[:0]
 	ei.recyclable = ei.recyclable[:0]
 	ei.currEntryID = 0
+	for i, pool := range ei.reserved {
+		ei.reserved[i] = newIDPool(pool.rng)
+	}
 	ei.IncGen()
 	return nil
 }
@@ -149,6 +163,8 @@ func (ei *entryIndex) Recyclable() []Entry {
 }
 
 // Use with caution, primarily for deser
+// The ID is claimed: it leaves the recyclable IDs, the highest issued ID moves up to it and
+// the IDs it skips become recyclable, so later allocations match the side that issued the ID
 func (ei *entryIndex) ForceNewEntry(id int, recycledValue, tblIndex int, tbl Table) error {
 	entryIDToForce := EntryID(id)
 	if entryIDToForce == 0 {
@@ -168,11 +184,6 @@ func (ei *entryIndex) ForceNewEntry(id int, recycledValue, tblIndex int, tbl Tab
 		// Replace the old slice
 		ei.entries = newEntriesSlice
 
-		// Update highest ID tracker if needed
-		if entryIDToForce > ei.currEntryID {
-			ei.currEntryID = entryIDToForce
-		}
-
 	} else {
 		// Slot already exists. Check if it's currently occupied by a *different* entry.
 		existingEntry := ei.entries[index]
@@ -183,6 +194,7 @@ func (ei *entryIndex) ForceNewEntry(id int, recycledValue, tblIndex int, tbl Tab
 		}
 	}
 
+	ei.claim(entryIDToForce)
 	ei.entries[index] = entry{
 		id:       entryIDToForce,
 		table:    tbl,
@@ -194,6 +206,125 @@ func (ei *entryIndex) ForceNewEntry(id int, recycledValue, tblIndex int, tbl Tab
 	return nil
 }
 
+// neverIssued is the recycled count of free IDs that were skipped by ForceNewEntry rather than recycled,
+// so their first allocation has a recycled count of 0 like on the side that issued them fresh
+const neverIssued = -1
+
+// claim takes a forced ID out of circulation so it is never handed out twice
+// IDs skipped over by the force become free, keeping allocation gap free and mirrorable
+func (ei *entryIndex) claim(id EntryID) {
+	if pool := ei.poolFor(id); pool != nil {
+		for _, skipped := range pool.claim(id) {
+			ei.entries[skipped-1].recycled = neverIssued
+		}
+		return
+	}
+	for i, recyclable := range ei.recyclable {
+		if recyclable.id == id {
+			ei.recyclable = append(ei.recyclable[:i], ei.recyclable[i+1:]...)
+			return
+		}
+	}
+	for next := ei.nextUnreservedID(ei.currEntryID + 1); next < id; next = ei.nextUnreservedID(next + 1) {
+		ei.recyclable = append(ei.recyclable, entry{id: next, recycled: neverIssued})
+	}
+	ei.currEntryID = max(ei.currEntryID, id)
+}
+
+// place stores the entry at its ID's slot, growing the entries as needed
+func (ei *entryIndex) place(e entry) {
+	for int(e.id) > len(ei.entries) {
+		ei.entries = append(ei.entries, entry{})
+	}
+	ei.entries[e.id-1] = e
+}
+
+// poolFor returns the reserved pool containing the ID, if any
+func (ei *entryIndex) poolFor(id EntryID) *idPool {
+	for _, pool := range ei.reserved {
+		if pool.rng.Contains(id) {
+			return pool
+		}
+	}
+	return nil
+}
+
+// nextUnreservedID returns the first ID at or after id that lies outside every reserved range
+func (ei *entryIndex) nextUnreservedID(id EntryID) EntryID {
+	for pool := ei.poolFor(id); pool != nil; pool = ei.poolFor(id) {
+		id = pool.rng.Max + 1
+	}
+	return id
+}
+
+// SetAllocationMode sets the order in which recycled IDs are reused
+func (ei *entryIndex) SetAllocationMode(mode AllocationMode) {
+	ei.mode = mode
+}
+
+// AllocationMode returns the order in which recycled IDs are reused
+func (ei *entryIndex) AllocationMode() AllocationMode {
+	return ei.mode
+}
+
+// ReserveRange sets a range of IDs aside for NewEntriesInRange
+// Regular allocation skips reserved IDs. The range must not overlap another
+// reservation or any ID regular allocation has already handed out
+func (ei *entryIndex) ReserveRange(r IDRange) error {
+	if r.Min == 0 || r.Max < r.Min {
+		return fmt.Errorf("invalid ID range [%d, %d]", r.Min, r.Max)
+	}
+	if r.Min <= ei.currEntryID {
+		return fmt.Errorf("cannot reserve ID range [%d, %d]: IDs up to %d are already allocated", r.Min, r.Max, ei.currEntryID)
+	}
+	for _, pool := range ei.reserved {
+		if pool.rng.Overlaps(r) {
+			return fmt.Errorf("ID range [%d, %d] overlaps reserved range [%d, %d]", r.Min, r.Max, pool.rng.Min, pool.rng.Max)
+		}
+	}
+	ei.reserved = append(ei.reserved, newIDPool(r))
+	return nil
+}
+
+// NewEntriesInRange creates entries whose IDs come from a previously reserved range
+func (ei *entryIndex) NewEntriesInRange(n, start int, tbl Table, r IDRange) ([]Entry, error) {
+	if n <= 0 {
+		return nil, BatchOperationError{Count: n}
+	}
+	var pool *idPool
+	for _, candidate := range ei.reserved {
+		if candidate.rng == r {
+			pool = candidate
+		}
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("ID range [%d, %d] is not reserved", r.Min, r.Max)
+	}
+	if pool.available() < n {
+		return nil, fmt.Errorf("ID range [%d, %d] exhausted: %d requested, %d available", r.Min, r.Max, n, pool.available())
+	}
+
+	newEntries := make([]Entry, 0, n)
+	for i := 0; i < n; i++ {
+		id, reused, _ := pool.take(ei.mode)
+		recycled := 0
+		if reused {
+			recycled = ei.entries[id-1].recycled + 1
+		}
+		e := entry{
+			id:       id,
+			recycled: recycled,
+			table:    tbl,
+			index:    start + i,
+		}
+		ei.place(e)
+		newEntries = append(newEntries, e)
+	}
+
+	ei.IncGen()
+	return newEntries, nil
+}
+
 func (ei *entryIndex) Preallocate(capacity int) {
 	if capacity > 0 && ei.entries == nil {
 		ei.entries = make([]entry, 0, capacity)
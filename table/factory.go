@@ -2,14 +2,31 @@ package table
 
 import (
 	"reflect"
+	"sync"
 )
 
 var (
-	buildTags                      = []string{}
-	Factory           TableFactory = initTableFactory()
-	entryIndexTracker              = map[Table]EntryIndex{}
+	buildTags              = []string{}
+	Factory   TableFactory = initTableFactory()
+
+	entryIndexTrackerMu sync.RWMutex
+	entryIndexTracker   = map[Table]EntryIndex{}
 )
 
+// trackEntryIndex records which entry index a table was built with
+func trackEntryIndex(tbl Table, entryIndex EntryIndex) {
+	entryIndexTrackerMu.Lock()
+	defer entryIndexTrackerMu.Unlock()
+	entryIndexTracker[tbl] = entryIndex
+}
+
+// sharesEntryIndex reports whether two tables were built with the same entry index
+func sharesEntryIndex(a, b Table) bool {
+	entryIndexTrackerMu.RLock()
+	defer entryIndexTrackerMu.RUnlock()
+	return entryIndexTracker[a] == entryIndexTracker[b]
+}
+
 func initTableFactory() TableFactory {
 	var tableB iTableFactory = safeTableFactory{}
 	if Config.Unsafe() {
@@ -60,7 +77,7 @@ func (factory safeTableFactory) NewTable(schema Schema, entryIndex EntryIndex, i
 	if err != nil {
 		return nil, err
 	}
-	entryIndexTracker[tbl] = entryIndex
+	trackEntryIndex(tbl, entryIndex)
 	return tbl, nil
 }
 
@@ -69,7 +86,7 @@ func (factory unsafeTableFactory) NewTable(schema Schema, entryIndex EntryIndex,
 	if err != nil {
 		return nil, err
 	}
-	entryIndexTracker[tbl] = entryIndex
+	trackEntryIndex(tbl, entryIndex)
 	return tbl, err
 }
 
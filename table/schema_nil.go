@@ -11,7 +11,7 @@ func newNilSchema() *nilSchema {
 func (s *nilSchema) Register(elementTypes ...ElementType) {}
 
 func (s *nilSchema) Registered() int {
-	return int(nextElementTypeID) - 1
+	return int(lastElementTypeID.Load())
 }
 
 func (s *nilSchema) RowIndexFor(elementType ElementType) uint32 {
@@ -5,5 +5,5 @@ var Stats stats = stats{}
 type stats struct{}
 
 func (s stats) TotalElementTypes() int {
-	return int(nextElementTypeID) - 1
+	return int(lastElementTypeID.Load())
 }
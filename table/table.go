@@ -28,6 +28,7 @@ type quickTable struct {
 	len          int
 	cap          int
 	events       TableEvents
+	idRange      IDRange
 }
 
 func newTable(
@@ -102,7 +103,13 @@ func (tbl *quickTable) NewEntries(n int) ([]Entry, error) {
 		return nil, err
 	}
 
-	entries, entryIndexError := tbl.entryIndex.NewEntries(n, prevTableLength, tbl)
+	var entries []Entry
+	var entryIndexError error
+	if tbl.idRange.IsZero() {
+		entries, entryIndexError = tbl.entryIndex.NewEntries(n, prevTableLength, tbl)
+	} else {
+		entries, entryIndexError = tbl.entryIndex.NewEntriesInRange(n, prevTableLength, tbl, tbl.idRange)
+	}
 	if tbl.hasEvents() {
 		defer tbl.events.OnAfterEntriesCreated(entries)
 	}
@@ -118,6 +125,12 @@ func (tbl *quickTable) NewEntries(n int) ([]Entry, error) {
 	return entries, nil
 }
 
+// SetIDRange makes NewEntries draw IDs from a range reserved on the table's entry index
+// The zero IDRange restores regular allocation
+func (tbl *quickTable) SetIDRange(r IDRange) {
+	tbl.idRange = r
+}
+
 func (tbl *quickTable) ForceNewEntry(id, recycled int) error {
 	defer tbl.rowCache.cacheRows(tbl)
 
@@ -178,7 +191,7 @@ func (tbl *quickTable) TransferEntries(other Table, indexes ...int) error {
 	}
 
 	// Tables must share the same entry index
-	if entryIndexTracker[tbl] != entryIndexTracker[other] {
+	if !sharesEntryIndex(tbl, other) {
 		return TransferEntryIndexMismatchError{}
 	}
 
@@ -256,3 +256,209 @@ func TestEntryIndex_Recyclable(t *testing.T) {
 		t.Errorf("%s failed, expected 0 recyclable entries after reuse, got %d", funcName, len(ei.Recyclable()))
 	}
 }
+
+func TestEntryIndex_AllocationMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        table.AllocationMode
+		expectedIDs []table.EntryID
+	}{
+		{
+			name:        "fifo reuses in recycle order",
+			mode:        table.AllocateFIFO,
+			expectedIDs: []table.EntryID{4, 2, 3},
+		},
+		{
+			name:        "lowest first ignores recycle order",
+			mode:        table.AllocateLowestFirst,
+			expectedIDs: []table.EntryID{2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ei := f.NewEntryIndex()
+			ei.SetAllocationMode(tt.mode)
+			tbl, err := f.NewTable(f.NewSchema(), ei, 0, blankElementType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ei.NewEntries(5, tbl.Length(), tbl)
+			ei.RecycleEntries(4)
+			ei.RecycleEntries(2, 3)
+
+			newEntries, err := ei.NewEntries(3, tbl.Length(), tbl)
+			testing_util.CheckError(t, ei.NewEntries, err)
+			for i, entry := range newEntries {
+				if entry.ID() != tt.expectedIDs[i] {
+					t.Errorf("Entry %d got ID %d, expected %d", i, entry.ID(), tt.expectedIDs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestEntryIndex_ReserveRange(t *testing.T) {
+	ei := f.NewEntryIndex()
+	tbl, err := f.NewTable(f.NewSchema(), ei, 0, blankElementType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ei.NewEntries(2, tbl.Length(), tbl)
+
+	reserved := table.IDRange{Min: 3, Max: 5}
+	if err := ei.ReserveRange(table.IDRange{Min: 2, Max: 4}); err == nil {
+		t.Errorf("Expected error reserving already allocated IDs")
+	}
+	if err := ei.ReserveRange(reserved); err != nil {
+		t.Fatalf("ReserveRange failed: %v", err)
+	}
+	if err := ei.ReserveRange(table.IDRange{Min: 5, Max: 8}); err == nil {
+		t.Errorf("Expected error reserving an overlapping range")
+	}
+
+	// Regular allocation skips the reserved range
+	regular, err := ei.NewEntries(1, tbl.Length(), tbl)
+	testing_util.CheckError(t, ei.NewEntries, err)
+	if regular[0].ID() != 6 {
+		t.Errorf("Regular entry got ID %d, expected 6", regular[0].ID())
+	}
+
+	ranged, err := ei.NewEntriesInRange(3, tbl.Length(), tbl, reserved)
+	testing_util.CheckError(t, ei.NewEntriesInRange, err)
+	for i, entry := range ranged {
+		if entry.ID() != table.EntryID(3+i) {
+			t.Errorf("Ranged entry %d got ID %d, expected %d", i, entry.ID(), 3+i)
+		}
+	}
+	if _, err := ei.NewEntriesInRange(1, tbl.Length(), tbl, reserved); err == nil {
+		t.Errorf("Expected error allocating from an exhausted range")
+	}
+
+	ei.RecycleEntries(4)
+	reused, err := ei.NewEntriesInRange(1, tbl.Length(), tbl, reserved)
+	testing_util.CheckError(t, ei.NewEntriesInRange, err)
+	if reused[0].ID() != 4 || reused[0].Recycled() != 1 {
+		t.Errorf("Expected ID 4 recycled once, got ID %d recycled %d", reused[0].ID(), reused[0].Recycled())
+	}
+}
+
+func TestEntryIndex_ForceNewEntryClaimsID(t *testing.T) {
+	ei := f.NewEntryIndex()
+	ei.SetAllocationMode(table.AllocateLowestFirst)
+	tbl, err := f.NewTable(f.NewSchema(), ei, 0, blankElementType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ei.NewEntries(3, tbl.Length(), tbl)
+	ei.RecycleEntries(2)
+
+	// Forcing a recyclable ID must take it out of circulation
+	if err := ei.ForceNewEntry(2, 1, 0, tbl); err != nil {
+		t.Fatalf("ForceNewEntry failed: %v", err)
+	}
+	// Forcing past the highest ID frees the skipped IDs
+	if err := ei.ForceNewEntry(6, 0, 0, tbl); err != nil {
+		t.Fatalf("ForceNewEntry failed: %v", err)
+	}
+
+	newEntries, err := ei.NewEntries(3, tbl.Length(), tbl)
+	testing_util.CheckError(t, ei.NewEntries, err)
+	expectedIDs := []table.EntryID{4, 5, 7}
+	for i, entry := range newEntries {
+		if entry.ID() != expectedIDs[i] {
+			t.Errorf("Entry %d got ID %d, expected %d", i, entry.ID(), expectedIDs[i])
+		}
+	}
+}
+
+func TestEntryIndex_ForceNewEntryGapsStartFresh(t *testing.T) {
+	// The authoritative side issues IDs 1-4 fresh, the mirror only sees ID 4 forced
+	authority := f.NewEntryIndex()
+	authorityTbl, err := f.NewTable(f.NewSchema(), authority, 0, blankElementType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authority.NewEntries(4, authorityTbl.Length(), authorityTbl)
+
+	mirror := f.NewEntryIndex()
+	mirror.SetAllocationMode(table.AllocateLowestFirst)
+	mirrorTbl, err := f.NewTable(f.NewSchema(), mirror, 0, blankElementType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mirror.ForceNewEntry(4, 0, 0, mirrorTbl); err != nil {
+		t.Fatalf("ForceNewEntry failed: %v", err)
+	}
+
+	newEntries, err := mirror.NewEntries(4, mirrorTbl.Length(), mirrorTbl)
+	testing_util.CheckError(t, mirror.NewEntries, err)
+	expectedIDs := []table.EntryID{1, 2, 3, 5}
+	for i, entry := range newEntries {
+		if entry.ID() != expectedIDs[i] {
+			t.Errorf("Entry %d got ID %d, expected %d", i, entry.ID(), expectedIDs[i])
+		}
+		if entry.Recycled() != 0 {
+			t.Errorf("Entry %d (ID %d) recycled %d, expected 0 like the authority", i, entry.ID(), entry.Recycled())
+		}
+	}
+
+	// Gaps that are later recycled count generations from 0 as well
+	mirror.RecycleEntries(2)
+	reused, err := mirror.NewEntries(1, mirrorTbl.Length(), mirrorTbl)
+	testing_util.CheckError(t, mirror.NewEntries, err)
+	if reused[0].ID() != 2 || reused[0].Recycled() != 1 {
+		t.Errorf("Expected ID 2 recycled once, got ID %d recycled %d", reused[0].ID(), reused[0].Recycled())
+	}
+}
+
+func TestEntryIndex_ForceNewEntryClaimState(t *testing.T) {
+	ei := f.NewEntryIndex()
+	tbl, err := f.NewTable(f.NewSchema(), ei, 0, blankElementType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ei.NewEntries(2, tbl.Length(), tbl)
+	ei.RecycleEntries(1)
+
+	// Deserializing IDs 1 (recyclable) and 5 (past the highest ID) claims both
+	if err := ei.ForceNewEntry(1, 3, 0, tbl); err != nil {
+		t.Fatalf("ForceNewEntry failed: %v", err)
+	}
+	if err := ei.ForceNewEntry(5, 0, 0, tbl); err != nil {
+		t.Fatalf("ForceNewEntry failed: %v", err)
+	}
+	recyclable := ei.Recyclable()
+	if len(recyclable) != 2 || recyclable[0].ID() != 3 || recyclable[1].ID() != 4 {
+		t.Fatalf("Expected skipped IDs 3 and 4 to be recyclable, got %v", recyclable)
+	}
+	forced, err := ei.Entry(0)
+	if err != nil || forced.Recycled() != 3 {
+		t.Errorf("Forced entry 1 = %v, %v, expected recycled 3", forced, err)
+	}
+
+	newEntries, err := ei.NewEntries(3, tbl.Length(), tbl)
+	testing_util.CheckError(t, ei.NewEntries, err)
+	expectedIDs := []table.EntryID{3, 4, 6}
+	for i, entry := range newEntries {
+		if entry.ID() != expectedIDs[i] {
+			t.Errorf("Entry %d got ID %d, expected %d", i, entry.ID(), expectedIDs[i])
+		}
+	}
+
+	// Forcing into a reserved range frees the skipped range IDs the same way
+	reserved := table.IDRange{Min: 10, Max: 14}
+	if err := ei.ReserveRange(reserved); err != nil {
+		t.Fatal(err)
+	}
+	if err := ei.ForceNewEntry(12, 0, 0, tbl); err != nil {
+		t.Fatalf("ForceNewEntry failed: %v", err)
+	}
+	ranged, err := ei.NewEntriesInRange(3, tbl.Length(), tbl, reserved)
+	testing_util.CheckError(t, ei.NewEntriesInRange, err)
+	expectedIDs = []table.EntryID{10, 11, 13}
+	for i, entry := range ranged {
+		if entry.ID() != expectedIDs[i] || entry.Recycled() != 0 {
+			t.Errorf("Ranged entry %d got ID %d recycled %d, expected ID %d recycled 0", i, entry.ID(), entry.Recycled(), expectedIDs[i])
+		}
+	}
+}
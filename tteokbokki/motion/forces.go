@@ -49,7 +49,7 @@ func (forcesGenerator) NewGravityForce(mass, gravity, pixelsPerMeter float64) ve
 // NewFrictionForce creates a friction force opposing the direction of movement
 func (forcesGenerator) NewFrictionForce(velocity vector.Two, frictionCoefficient float64) vector.Two {
 	// Calculate velocity magnitude
-	velMagSquared := velocity.MagSquared()
+	velMagSquared := magSquared(velocity)
 
 	// If velocity is very small, just return a force that will zero it out completely
 	if velMagSquared < 1.0 {
@@ -78,7 +78,7 @@ func (forcesGenerator) ApplyVelocityDamping(dyn *Dynamics, dampingFactor float64
 	dyn.Vel = dyn.Vel.Scale(dampingFactor)
 
 	// Zero out very small velocities completely
-	if dyn.Vel.MagSquared() < 0.5 {
+	if magSquared(dyn.Vel) < 0.5 {
 		dyn.Vel = vector.Two{X: 0, Y: 0}
 	}
 
@@ -0,0 +1,11 @@
+//go:build fixedpoint
+
+package motion
+
+import "github.com/TheBitDrifter/bappa/blueprint/vector"
+
+// magSquared returns the squared magnitude the force helpers compare against their thresholds
+// Squaring in fixed-point keeps the comparison from depending on a fused multiply-add
+func magSquared(v vector.Two) float64 {
+	return v.ToFixed().MagSquared().Float()
+}
@@ -0,0 +1,10 @@
+//go:build !fixedpoint
+
+package motion
+
+import "github.com/TheBitDrifter/bappa/blueprint/vector"
+
+// magSquared returns the squared magnitude the force helpers compare against their thresholds
+func magSquared(v vector.Two) float64 {
+	return v.MagSquared()
+}
@@ -0,0 +1,18 @@
+//go:build fixedpoint
+
+package motion
+
+import "github.com/TheBitDrifter/bappa/blueprint/vector"
+
+// ApplyImpulse applies both linear and angular impulse to a dynamics object
+func ApplyImpulse(dyn *Dynamics, linearImpulse, torqueArm vector.Two) {
+	applyImpulseFixed(dyn, linearImpulse.ToFixed(), torqueArm.ToFixed())
+}
+
+// applyImpulseFixed applies an impulse already in fixed-point, so resolvers skip a float round trip
+func applyImpulseFixed(dyn *Dynamics, linearImpulse, torqueArm vector.TwoFixed) {
+	linearImpulseScaled := linearImpulse.Scale(vector.FixedFromFloat(dyn.InverseMass))
+	dyn.Vel = dyn.Vel.ToFixed().Add(linearImpulseScaled).Two()
+	angularImpulseScaled := torqueArm.CrossProduct(linearImpulse).Mul(vector.FixedFromFloat(dyn.InverseAngularMass))
+	dyn.AngularVel = (vector.FixedFromFloat(dyn.AngularVel) + angularImpulseScaled).Float()
+}
@@ -1,3 +1,5 @@
+//go:build !fixedpoint
+
 package motion
 
 import "github.com/TheBitDrifter/bappa/blueprint/vector"
@@ -0,0 +1,42 @@
+//go:build fixedpoint
+
+package motion
+
+import "github.com/TheBitDrifter/bappa/blueprint/vector"
+
+// Built with -tags fixedpoint, integration runs on vector.Fixed and only converts back to float64
+// when storing the results, so every platform steps the same state to the same bits
+
+// IntegrateLinear calculates new position based on forces, acceleration and velocity
+func IntegrateLinear(dyn *Dynamics, pos vector.TwoReader, dt float64) (newPos vector.Two) {
+	posConc := vector.Two{
+		X: pos.GetX(),
+		Y: pos.GetY(),
+	}
+	if dyn.InverseMass == 0 {
+		return posConc
+	}
+	step := vector.FixedFromFloat(dt)
+	accel := dyn.SumForces.ToFixed().Scale(vector.FixedFromFloat(dyn.InverseMass))
+	vel := dyn.Vel.ToFixed().Add(accel.Scale(step))
+	dyn.Accel = accel.Two()
+	dyn.Vel = vel.Two()
+	newPos = posConc.ToFixed().Add(vel.Scale(step)).Two()
+	Forces.ClearForces(dyn)
+	return newPos
+}
+
+// IntegrateAngular calculates new rotation based on torque, angular acceleration and velocity
+func IntegrateAngular(dyn *Dynamics, rotation float64, dt float64) (newRotation float64) {
+	if dyn.InverseAngularMass == 0 {
+		return rotation
+	}
+	step := vector.FixedFromFloat(dt)
+	accel := vector.FixedFromFloat(dyn.SumTorque).Mul(vector.FixedFromFloat(dyn.InverseAngularMass))
+	vel := vector.FixedFromFloat(dyn.AngularVel) + accel.Mul(step)
+	dyn.AngularAccel = accel.Float()
+	dyn.AngularVel = vel.Float()
+	newRotation = (vector.FixedFromFloat(rotation) + vel.Mul(step)).Float()
+	Forces.ClearTorque(dyn)
+	return newRotation
+}
@@ -0,0 +1,82 @@
+//go:build fixedpoint
+
+package motion
+
+import (
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
+)
+
+// resolvePositions corrects object positions based on collision depth and mass
+func (resolver) resolvePositions(dynA, dynB *Dynamics, posA, posB *vector.Two, collision spatial.Collision) {
+	invMassA, invMassB := vector.FixedFromFloat(dynA.InverseMass), vector.FixedFromFloat(dynB.InverseMass)
+	depth := vector.FixedFromFloat(collision.Depth)
+	normal := collision.Normal.ToFixed()
+	correctionA := depth.Div(invMassA + invMassB).Mul(invMassA)
+	correctionB := depth.Div(invMassA + invMassB).Mul(invMassB)
+	*posA = posA.ToFixed().Sub(normal.Scale(correctionA)).Two()
+	*posB = posB.ToFixed().Add(normal.Scale(correctionB)).Two()
+}
+
+// applyResolutionImpulses calculates and applies impulses to both objects
+func (resolver) applyResolutionImpulses(dynA, dynB *Dynamics, posA, posB *vector.Two, collision spatial.Collision) {
+	combinedElasticity := (vector.FixedFromFloat(dynA.Elasticity) + vector.FixedFromFloat(dynB.Elasticity)) / 2
+	combinedFriction := (vector.FixedFromFloat(dynA.Friction) + vector.FixedFromFloat(dynB.Friction)) / 2
+	invMassA, invMassB := vector.FixedFromFloat(dynA.InverseMass), vector.FixedFromFloat(dynB.InverseMass)
+	invAngularMassA := vector.FixedFromFloat(dynA.InverseAngularMass)
+	invAngularMassB := vector.FixedFromFloat(dynB.InverseAngularMass)
+	angularVelA, angularVelB := vector.FixedFromFloat(dynA.AngularVel), vector.FixedFromFloat(dynB.AngularVel)
+	normal := collision.Normal.ToFixed()
+	centerToImpactA := collision.End.ToFixed().Sub(posA.ToFixed())
+	centerToImpactB := collision.Start.ToFixed().Sub(posB.ToFixed())
+
+	// Calculate relative velocities at impact points
+	relativeVelA := dynA.Vel.ToFixed().Add(
+		vector.TwoFixed{
+			X: -angularVelA.Mul(centerToImpactA.Y),
+			Y: angularVelA.Mul(centerToImpactA.X),
+		})
+	relativeVelB := dynB.Vel.ToFixed().Add(
+		vector.TwoFixed{
+			X: -angularVelB.Mul(centerToImpactB.Y),
+			Y: angularVelB.Mul(centerToImpactB.X),
+		})
+
+	impactVelocity := relativeVelA.Sub(relativeVelB)
+	normalVelocity := impactVelocity.ScalarProduct(normal)
+
+	// Calculate rotational factors
+	rotationFactorA := centerToImpactA.CrossProduct(normal)
+	rotationFactorASq := rotationFactorA.Mul(rotationFactorA)
+	rotationFactorB := centerToImpactB.CrossProduct(normal)
+	rotationFactorBSq := rotationFactorB.Mul(rotationFactorB)
+
+	// Compute normal impulse
+	totalInverseMass := invMassA + invMassB
+	normalImpulseDenom := totalInverseMass +
+		rotationFactorASq.Mul(invAngularMassA) +
+		rotationFactorBSq.Mul(invAngularMassB)
+	restitution := -(vector.FixedOne + combinedElasticity)
+	normalImpulseMag := restitution.Mul(normalVelocity).Div(normalImpulseDenom)
+	normalImpulse := normal.Scale(normalImpulseMag)
+
+	// Compute tangential impulse for friction
+	tangentDir := normal.Perpendicular().Norm()
+	tangentVelocity := impactVelocity.ScalarProduct(tangentDir)
+	rotationFactorTangentA := centerToImpactA.CrossProduct(tangentDir)
+	rotationFactorTangentASq := rotationFactorTangentA.Mul(rotationFactorTangentA)
+	rotationFactorTangentB := centerToImpactB.CrossProduct(tangentDir)
+	rotationFactorTangentBSq := rotationFactorTangentB.Mul(rotationFactorTangentB)
+
+	tangentImpulseDenom := totalInverseMass +
+		rotationFactorTangentASq.Mul(invAngularMassA) +
+		rotationFactorTangentBSq.Mul(invAngularMassB)
+	tangentImpulseMag := combinedFriction.Mul(restitution).Mul(tangentVelocity).Div(tangentImpulseDenom)
+	tangentImpulse := tangentDir.Scale(tangentImpulseMag)
+
+	// Apply combined impulses to both objects
+	totalImpulseA := normalImpulse.Add(tangentImpulse)
+	totalImpulseB := totalImpulseA.Scale(-vector.FixedOne)
+	applyImpulseFixed(dynA, totalImpulseA, centerToImpactA)
+	applyImpulseFixed(dynB, totalImpulseB, centerToImpactB)
+}
@@ -0,0 +1,66 @@
+//go:build fixedpoint
+
+package motion
+
+import (
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
+)
+
+// resolvePositions corrects object positions considering only vertical (Y) components
+func (verticalResolver) resolvePositions(dynA, dynB *Dynamics, posA, posB *vector.Two, collision spatial.Collision) {
+	// Only consider Y component of the normal
+	yOnlyNormal := vector.TwoFixed{X: 0, Y: vector.FixedFromFloat(collision.Normal.Y)}
+	if yOnlyNormal.Y != 0 {
+		yOnlyNormal = yOnlyNormal.Norm()
+		invMassA, invMassB := vector.FixedFromFloat(dynA.InverseMass), vector.FixedFromFloat(dynB.InverseMass)
+		depth := vector.FixedFromFloat(collision.Depth)
+		correctionA := depth.Div(invMassA + invMassB).Mul(invMassA)
+		correctionB := depth.Div(invMassA + invMassB).Mul(invMassB)
+		*posA = posA.ToFixed().Sub(yOnlyNormal.Scale(correctionA)).Two()
+		*posB = posB.ToFixed().Add(yOnlyNormal.Scale(correctionB)).Two()
+	}
+}
+
+// applyResolutionImpulses calculates and applies impulses considering only Y-axis components
+func (verticalResolver) applyResolutionImpulses(dynA, dynB *Dynamics, posA, posB *vector.Two, collision spatial.Collision) {
+	combinedElasticity := (vector.FixedFromFloat(dynA.Elasticity) + vector.FixedFromFloat(dynB.Elasticity)) / 2
+	centerToImpactA := collision.End.ToFixed().Sub(posA.ToFixed())
+	centerToImpactB := collision.Start.ToFixed().Sub(posB.ToFixed())
+
+	// Only consider Y component of velocities
+	relativeVelA := vector.TwoFixed{
+		X: 0,
+		Y: vector.FixedFromFloat(dynA.Vel.Y) + vector.FixedFromFloat(dynA.AngularVel).Mul(centerToImpactA.X),
+	}
+	relativeVelB := vector.TwoFixed{
+		X: 0,
+		Y: vector.FixedFromFloat(dynB.Vel.Y) + vector.FixedFromFloat(dynB.AngularVel).Mul(centerToImpactB.X),
+	}
+	impactVelocity := relativeVelA.Sub(relativeVelB)
+
+	// Use Y-only normal
+	yOnlyNormal := vector.TwoFixed{X: 0, Y: vector.FixedFromFloat(collision.Normal.Y)}
+	if yOnlyNormal.Y != 0 {
+		yOnlyNormal = yOnlyNormal.Norm()
+		normalVelocity := impactVelocity.ScalarProduct(yOnlyNormal)
+
+		// Calculate rotational factors
+		rotationFactorA := centerToImpactA.CrossProduct(yOnlyNormal)
+		rotationFactorASq := rotationFactorA.Mul(rotationFactorA)
+		rotationFactorB := centerToImpactB.CrossProduct(yOnlyNormal)
+		rotationFactorBSq := rotationFactorB.Mul(rotationFactorB)
+
+		// Compute normal impulse
+		totalInverseMass := vector.FixedFromFloat(dynA.InverseMass) + vector.FixedFromFloat(dynB.InverseMass)
+		normalImpulseDenom := totalInverseMass +
+			rotationFactorASq.Mul(vector.FixedFromFloat(dynA.InverseAngularMass)) +
+			rotationFactorBSq.Mul(vector.FixedFromFloat(dynB.InverseAngularMass))
+		normalImpulseMag := (-(vector.FixedOne + combinedElasticity)).Mul(normalVelocity).Div(normalImpulseDenom)
+		normalImpulse := yOnlyNormal.Scale(normalImpulseMag)
+
+		// Apply impulses to both objects
+		applyImpulseFixed(dynA, normalImpulse, centerToImpactA)
+		applyImpulseFixed(dynB, normalImpulse.Scale(-vector.FixedOne), centerToImpactB)
+	}
+}
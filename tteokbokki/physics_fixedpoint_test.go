@@ -0,0 +1,48 @@
+//go:build fixedpoint
+
+package tteokbokki_test
+
+import (
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/tteokbokki/motion"
+	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
+)
+
+// onFixedGrid reports whether every value is exactly representable in Q32.32, as results of fixed-point math are
+func onFixedGrid(values ...float64) bool {
+	for _, v := range values {
+		if vector.FixedFromFloat(v).Float() != v {
+			return false
+		}
+	}
+	return true
+}
+
+// TestFixedPointPhysics tests that the fixedpoint build runs motion and collision math on vector.Fixed
+func TestFixedPointPhysics(t *testing.T) {
+	a, b, posA, posB := collidingSquares()
+	_, collision := spatial.Detector.Check(a, b, posA, posB)
+	if !onFixedGrid(collision.Normal.X, collision.Normal.Y, collision.Depth, collision.Start.X, collision.End.Y) {
+		t.Errorf("Collision %+v was not computed in fixed-point", collision)
+	}
+
+	dynA, dynB := motion.NewDynamics(1), motion.NewDynamics(2)
+	dynA.SetDefaultAngularMass(a)
+	dynB.SetDefaultAngularMass(b)
+	dynA.Vel = vector.Two{X: 1.0 / 3}
+	motion.Resolver.Resolve(&posA, &posB, &dynA, &dynB, collision)
+	if !onFixedGrid(posA.X, posB.Y, dynA.Vel.X, dynB.Vel.Y, dynA.AngularVel, dynB.AngularVel) {
+		t.Errorf("Resolution was not computed in fixed-point")
+	}
+
+	dyn := motion.NewDynamics(3)
+	dyn.SetAngularMass(7)
+	motion.Forces.AddForce(&dyn, vector.Two{X: 0.1, Y: 9.81})
+	motion.Forces.AddTorque(&dyn, 0.7)
+	pos, rot := motion.Integrate(&dyn, vector.Two{X: 1.0 / 3}, 0.1, 1.0/60)
+	if !onFixedGrid(pos.X, pos.Y, rot, dyn.Vel.X, dyn.AngularVel) {
+		t.Errorf("Integration was not computed in fixed-point")
+	}
+}
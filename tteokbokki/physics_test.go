@@ -0,0 +1,87 @@
+package tteokbokki_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/tteokbokki/motion"
+	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
+)
+
+// The expectations come from the float64 build, the fixedpoint build must agree within tolerance
+const physicsTolerance = 1e-6
+
+func nearly(a, b float64) bool {
+	return math.Abs(a-b) <= physicsTolerance
+}
+
+func nearlyTwo(a, b vector.Two) bool {
+	return nearly(a.X, b.X) && nearly(a.Y, b.Y)
+}
+
+// collidingSquares returns a square at the origin overlapping a rotated square to its right
+func collidingSquares() (a, b spatial.Shape, posA, posB vector.Two) {
+	square := []vector.Two{{X: -1, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 1}, {X: -1, Y: 1}}
+	a, b = spatial.NewPolygon(square), spatial.NewPolygon(square)
+	posA, posB = vector.Two{}, vector.Two{X: 1.5, Y: 0.25}
+	a.Polygon.WorldVertices = spatial.UpdateWorldVertices(a.Polygon.LocalVertices, posA, spatial.NewScale(1, 1), 0)
+	b.Polygon.WorldVertices = spatial.UpdateWorldVertices(b.Polygon.LocalVertices, posB, spatial.NewScale(1, 1), 0.3)
+	return a, b, posA, posB
+}
+
+func TestPolygonCollisionAndResolution(t *testing.T) {
+	a, b, posA, posB := collidingSquares()
+	colliding, collision := spatial.Detector.Check(a, b, posA, posB)
+	if !colliding {
+		t.Fatal("Overlapping squares did not collide")
+	}
+	if want := (vector.Two{X: 0.955336489125606, Y: 0.2955202066613396}); !nearlyTwo(collision.Normal, want) {
+		t.Errorf("Normal = %v, want %v", collision.Normal, want)
+	}
+	if !nearly(collision.Depth, 0.7439719104332017) {
+		t.Errorf("Depth = %v, want 0.7439719104332017", collision.Depth)
+	}
+
+	dynA, dynB := motion.NewDynamics(1), motion.NewDynamics(2)
+	dynA.SetDefaultAngularMass(a)
+	dynB.SetDefaultAngularMass(b)
+	dynA.Vel = vector.Two{X: 3}
+	dynA.Elasticity, dynB.Elasticity = 0.5, 0.5
+	dynA.Friction, dynB.Friction = 0.2, 0.2
+	motion.Resolver.Resolve(&posA, &posB, &dynA, &dynB, collision)
+
+	got := []vector.Two{posA, posB, dynA.Vel, dynB.Vel, {X: dynA.AngularVel, Y: dynB.AngularVel}}
+	want := []vector.Two{
+		{X: -0.47382900861421645, Y: -0.1465724884809676},
+		{X: 1.7369145043071081, Y: 0.3232862442404838},
+		{X: 1.736020804438567, Y: -0.35790414348820854},
+		{X: 0.6319895977807165, Y: 0.17895207174410427},
+		{X: 1.3826313940003456, Y: -1.6433670708417503},
+	}
+	for i := range want {
+		if !nearlyTwo(got[i], want[i]) {
+			t.Errorf("Resolved state %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestIntegrate(t *testing.T) {
+	dyn := motion.NewDynamics(2)
+	dyn.SetAngularMass(4)
+	pos, rot := vector.Two{}, 0.0
+	for range 60 {
+		motion.Forces.AddForce(&dyn, vector.Two{X: 1, Y: 20})
+		motion.Forces.AddTorque(&dyn, 2)
+		pos, rot = motion.Integrate(&dyn, pos, rot, 1.0/60)
+	}
+	if want := (vector.Two{X: 0.2541666666666668, Y: 5.083333333333334}); !nearlyTwo(pos, want) {
+		t.Errorf("Position = %v, want %v", pos, want)
+	}
+	if want := (vector.Two{X: 0.5, Y: 10}); !nearlyTwo(dyn.Vel, want) {
+		t.Errorf("Velocity = %v, want %v", dyn.Vel, want)
+	}
+	if !nearly(rot, 0.2541666666666668) || !nearly(dyn.AngularVel, 0.5) {
+		t.Errorf("Rotation = %v at %v, want 0.2541666666666668 at 0.5", rot, dyn.AngularVel)
+	}
+}
@@ -36,8 +36,8 @@ func (continuousDetector) Check(
 		// Calculate interpolation factor
 		t := float64(step) / float64(steps)
 		// Interpolate positions
-		interpPosA := prevPosAConc.Add(deltaA.Scale(t))
-		interpPosB := prevPosBConc.Add(deltaB.Scale(t))
+		interpPosA := interpolate(prevPosAConc, deltaA, t)
+		interpPosB := interpolate(prevPosBConc, deltaB, t)
 
 		newWorldVerticesA := UpdateWorldVerticesSimple(shapeA.Polygon.LocalVertices, interpPosA)
 		shapeA.Polygon.WorldVertices = newWorldVerticesA
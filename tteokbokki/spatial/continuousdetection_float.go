@@ -0,0 +1,10 @@
+//go:build !fixedpoint
+
+package spatial
+
+import "github.com/TheBitDrifter/bappa/blueprint/vector"
+
+// interpolate returns the point a fraction t along delta from start
+func interpolate(start, delta vector.Two, t float64) vector.Two {
+	return start.Add(delta.Scale(t))
+}
@@ -34,36 +34,6 @@ func (detector) Check(shapeA, shapeB Shape, posA, posB vector.TwoReader) (bool,
 	return inspectPolygonCollision(shapeA.Polygon, shapeB.Polygon)
 }
 
-// inspectCircleCollision checks for collision between two circles and returns collision data
-func inspectCircleCollision(
-	circleA, circleB Circle,
-	posA, posB vector.Two,
-) (bool, Collision) {
-	distanceBetween := posB.Sub(posA)
-	radiusSum := circleB.Radius + circleA.Radius
-	notColliding := distanceBetween.MagSquared() > (radiusSum * radiusSum)
-	if notColliding {
-		return false, Collision{}
-	}
-	normal := distanceBetween.Norm()
-	start := posB.Sub(normal.Scale(circleB.Radius))
-	end := posA.Add(normal.Scale(circleA.Radius))
-	depth := end.Sub(start).Mag()
-
-	// For circles, we don't have traditional edges, so we use a placeholder
-	edgeA := CollisionEdge{
-		Index:    -1,
-		Vertices: []vector.Two{},
-	}
-
-	edgeB := CollisionEdge{
-		Index:    -1,
-		Vertices: []vector.Two{},
-	}
-
-	return true, Collision{start, end, normal, depth, edgeA, edgeB}
-}
-
 // inspectAABCollision checks for collision between two axis-aligned bounding boxes
 func inspectAABCollision(aabA, aabB AAB,
 	posA, posB vector.Two,
@@ -209,84 +179,6 @@ func broadFilter(shapeA, shapeB Shape, posA, posB vector.Two) bool {
 	return check
 }
 
-// inspectPolygonCollision performs detailed collision detection between two polygons
-func inspectPolygonCollision(polygonA, polygonB Polygon) (bool, Collision) {
-	var collision Collision
-	minSepA, incidentEdgeIndexA, penPointA := findMinSep(polygonA, polygonB)
-
-	edgeVectorA, v1A, v2A := edge(incidentEdgeIndexA, polygonA)
-	// Create colliding edge from polygon A with index information
-	collidingEdgeA := CollisionEdge{
-		Index:    incidentEdgeIndexA,
-		Vertices: []vector.Two{v1A, v2A},
-	}
-
-	if minSepA >= 0 {
-		return false, collision
-	}
-
-	minSepB, incidentEdgeIndexB, penPointB := findMinSep(polygonB, polygonA)
-
-	edgeVectorB, v1B, v2B := edge(incidentEdgeIndexB, polygonB)
-	// Create colliding edge from polygon B with index information
-	collidingEdgeB := CollisionEdge{
-		Index:    incidentEdgeIndexB,
-		Vertices: []vector.Two{v1B, v2B},
-	}
-
-	if minSepB >= 0 {
-		return false, collision
-	}
-
-	if minSepA > minSepB {
-		depth := -minSepA
-		normal := edgeVectorA.Perpendicular().Norm()
-		start := penPointA
-		end := start.Add(normal.Scale(depth))
-		collision = Collision{start, end, normal, depth, collidingEdgeA, collidingEdgeB}
-	} else {
-		depth := -minSepB
-		normal := edgeVectorB.Perpendicular().Norm().Scale(-1)
-		start := penPointB.Sub(normal.Scale(depth))
-		end := penPointB
-		collision = Collision{start, end, normal, depth, collidingEdgeA, collidingEdgeB}
-	}
-
-	return true, collision
-}
-
-// findMinSep finds the minimum separation between two polygons
-// Returns separation distance, reference edge index, and penetration point
-func findMinSep(polygonA, polygonB Polygon) (float64, int, vector.Two) {
-	sep := -math.MaxFloat64
-	var indexReferenceEdge int
-	var penPoint vector.Two
-
-	for i := range polygonA.WorldVertices {
-		va := polygonA.WorldVertices[i]
-		currentEdge, _, _ := edge(i, polygonA)
-		normal := currentEdge.Perpendicular().Norm()
-
-		minSep := math.MaxFloat64
-		var minVert vector.Two
-
-		for _, vb := range polygonB.WorldVertices {
-			projection := vb.Sub(va).ScalarProduct(normal)
-			if projection < minSep {
-				minSep = projection
-				minVert = vb
-			}
-		}
-
-		if minSep > sep {
-			sep = minSep
-			indexReferenceEdge = i
-			penPoint = minVert
-		}
-	}
-	return sep, indexReferenceEdge, penPoint
-}
-
 // edge returns the edge vector and vertices for a given edge index in a polygon
 func edge(index int, polygon Polygon) (edge, v1, v2 vector.Two) {
 	vertCount := len(polygon.WorldVertices)
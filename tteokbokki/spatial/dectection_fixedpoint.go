@@ -0,0 +1,123 @@
+//go:build fixedpoint
+
+package spatial
+
+import (
+	"math"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+)
+
+// Built with -tags fixedpoint, circle and polygon detection runs on vector.Fixed and only the resulting
+// Collision is converted back to float64. Bounding box checks stay float64, they only add, subtract and halve
+
+// inspectCircleCollision checks for collision between two circles and returns collision data
+func inspectCircleCollision(
+	circleA, circleB Circle,
+	posA, posB vector.Two,
+) (bool, Collision) {
+	fixedA, fixedB := posA.ToFixed(), posB.ToFixed()
+	radiusA, radiusB := vector.FixedFromFloat(circleA.Radius), vector.FixedFromFloat(circleB.Radius)
+	distanceBetween := fixedB.Sub(fixedA)
+	radiusSum := radiusB + radiusA
+	notColliding := distanceBetween.MagSquared() > radiusSum.Mul(radiusSum)
+	if notColliding {
+		return false, Collision{}
+	}
+	normal := distanceBetween.Norm()
+	start := fixedB.Sub(normal.Scale(radiusB))
+	end := fixedA.Add(normal.Scale(radiusA))
+	depth := end.Sub(start).Mag()
+
+	// For circles, we don't have traditional edges, so we use a placeholder
+	edgeA := CollisionEdge{
+		Index:    -1,
+		Vertices: []vector.Two{},
+	}
+
+	edgeB := CollisionEdge{
+		Index:    -1,
+		Vertices: []vector.Two{},
+	}
+
+	return true, Collision{start.Two(), end.Two(), normal.Two(), depth.Float(), edgeA, edgeB}
+}
+
+// inspectPolygonCollision performs detailed collision detection between two polygons
+func inspectPolygonCollision(polygonA, polygonB Polygon) (bool, Collision) {
+	var collision Collision
+	minSepA, incidentEdgeIndexA, penPointA := findMinSep(polygonA, polygonB)
+
+	edgeVectorA, v1A, v2A := edge(incidentEdgeIndexA, polygonA)
+	// Create colliding edge from polygon A with index information
+	collidingEdgeA := CollisionEdge{
+		Index:    incidentEdgeIndexA,
+		Vertices: []vector.Two{v1A, v2A},
+	}
+
+	if minSepA >= 0 {
+		return false, collision
+	}
+
+	minSepB, incidentEdgeIndexB, penPointB := findMinSep(polygonB, polygonA)
+
+	edgeVectorB, v1B, v2B := edge(incidentEdgeIndexB, polygonB)
+	// Create colliding edge from polygon B with index information
+	collidingEdgeB := CollisionEdge{
+		Index:    incidentEdgeIndexB,
+		Vertices: []vector.Two{v1B, v2B},
+	}
+
+	if minSepB >= 0 {
+		return false, collision
+	}
+
+	if minSepA > minSepB {
+		depth := -minSepA
+		normal := edgeVectorA.ToFixed().Perpendicular().Norm()
+		start := penPointA
+		end := start.Add(normal.Scale(depth))
+		collision = Collision{start.Two(), end.Two(), normal.Two(), depth.Float(), collidingEdgeA, collidingEdgeB}
+	} else {
+		depth := -minSepB
+		normal := edgeVectorB.ToFixed().Perpendicular().Norm().Scale(-vector.FixedOne)
+		start := penPointB.Sub(normal.Scale(depth))
+		end := penPointB
+		collision = Collision{start.Two(), end.Two(), normal.Two(), depth.Float(), collidingEdgeA, collidingEdgeB}
+	}
+
+	return true, collision
+}
+
+// findMinSep finds the minimum separation between two polygons
+// Returns separation distance, reference edge index, and penetration point
+func findMinSep(polygonA, polygonB Polygon) (vector.Fixed, int, vector.TwoFixed) {
+	sep := vector.Fixed(math.MinInt64)
+	var indexReferenceEdge int
+	var penPoint vector.TwoFixed
+
+	for i := range polygonA.WorldVertices {
+		va := polygonA.WorldVertices[i].ToFixed()
+		currentEdge, _, _ := edge(i, polygonA)
+		normal := currentEdge.ToFixed().Perpendicular().Norm()
+
+		minSep := vector.Fixed(math.MaxInt64)
+		var minVert vector.TwoFixed
+
+		for _, vertex := range polygonB.WorldVertices {
+			vb := vertex.ToFixed()
+			projection := vb.Sub(va).ScalarProduct(normal)
+			if projection < minSep {
+				minSep = projection
+				minVert = vb
+			}
+		}
+
+		if minSep > sep {
+			sep = minSep
+			indexReferenceEdge = i
+			penPoint = minVert
+		}
+	}
+	return sep, indexReferenceEdge, penPoint
+}
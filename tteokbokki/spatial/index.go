@@ -0,0 +1,124 @@
+package spatial
+
+import (
+	"sort"
+
+	"github.com/TheBitDrifter/bappa/blueprint/vector"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// DefaultIndexCellSize is the grid cell size used by indexes created through IndexFor
+var DefaultIndexCellSize = 64.0
+
+// indexName is the name of the shared shape index among a storage's spatial indexes
+const indexName = "tteokbokki/spatial.shapes"
+
+// NewIndex creates a spatial index over entities with both a Position and a Shape
+func NewIndex(sto warehouse.Storage, cellSize float64) warehouse.SpatialIndex {
+	tracked := warehouse.Factory.NewQuery().And(Components.Position, Components.Shape)
+	return warehouse.Factory.NewSpatialIndex(sto, cellSize, tracked, func(en warehouse.Entity) (warehouse.AABB, bool) {
+		pos := Components.Position.GetFromEntity(en)
+		shape := Components.Shape.GetFromEntity(en)
+		return ShapeBounds(*shape, pos.Two), true
+	})
+}
+
+// IndexFor returns the shared spatial index for a storage, creating and filling it on first use
+// The storage keeps it current as entities come and go, and tteo_coresystems.TransformSystem
+// updates the entities it moves
+func IndexFor(sto warehouse.Storage) (warehouse.SpatialIndex, error) {
+	var syncErr error
+	index := sto.SpatialIndexes().GetOrCreate(indexName, func() warehouse.SpatialIndex {
+		index := NewIndex(sto, DefaultIndexCellSize)
+		syncErr = index.Sync()
+		return index
+	})
+	if syncErr != nil {
+		ReleaseIndex(sto)
+		return nil, syncErr
+	}
+	return index, nil
+}
+
+// LookupIndex returns the shared spatial index for a storage if one was created
+func LookupIndex(sto warehouse.Storage) (warehouse.SpatialIndex, bool) {
+	return sto.SpatialIndexes().Get(indexName)
+}
+
+// ReleaseIndex drops the shared spatial index for a storage
+func ReleaseIndex(sto warehouse.Storage) {
+	sto.SpatialIndexes().Release(indexName)
+}
+
+// Sense returns the entities with a shape within radius of origin that match filter (nil matches all),
+// nearest first. It is meant for AI sensing and uses the shared index when the storage has one,
+// otherwise it scans every entity with a position and shape
+func Sense(sto warehouse.Storage, origin vector.Two, radius float64, filter warehouse.QueryNode) []warehouse.Entity {
+	var found []warehouse.Entity
+	if index, ok := LookupIndex(sto); ok {
+		found = index.QueryRadius(origin.X, origin.Y, radius, filter)
+	} else {
+		found = scanRadius(sto, origin, radius, filter)
+	}
+	distances := make([]float64, len(found))
+	for i, en := range found {
+		distances[i] = Components.Position.GetFromEntity(en).DistanceSquaredTo(origin)
+	}
+	order := make([]int, len(found))
+	for i := range order {
+		order[i] = i
+	}
+	// Stable so equally distant entities keep ID order
+	sort.SliceStable(order, func(i, j int) bool { return distances[order[i]] < distances[order[j]] })
+
+	sensed := make([]warehouse.Entity, len(found))
+	for i, index := range order {
+		sensed[i] = found[index]
+	}
+	return sensed
+}
+
+// scanRadius returns the entities whose shape bounds intersect the circle, ordered by ID like an index query
+func scanRadius(sto warehouse.Storage, origin vector.Two, radius float64, filter warehouse.QueryNode) []warehouse.Entity {
+	query := warehouse.Factory.NewQuery().And(Components.Position, Components.Shape)
+	if filter != nil {
+		query = warehouse.Factory.NewQuery().And(Components.Position, Components.Shape, filter)
+	}
+
+	found := []warehouse.Entity{}
+	cursor := warehouse.Factory.NewCursor(query, sto)
+	for range cursor.Next() {
+		pos := Components.Position.GetFromCursor(cursor)
+		bounds := ShapeBounds(*Components.Shape.GetFromCursor(cursor), pos.Two)
+		dx := max(bounds.MinX-origin.X, 0, origin.X-bounds.MaxX)
+		dy := max(bounds.MinY-origin.Y, 0, origin.Y-bounds.MaxY)
+		if dx*dx+dy*dy > radius*radius {
+			continue
+		}
+		if en, err := cursor.CurrentEntity(); err == nil {
+			found = append(found, en)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID() < found[j].ID() })
+	return found
+}
+
+// ShapeBounds returns the world space bounds of a shape at the given position
+// World vertices are used when available so rotated shapes are covered,
+// otherwise the circle skin is used
+func ShapeBounds(shape Shape, pos vector.Two) warehouse.AABB {
+	verts := shape.Polygon.WorldVertices
+	if len(verts) == 0 {
+		r := shape.Skin.Circle.Radius
+		return warehouse.AABB{MinX: pos.X - r, MinY: pos.Y - r, MaxX: pos.X + r, MaxY: pos.Y + r}
+	}
+
+	bounds := warehouse.AABB{MinX: verts[0].X, MinY: verts[0].Y, MaxX: verts[0].X, MaxY: verts[0].Y}
+	for _, v := range verts[1:] {
+		bounds.MinX = min(bounds.MinX, v.X)
+		bounds.MinY = min(bounds.MinY, v.Y)
+		bounds.MaxX = max(bounds.MaxX, v.X)
+		bounds.MaxY = max(bounds.MaxY, v.Y)
+	}
+	return bounds
+}
@@ -232,9 +232,7 @@ func NewRegularPolygon(radius float64, numVertices int) Shape {
 	// Calculate the vertices of the regular polygon around the origin (0,0)
 	for i := 0; i < numVertices; i++ {
 		angle := angleStep * float64(i)
-		x := radius * math.Cos(angle)
-		y := radius * math.Sin(angle)
-		shape.Polygon.LocalVertices[i] = vector.Two{X: x, Y: y}
+		shape.Polygon.LocalVertices[i] = vector.Two{X: radius}.Rotate(angle)
 	}
 
 	// Clone the local vertices to world vertices
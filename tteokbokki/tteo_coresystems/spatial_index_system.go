@@ -0,0 +1,17 @@
+package tteo_coresystems
+
+import (
+	"github.com/TheBitDrifter/bappa/blueprint"
+	"github.com/TheBitDrifter/bappa/tteokbokki/spatial"
+)
+
+// SpatialIndexSystem attaches the shared spatial index to the scene storage
+// The storage keeps the index current as entities come and go and TransformSystem updates the
+// entities it moves, so after the first tick this does no per entity work
+type SpatialIndexSystem struct{}
+
+// Run creates and fills the spatial index for the scene storage if it does not exist yet
+func (SpatialIndexSystem) Run(scene blueprint.Scene, dt float64) error {
+	_, err := spatial.IndexFor(scene.Storage())
+	return err
+}
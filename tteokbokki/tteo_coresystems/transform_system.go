@@ -10,7 +10,9 @@ import (
 type TransformSystem struct{}
 
 // Run processes all entities with Shape components and updates their world vertices
+// Entities are also updated in the shared spatial index, when the scene has one
 func (TransformSystem) Run(scene blueprint.Scene, dt float64) error {
+	index, indexed := spatial.LookupIndex(scene.Storage())
 	cursor := scene.NewCursor(blueprint.Queries.Shape)
 	for range cursor.Next() {
 		shape := spatial.Components.Shape.GetFromCursor(cursor)
@@ -45,6 +47,14 @@ func (TransformSystem) Run(scene blueprint.Scene, dt float64) error {
 		newWorldVertices := spatial.UpdateWorldVertices(shape.Polygon.LocalVertices, posToUse, scaleToUse, rot64)
 		shape.Polygon.WorldVertices = newWorldVertices
 		spatial.UpdateSkinAndAAB(shape, scaleToUse, rot64)
+
+		if indexed {
+			en, err := cursor.CurrentEntity()
+			if err != nil {
+				return err
+			}
+			index.Update(en)
+		}
 	}
 	return nil
 }
@@ -32,6 +32,31 @@ type ArchetypeImpl struct {
 	table      table.Table
 	storage    *storage
 	components []Component
+	edges      *archetypeEdges
+}
+
+// archetypeEdges caches the neighboring archetypes reached by adding or removing a single component
+// Keyed by component ID, shared by every copy of the ArchetypeImpl
+type archetypeEdges struct {
+	add    map[table.ElementTypeID]archetypeID
+	remove map[table.ElementTypeID]archetypeID
+}
+
+func newArchetypeEdges() *archetypeEdges {
+	return &archetypeEdges{
+		add:    make(map[table.ElementTypeID]archetypeID),
+		remove: make(map[table.ElementTypeID]archetypeID),
+	}
+}
+
+// lookup returns the cached neighbor for the component transition
+func (e *archetypeEdges) lookup(c Component, add bool) (archetypeID, bool) {
+	if add {
+		id, ok := e.add[c.ID()]
+		return id, ok
+	}
+	id, ok := e.remove[c.ID()]
+	return id, ok
 }
 
 // newArchetypeImpl creates a new archetype with the given components
@@ -52,11 +77,13 @@ func newArchetype(
 	if err != nil {
 		return ArchetypeImpl{}, err
 	}
+	tbl.SetIDRange(sto.idRange)
 	return ArchetypeImpl{
 		storage:    sto,
 		components: components,
 		table:      tbl,
 		id:         id,
+		edges:      newArchetypeEdges(),
 	}, nil
 }
 
@@ -96,10 +123,11 @@ func (a ArchetypeImpl) Generate(count int, fromComponents ...any) error {
 					"entity_index", en.Index())
 				continue
 			}
-			compValue := reflect.ValueOf(component)
-			reflect.Value(row).Index(en.Index()).Set(compValue)
+			reflect.Value(row).Index(en.Index()).Set(componentValue(component))
 		}
 	}
+	// Indexes saw the entities before their values were set
+	a.storage.spatialIndexes.entitiesChanged(entities...)
 	return nil
 }
 
@@ -126,10 +154,11 @@ func (a ArchetypeImpl) GenerateAndReturnEntity(count int, fromComponents ...any)
 					"entity_index", en.Index())
 				continue
 			}
-			compValue := reflect.ValueOf(component)
-			reflect.Value(row).Index(en.Index()).Set(compValue)
+			reflect.Value(row).Index(en.Index()).Set(componentValue(component))
 		}
 	}
+	// Indexes saw the entities before their values were set
+	a.storage.spatialIndexes.entitiesChanged(entities...)
 	return entities, nil
 }
 
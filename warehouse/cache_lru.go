@@ -0,0 +1,214 @@
+package warehouse
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Ensure LRUCache implements the RefCountedCache interface
+var _ RefCountedCache[any] = &LRUCache[any]{}
+
+// RefCountedCache is a Cache that evicts unreferenced items instead of rejecting new ones
+//
+// Indices are stable for as long as an item stays cached. An item with a positive reference
+// count is never evicted, so indices held by live references remain valid
+type RefCountedCache[T any] interface {
+	Cache[T]
+	// Retain increments the reference count of the item with the given key
+	// Returns the item's index, or false if the key is not cached
+	Retain(string) (int, bool)
+	// RegisterAndRetain registers the item and increments its reference count in one step,
+	// so it cannot be evicted in between
+	RegisterAndRetain(string, T) (int, error)
+	// Release decrements the reference count of the item with the given key
+	Release(string)
+	// RefCount returns the reference count of the item with the given key
+	RefCount(string) int
+	// GetItemIfKey retrieves the item at the index only while its slot still holds the key
+	// Evicted slots are handed to other items, so indices stored outside the cache must be checked
+	GetItemIfKey(int, string) (T, bool)
+}
+
+// lruEntry is a single occupied slot of an LRUCache
+type lruEntry[T any] struct {
+	key      string
+	item     T
+	refs     int
+	lastUsed atomic.Uint64
+}
+
+// LRUCache implements the RefCountedCache interface with a fixed number of slots
+//
+// When every slot is occupied, Register reuses the slot of the least recently used item
+// whose reference count is zero. Registration only fails when all items are retained
+type LRUCache[T any] struct {
+	mu          sync.RWMutex
+	entries     []*lruEntry[T]
+	itemIndices map[string]int
+	clock       atomic.Uint64
+	maxCapacity int
+}
+
+// touch marks the entry as most recently used
+func (c *LRUCache[T]) touch(entry *lruEntry[T]) {
+	entry.lastUsed.Store(c.clock.Add(1))
+}
+
+// GetIndex retrieves the index of an item by its key
+func (c *LRUCache[T]) GetIndex(key string) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	index, ok := c.itemIndices[key]
+	if ok {
+		c.touch(c.entries[index-1])
+	}
+	return index, ok
+}
+
+// GetItem retrieves an item by its index
+func (c *LRUCache[T]) GetItem(index int) T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry := c.entries[index-1]
+	c.touch(entry)
+	return entry.item
+}
+
+// GetItemIfKey retrieves the item at the index only while its slot still holds the key
+func (c *LRUCache[T]) GetItemIfKey(index int, key string) (T, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if index < 1 || index > len(c.entries) || c.entries[index-1].key != key {
+		var zero T
+		return zero, false
+	}
+	entry := c.entries[index-1]
+	c.touch(entry)
+	return entry.item, true
+}
+
+// GetItem32 retrieves an item by its uint32 index
+func (c *LRUCache[T]) GetItem32(index uint32) T {
+	return c.GetItem(int(index))
+}
+
+// Register adds a new item to the cache with the given key
+// Registering an existing key replaces its item in place and keeps its index
+// Returns an error if the cache is full and every item is retained
+func (c *LRUCache[T]) Register(key string, item T) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.register(key, item)
+}
+
+// RegisterAndRetain registers the item and increments its reference count in one step
+// Returns an error if the cache is full and every item is retained
+func (c *LRUCache[T]) RegisterAndRetain(key string, item T) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx, err := c.register(key, item)
+	if err != nil {
+		return -1, err
+	}
+	c.entries[idx-1].refs++
+	return idx, nil
+}
+
+// register adds or replaces the item, the caller must hold the write lock
+func (c *LRUCache[T]) register(key string, item T) (int, error) {
+	if idx, ok := c.itemIndices[key]; ok {
+		entry := c.entries[idx-1]
+		entry.item = item
+		c.touch(entry)
+		return idx, nil
+	}
+
+	entry := &lruEntry[T]{key: key, item: item}
+	c.touch(entry)
+
+	if len(c.entries) < c.maxCapacity {
+		c.entries = append(c.entries, entry)
+		idx := len(c.entries)
+		c.itemIndices[key] = idx
+		return idx, nil
+	}
+
+	victim := -1
+	for i, candidate := range c.entries {
+		if candidate.refs > 0 {
+			continue
+		}
+		if victim == -1 || candidate.lastUsed.Load() < c.entries[victim].lastUsed.Load() {
+			victim = i
+		}
+	}
+	if victim == -1 {
+		return -1, fmt.Errorf("cache at maximum capacity (%d) with every item retained", c.maxCapacity)
+	}
+
+	delete(c.itemIndices, c.entries[victim].key)
+	c.entries[victim] = entry
+	idx := victim + 1
+	c.itemIndices[key] = idx
+	return idx, nil
+}
+
+// Retain increments the reference count of the item with the given key
+func (c *LRUCache[T]) Retain(key string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx, ok := c.itemIndices[key]
+	if !ok {
+		return 0, false
+	}
+	entry := c.entries[idx-1]
+	entry.refs++
+	c.touch(entry)
+	return idx, true
+}
+
+// Release decrements the reference count of the item with the given key
+// The item stays cached and becomes evictable once its count reaches zero
+func (c *LRUCache[T]) Release(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	idx, ok := c.itemIndices[key]
+	if !ok {
+		return
+	}
+	entry := c.entries[idx-1]
+	if entry.refs > 0 {
+		entry.refs--
+	}
+}
+
+// RefCount returns the reference count of the item with the given key
+func (c *LRUCache[T]) RefCount(key string) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	idx, ok := c.itemIndices[key]
+	if !ok {
+		return 0
+	}
+	return c.entries[idx-1].refs
+}
+
+// Clear removes all items from the cache, regardless of their reference counts
+func (c *LRUCache[T]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make([]*lruEntry[T], 0, c.maxCapacity)
+	c.itemIndices = make(map[string]int)
+}
+
+// All returns the cached items ordered by index
+func (c *LRUCache[T]) All() []T {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	items := make([]T, len(c.entries))
+	for i, entry := range c.entries {
+		items[i] = entry.item
+	}
+	return items
+}
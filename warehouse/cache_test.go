@@ -1,6 +1,8 @@
 package warehouse
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 )
 
@@ -195,3 +197,135 @@ func TestCacheConcurrentAccess(t *testing.T) {
 	// Wait for reader goroutine to finish
 	<-done
 }
+
+// TestLRUCacheEviction tests that a full LRU cache reuses the slot of the least recently used item
+func TestLRUCacheEviction(t *testing.T) {
+	cache := FactoryNewLRUCache[string](3)
+
+	indices := map[string]int{}
+	for _, key := range []string{"a", "b", "c"} {
+		idx, err := cache.Register(key, key)
+		if err != nil {
+			t.Fatalf("Failed to register item %s: %v", key, err)
+		}
+		indices[key] = idx
+	}
+
+	// Touch "a" and "c" so "b" becomes the least recently used
+	idxA, idxB := indices["a"], indices["b"]
+	cache.GetItem(idxA)
+	cache.GetIndex("c")
+
+	idxD, err := cache.Register("d", "d")
+	if err != nil {
+		t.Fatalf("Register on full cache failed: %v", err)
+	}
+	if idxD != idxB {
+		t.Errorf("Index for d is %d, expected evicted slot %d", idxD, idxB)
+	}
+	if _, found := cache.GetIndex("b"); found {
+		t.Errorf("Evicted item b still found in cache")
+	}
+	if cache.GetItem(idxA) != "a" {
+		t.Errorf("Index of surviving item a changed")
+	}
+	if len(cache.All()) != 3 {
+		t.Errorf("Cache holds %d items, expected 3", len(cache.All()))
+	}
+}
+
+// TestLRUCacheRetain tests that retained items are never evicted
+func TestLRUCacheRetain(t *testing.T) {
+	cache := FactoryNewLRUCache[int](2)
+
+	idxA, _ := cache.Register("a", 1)
+	cache.Register("b", 2)
+
+	if _, ok := cache.Retain("a"); !ok {
+		t.Fatalf("Failed to retain a")
+	}
+	cache.Retain("b")
+
+	if _, err := cache.Register("c", 3); err == nil {
+		t.Errorf("Expected error when every item is retained")
+	}
+
+	cache.Release("b")
+	if cache.RefCount("b") != 0 {
+		t.Errorf("RefCount for b is %d, expected 0", cache.RefCount("b"))
+	}
+	if _, err := cache.Register("c", 3); err != nil {
+		t.Errorf("Register after release failed: %v", err)
+	}
+	if cache.GetItem(idxA) != 1 {
+		t.Errorf("Retained item a moved or was evicted")
+	}
+	if _, found := cache.GetIndex("b"); found {
+		t.Errorf("Released item b should have been evicted")
+	}
+}
+
+// TestLRUCacheGetItemIfKey tests that stale indices are detected after eviction
+func TestLRUCacheGetItemIfKey(t *testing.T) {
+	cache := FactoryNewLRUCache[string](1)
+
+	idxA, _ := cache.Register("a", "a")
+	if item, ok := cache.GetItemIfKey(idxA, "a"); !ok || item != "a" {
+		t.Fatalf("GetItemIfKey(a) = %q, %v, want a, true", item, ok)
+	}
+
+	idxB, _ := cache.Register("b", "b")
+	if idxB != idxA {
+		t.Fatalf("Index for b is %d, expected reused slot %d", idxB, idxA)
+	}
+	if item, ok := cache.GetItemIfKey(idxA, "a"); ok {
+		t.Errorf("Stale index for a returned %q", item)
+	}
+	if _, ok := cache.GetItemIfKey(idxA+1, "b"); ok {
+		t.Errorf("Out of range index reported as valid")
+	}
+}
+
+// TestLRUCacheRegisterAndRetain tests that items registered in parallel are never evicted before they are retained
+func TestLRUCacheRegisterAndRetain(t *testing.T) {
+	const capacity = 4
+	cache := FactoryNewLRUCache[int](capacity)
+
+	type registered struct {
+		key   string
+		index int
+	}
+	results := make(chan registered, 16)
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := fmt.Sprintf("item%d", i)
+			if index, err := cache.RegisterAndRetain(key, i); err == nil {
+				results <- registered{key, index}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	count, cached := 0, ""
+	for r := range results {
+		count++
+		cached = r.key
+		if _, ok := cache.GetItemIfKey(r.index, r.key); !ok || cache.RefCount(r.key) != 1 {
+			t.Errorf("%s was evicted or not retained after registering, refs %d", r.key, cache.RefCount(r.key))
+		}
+	}
+	if count != capacity {
+		t.Errorf("%d registrations succeeded, want %d", count, capacity)
+	}
+
+	if _, err := cache.RegisterAndRetain("extra", -1); err == nil {
+		t.Error("Expected error when every item is retained")
+	}
+	if _, err := cache.RegisterAndRetain(cached, -1); err != nil || cache.RefCount(cached) != 2 {
+		t.Errorf("Registering cached %s again = %v with refs %d, want 2", cached, err, cache.RefCount(cached))
+	}
+}
@@ -1,6 +1,8 @@
 package warehouse
 
 import (
+	"reflect"
+
 	"github.com/TheBitDrifter/bappa/table"
 )
 
@@ -11,3 +13,20 @@ import (
 type Component interface {
 	table.ElementType
 }
+
+// ComponentCloner is implemented by component values that hold reference types (slices, maps)
+//
+// Generate and AddComponentWithValue store the result of CloneComponent instead of the value itself,
+// so entities created from the same value never share mutable state.
+// CloneComponent must return a value of the same type as the component
+type ComponentCloner interface {
+	CloneComponent() any
+}
+
+// componentValue returns the value to store for a component, cloned when it implements ComponentCloner
+func componentValue(component any) reflect.Value {
+	if cloner, ok := component.(ComponentCloner); ok {
+		return reflect.ValueOf(cloner.CloneComponent())
+	}
+	return reflect.ValueOf(component)
+}
@@ -0,0 +1,406 @@
+package warehouse
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FieldInfo describes a single field of a component type
+type FieldInfo struct {
+	Name     string            // Go field name
+	Path     string            // Dotted path from the component root, e.g. "Vel.X"
+	Type     reflect.Type      // Field type
+	Tag      reflect.StructTag // Raw struct tag
+	Exported bool              // Whether the field can be read and written through reflection
+	Fields   []FieldInfo       // Nested fields when Type is a struct (or pointer to struct)
+
+	min, max float64
+	ranged   bool
+}
+
+// Range returns the inclusive bounds declared with a `range:"min,max"` struct tag
+func (f FieldInfo) Range() (min, max float64, ok bool) {
+	return f.min, f.max, f.ranged
+}
+
+// ComponentSchema is the reflected field layout of a component type
+type ComponentSchema struct {
+	Name      string
+	Component Component
+	Type      reflect.Type
+	Fields    []FieldInfo
+}
+
+// Field returns the field at the dotted path relative to the component root
+func (s *ComponentSchema) Field(path string) (FieldInfo, bool) {
+	fields := s.Fields
+	var found FieldInfo
+	for _, name := range strings.Split(path, ".") {
+		ok := false
+		for _, f := range fields {
+			if f.Name == name {
+				found, fields, ok = f, f.Fields, true
+				break
+			}
+		}
+		if !ok {
+			return FieldInfo{}, false
+		}
+	}
+	return found, true
+}
+
+// All returns every field of the schema, depth first
+func (s *ComponentSchema) All() []FieldInfo {
+	var all []FieldInfo
+	var walk func([]FieldInfo)
+	walk = func(fields []FieldInfo) {
+		for _, f := range fields {
+			all = append(all, f)
+			walk(f.Fields)
+		}
+	}
+	walk(s.Fields)
+	return all
+}
+
+// schemaCache holds reflected field layouts keyed by component type
+var schemaCache sync.Map // map[reflect.Type][]FieldInfo
+
+// Schema returns the reflected schema of a registered component
+func (r *TypeRegistry) Schema(comp Component) (*ComponentSchema, error) {
+	name, ok := r.LookupName(comp)
+	if !ok {
+		return nil, fmt.Errorf("component %s is not registered", comp.Type())
+	}
+	return &ComponentSchema{
+		Name:      name,
+		Component: comp,
+		Type:      comp.Type(),
+		Fields:    reflectFields(comp.Type()),
+	}, nil
+}
+
+// SchemaByName returns the reflected schema of the component registered under the name
+// Bare type names ("Position") resolve when they are unambiguous
+func (r *TypeRegistry) SchemaByName(name string) (*ComponentSchema, error) {
+	comp, err := r.resolveComponentName(name)
+	if err != nil {
+		return nil, err
+	}
+	return r.Schema(comp)
+}
+
+// reflectFields returns the field layout of a type, reflecting it once per type
+func reflectFields(t reflect.Type) []FieldInfo {
+	if cached, ok := schemaCache.Load(t); ok {
+		return cached.([]FieldInfo)
+	}
+	fields := buildFields(t, "", map[reflect.Type]bool{})
+	schemaCache.Store(t, fields)
+	return fields
+}
+
+func buildFields(t reflect.Type, prefix string, visiting map[reflect.Type]bool) []FieldInfo {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return nil
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields := make([]FieldInfo, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		info := FieldInfo{
+			Name:     sf.Name,
+			Path:     prefix + sf.Name,
+			Type:     sf.Type,
+			Tag:      sf.Tag,
+			Exported: sf.IsExported(),
+		}
+		if tag, ok := sf.Tag.Lookup("range"); ok {
+			info.min, info.max, info.ranged = parseRangeTag(tag)
+		}
+		info.Fields = buildFields(sf.Type, info.Path+".", visiting)
+		fields = append(fields, info)
+	}
+	return fields
+}
+
+// parseRangeTag parses "min,max"
+func parseRangeTag(tag string) (float64, float64, bool) {
+	lo, hi, found := strings.Cut(tag, ",")
+	if !found {
+		return 0, 0, false
+	}
+	min, errMin := strconv.ParseFloat(strings.TrimSpace(lo), 64)
+	max, errMax := strconv.ParseFloat(strings.TrimSpace(hi), 64)
+	if errMin != nil || errMax != nil {
+		return 0, 0, false
+	}
+	return min, max, true
+}
+
+// GetField reads a value from a live entity by path
+//
+// The path starts with the component name followed by field names and slice/array indices,
+// e.g. "Dynamics.Vel.X" or "motion.Dynamics.Vel.X". A path naming only the component returns
+// the whole component value
+// Nil pointers along the path read as the zero value of what they point to, the entity is never changed
+func GetField(entity Entity, path string) (any, error) {
+	val, _, err := resolveFieldPath(entity, path, false)
+	if err != nil {
+		return nil, err
+	}
+	return val.Interface(), nil
+}
+
+// SetField writes a value into a live entity by path, coercing it to the field type
+//
+// Coercion follows the deserialization rules (maps for structs, slices for arrays), converts
+// between numeric kinds and parses strings for numeric and boolean fields. Values outside a field's
+// `range:"min,max"` tag are rejected. Nil pointers along the path are allocated once the value is
+// known to be valid
+func SetField(entity Entity, path string, value any) error {
+	target, info, err := resolveFieldPath(entity, path, false)
+	if err != nil {
+		return err
+	}
+
+	value, err = coerceFieldValue(value, target.Type())
+	if err != nil {
+		return fmt.Errorf("field %s: %w", path, err)
+	}
+	converted, err := convertToType(value, target.Type())
+	if err != nil {
+		return fmt.Errorf("field %s: %w", path, err)
+	}
+	convertedVal := reflect.ValueOf(converted)
+	if !convertedVal.IsValid() {
+		convertedVal = reflect.Zero(target.Type())
+	} else if !convertedVal.Type().AssignableTo(target.Type()) {
+		if !convertedVal.CanConvert(target.Type()) {
+			return fmt.Errorf("field %s: cannot assign %T to %s", path, converted, target.Type())
+		}
+		convertedVal = convertedVal.Convert(target.Type())
+	}
+
+	if min, max, ok := info.Range(); ok && isNumeric(convertedVal) {
+		if f := numericValue(convertedVal); f < min || f > max {
+			return fmt.Errorf("field %s: value %v outside range [%v, %v]", path, f, min, max)
+		}
+	}
+
+	target, _, err = resolveFieldPath(entity, path, true)
+	if err != nil {
+		return err
+	}
+	if !target.CanSet() {
+		return fmt.Errorf("field %s is not settable", path)
+	}
+	target.Set(convertedVal)
+	return nil
+}
+
+// resolveFieldPath walks a path on a live entity and returns the addressed value
+// Nil pointers are allocated when resolving for a write, and read as zero values otherwise
+func resolveFieldPath(entity Entity, path string, write bool) (reflect.Value, FieldInfo, error) {
+	if entity == nil || !entity.Valid() {
+		return reflect.Value{}, FieldInfo{}, fmt.Errorf("invalid entity")
+	}
+	registry := entity.Storage().World().Registry()
+	comp, rest, err := registry.splitComponentPath(path)
+	if err != nil {
+		return reflect.Value{}, FieldInfo{}, err
+	}
+
+	tbl := entity.Table()
+	if !tbl.Contains(comp) {
+		return reflect.Value{}, FieldInfo{}, fmt.Errorf("entity %d has no %s component", entity.ID(), comp.Type())
+	}
+	val, err := tbl.Get(comp, entity.Index())
+	if err != nil {
+		return reflect.Value{}, FieldInfo{}, err
+	}
+
+	info := FieldInfo{Name: comp.Type().Name(), Type: comp.Type(), Exported: true, Fields: reflectFields(comp.Type())}
+	for _, segment := range rest {
+		for val.Kind() == reflect.Pointer {
+			if val.IsNil() {
+				if !write {
+					val = reflect.Zero(val.Type().Elem())
+					continue
+				}
+				if !val.CanSet() {
+					return reflect.Value{}, FieldInfo{}, fmt.Errorf("nil pointer at %s in %s", segment, path)
+				}
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+
+		switch val.Kind() {
+		case reflect.Struct:
+			next, ok := fieldInfo(info.Fields, segment)
+			if !ok {
+				return reflect.Value{}, FieldInfo{}, fmt.Errorf("unknown field %s in %s", segment, path)
+			}
+			if !next.Exported {
+				return reflect.Value{}, FieldInfo{}, fmt.Errorf("field %s in %s is unexported", segment, path)
+			}
+			val = val.FieldByName(segment)
+			info = next
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= val.Len() {
+				return reflect.Value{}, FieldInfo{}, fmt.Errorf("invalid index %s in %s (length %d)", segment, path, val.Len())
+			}
+			val = val.Index(i)
+			elem := val.Type()
+			info = FieldInfo{Name: segment, Type: elem, Exported: true, Fields: reflectFields(elem)}
+		default:
+			return reflect.Value{}, FieldInfo{}, fmt.Errorf("cannot descend into %s at %s in %s", val.Type(), segment, path)
+		}
+	}
+	return val, info, nil
+}
+
+func fieldInfo(fields []FieldInfo, name string) (FieldInfo, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return FieldInfo{}, false
+}
+
+// splitComponentPath separates the component name from the field segments of a path
+// The longest registered qualified name wins, otherwise the first segment is a bare type name
+func (r *TypeRegistry) splitComponentPath(path string) (Component, []string, error) {
+	segments := strings.Split(path, ".")
+	for i := len(segments); i > 0; i-- {
+		if comp, ok := r.LookupComp(strings.Join(segments[:i], ".")); ok {
+			return comp, segments[i:], nil
+		}
+	}
+	comp, err := r.resolveComponentName(segments[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	return comp, segments[1:], nil
+}
+
+// coerceFieldValue handles the conversions convertToType leaves out: strings parsed into numeric
+// and boolean targets, and any numeric kind into any other numeric kind
+// Numbers that overflow the target or lose a fractional part on the way to an integer are rejected
+func coerceFieldValue(value any, target reflect.Type) (any, error) {
+	if s, ok := value.(string); ok {
+		var err error
+		switch {
+		case target.Kind() == reflect.Bool:
+			return strconv.ParseBool(s)
+		case target.Kind() >= reflect.Int && target.Kind() <= reflect.Int64:
+			value, err = strconv.ParseInt(s, 10, 64)
+		case target.Kind() >= reflect.Uint && target.Kind() <= reflect.Uint64:
+			value, err = strconv.ParseUint(s, 10, 64)
+		case target.Kind() == reflect.Float32 || target.Kind() == reflect.Float64:
+			if s == strPosInf || s == strNegInf || s == strNaN {
+				return s, nil
+			}
+			value, err = strconv.ParseFloat(s, 64)
+		default:
+			return value, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if v := reflect.ValueOf(value); v.IsValid() && isNumeric(v) && isNumeric(reflect.Zero(target)) {
+		return convertNumeric(v, target)
+	}
+	return value, nil
+}
+
+// convertNumeric converts between numeric kinds, failing instead of wrapping or truncating
+func convertNumeric(v reflect.Value, target reflect.Type) (any, error) {
+	out := reflect.New(target).Elem()
+	switch {
+	case out.CanInt():
+		var n int64
+		switch {
+		case v.CanInt():
+			n = v.Int()
+		case v.CanUint():
+			if v.Uint() > math.MaxInt64 {
+				return nil, fmt.Errorf("value %v overflows %s", v.Uint(), target)
+			}
+			n = int64(v.Uint())
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) {
+				return nil, fmt.Errorf("value %v is not a whole number for %s", f, target)
+			}
+			// -2^63 is exact as a float64, 2^63 is the first value past MaxInt64
+			if f < math.MinInt64 || f >= -math.MinInt64 {
+				return nil, fmt.Errorf("value %v overflows %s", f, target)
+			}
+			n = int64(f)
+		}
+		if out.OverflowInt(n) {
+			return nil, fmt.Errorf("value %v overflows %s", n, target)
+		}
+		out.SetInt(n)
+	case out.CanUint():
+		var n uint64
+		switch {
+		case v.CanInt():
+			if v.Int() < 0 {
+				return nil, fmt.Errorf("value %v overflows %s", v.Int(), target)
+			}
+			n = uint64(v.Int())
+		case v.CanUint():
+			n = v.Uint()
+		default:
+			f := v.Float()
+			if f != math.Trunc(f) {
+				return nil, fmt.Errorf("value %v is not a whole number for %s", f, target)
+			}
+			if f < 0 || f >= 2*-math.MinInt64 {
+				return nil, fmt.Errorf("value %v overflows %s", f, target)
+			}
+			n = uint64(f)
+		}
+		if out.OverflowUint(n) {
+			return nil, fmt.Errorf("value %v overflows %s", n, target)
+		}
+		out.SetUint(n)
+	default:
+		f := numericValue(v)
+		if out.OverflowFloat(f) {
+			return nil, fmt.Errorf("value %v overflows %s", f, target)
+		}
+		out.SetFloat(f)
+	}
+	return out.Interface(), nil
+}
+
+func isNumeric(v reflect.Value) bool {
+	return v.CanFloat() || v.CanInt() || v.CanUint()
+}
+
+func numericValue(v reflect.Value) float64 {
+	switch {
+	case v.CanFloat():
+		return v.Float()
+	case v.CanInt():
+		return float64(v.Int())
+	}
+	return float64(v.Uint())
+}
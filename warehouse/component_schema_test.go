@@ -0,0 +1,214 @@
+package warehouse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+type Dynamics struct {
+	Vel    Velocity
+	Mass   float64 `range:"0,100"`
+	Points []Position
+	Target *Position
+	secret int
+}
+
+var dynamicsComp = FactoryNewComponent[Dynamics]()
+
+type Counters struct {
+	Small int8
+	Count uint16
+	Big   uint64
+	Ratio float32
+	Level int `range:"0,10"`
+}
+
+var countersComp = FactoryNewComponent[Counters]()
+
+// TestComponentSchema tests the reflected field metadata of a component
+func TestComponentSchema(t *testing.T) {
+	schema, err := GlobalTypeRegistry.SchemaByName("Dynamics")
+	if err != nil {
+		t.Fatalf("SchemaByName failed: %v", err)
+	}
+	if schema.Name != "warehouse.Dynamics" || schema.Type != reflect.TypeOf(Dynamics{}) {
+		t.Errorf("Unexpected schema identity: %s %v", schema.Name, schema.Type)
+	}
+
+	velX, ok := schema.Field("Vel.X")
+	if !ok || velX.Path != "Vel.X" || velX.Type.Kind() != reflect.Float64 {
+		t.Errorf("Unexpected Vel.X field: %+v", velX)
+	}
+	mass, _ := schema.Field("Mass")
+	if min, max, ok := mass.Range(); !ok || min != 0 || max != 100 {
+		t.Errorf("Mass range = %v, %v, %v", min, max, ok)
+	}
+	secret, ok := schema.Field("secret")
+	if !ok || secret.Exported {
+		t.Errorf("Expected unexported secret field, got %+v", secret)
+	}
+	if _, ok := schema.Field("Vel.Z"); ok {
+		t.Errorf("Found nonexistent field Vel.Z")
+	}
+	// Vel, Vel.X, Vel.Y, Mass, Points, Target, Target.X, Target.Y, secret
+	if got := len(schema.All()); got != 9 {
+		t.Errorf("All returned %d fields, want 9", got)
+	}
+}
+
+// TestFieldPaths tests reading and writing live entity fields by path
+func TestFieldPaths(t *testing.T) {
+	storage := Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(1, dynamicsComp)
+	if err != nil {
+		t.Fatalf("Failed to create entity: %v", err)
+	}
+	en := entities[0]
+	dynamicsComp.GetFromEntity(en).Points = make([]Position, 2)
+
+	tests := []struct {
+		path  string
+		value any
+		want  any
+	}{
+		{"Dynamics.Vel.X", 3.5, 3.5},
+		{"warehouse.Dynamics.Vel.Y", "-2", -2.0},
+		{"Dynamics.Mass", 42, 42.0},
+		{"Dynamics.Points.1.Y", float32(7), 7.0},
+		{"Dynamics.Target.X", 1, 1.0},
+		{"Dynamics.Vel", map[string]interface{}{"X": 8.0, "Y": 9.0}, Velocity{X: 8, Y: 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if err := SetField(en, tt.path, tt.value); err != nil {
+				t.Fatalf("SetField failed: %v", err)
+			}
+			got, err := GetField(en, tt.path)
+			if err != nil {
+				t.Fatalf("GetField failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetField = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	dyn := dynamicsComp.GetFromEntity(en)
+	if dyn.Points[1].Y != 7 || dyn.Target == nil || dyn.Target.X != 1 {
+		t.Errorf("Writes did not reach the live component: %+v", *dyn)
+	}
+
+	errorPaths := []struct {
+		path  string
+		value any
+	}{
+		{"Dynamics.Mass", 101},
+		{"Dynamics.secret", 1},
+		{"Dynamics.Vel.Z", 1},
+		{"Dynamics.Points.5.X", 1},
+		{"Dynamics.Vel.X", "fast"},
+		{"Missing.X", 1},
+		{"Position.X", 1},
+	}
+	for _, tt := range errorPaths {
+		if err := SetField(en, tt.path, tt.value); err == nil {
+			t.Errorf("SetField(%s, %v) succeeded, expected error", tt.path, tt.value)
+		}
+	}
+}
+
+// TestFieldPathNilPointers tests that only successful writes allocate nil pointers along a path
+func TestFieldPathNilPointers(t *testing.T) {
+	storage := Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(1, dynamicsComp)
+	if err != nil {
+		t.Fatalf("Failed to create entity: %v", err)
+	}
+	en := entities[0]
+	dyn := dynamicsComp.GetFromEntity(en)
+
+	if got, err := GetField(en, "Dynamics.Target.X"); err != nil || got != 0.0 {
+		t.Errorf("GetField through a nil pointer = %v, %v, want 0", got, err)
+	}
+	if got, err := GetField(en, "Dynamics.Target"); err != nil || got != (*Position)(nil) {
+		t.Errorf("GetField of a nil pointer = %#v, %v, want nil", got, err)
+	}
+	if err := SetField(en, "Dynamics.Target.X", "fast"); err == nil {
+		t.Error("SetField with an invalid value succeeded")
+	}
+	if dyn.Target != nil {
+		t.Fatalf("Reads or a failed write allocated the pointer: %+v", *dyn.Target)
+	}
+
+	if err := SetField(en, "Dynamics.Target.Y", 4); err != nil {
+		t.Fatalf("SetField failed: %v", err)
+	}
+	if dyn.Target == nil || dyn.Target.Y != 4 {
+		t.Errorf("Write through a nil pointer did not allocate it: %+v", dyn.Target)
+	}
+}
+
+// TestFieldPathConversions tests that numeric writes fail instead of wrapping, truncating or overflowing
+func TestFieldPathConversions(t *testing.T) {
+	storage := Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(1, countersComp)
+	if err != nil {
+		t.Fatalf("Failed to create entity: %v", err)
+	}
+	en := entities[0]
+
+	valid := []struct {
+		path  string
+		value any
+		want  any
+	}{
+		{"Counters.Small", -128, int8(-128)},
+		{"Counters.Small", 127.0, int8(127)},
+		{"Counters.Small", "-5", int8(-5)},
+		{"Counters.Count", uint64(65535), uint16(65535)},
+		{"Counters.Count", "300", uint16(300)},
+		{"Counters.Big", float64(1 << 63), uint64(1 << 63)},
+		{"Counters.Ratio", 1e38, float32(1e38)},
+		{"Counters.Level", 10.0, 10},
+	}
+	for _, tt := range valid {
+		if err := SetField(en, tt.path, tt.value); err != nil {
+			t.Errorf("SetField(%s, %v) failed: %v", tt.path, tt.value, err)
+			continue
+		}
+		if got, _ := GetField(en, tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SetField(%s, %v) stored %#v, want %#v", tt.path, tt.value, got, tt.want)
+		}
+	}
+
+	before := *countersComp.GetFromEntity(en)
+	invalid := []struct {
+		path  string
+		value any
+	}{
+		{"Counters.Small", 128},
+		{"Counters.Small", -129.0},
+		{"Counters.Small", "300"},
+		{"Counters.Small", 1.5},
+		{"Counters.Count", -1},
+		{"Counters.Count", 65536},
+		{"Counters.Count", "-1"},
+		{"Counters.Big", -1.0},
+		{"Counters.Big", 1e20},
+		{"Counters.Ratio", 1e39},
+		{"Counters.Level", 10.5},
+		{"Counters.Level", 9.99},
+		{"Counters.Level", 1e19},
+	}
+	for _, tt := range invalid {
+		if err := SetField(en, tt.path, tt.value); err == nil {
+			got, _ := GetField(en, tt.path)
+			t.Errorf("SetField(%s, %v) succeeded with %#v, expected error", tt.path, tt.value, got)
+		}
+	}
+	if after := *countersComp.GetFromEntity(en); after != before {
+		t.Errorf("Rejected writes changed the component: %+v, want %+v", after, before)
+	}
+}
@@ -1,15 +1,35 @@
 package warehouse
 
-import "github.com/TheBitDrifter/bappa/table"
+import (
+	"github.com/TheBitDrifter/bappa/table"
+	"github.com/TheBitDrifter/bark"
+)
 
 // Config holds global configuration for the table system
-var Config config = config{}
+var Config config = config{
+	operationErrorHandler: logOperationError,
+}
 
 type config struct {
-	tableEvents table.TableEvents
+	tableEvents           table.TableEvents
+	operationErrorHandler func(error)
 }
 
 // SetTableEvents configures the table event callbacks
 func (c *config) SetTableEvents(te table.TableEvents) {
 	c.tableEvents = te
 }
+
+// SetOperationErrorHandler configures how failures from automatically processed operation queues are reported
+// By default failures are logged, passing nil restores the default
+func (c *config) SetOperationErrorHandler(handler func(error)) {
+	if handler == nil {
+		handler = logOperationError
+	}
+	c.operationErrorHandler = handler
+}
+
+// logOperationError is the default operation error handler
+func logOperationError(err error) {
+	bark.For("warehouse.operations").Error("queued operations failed", "error", err)
+}
@@ -69,6 +69,7 @@ type EntityDestroyCallback func(Entity)
 // entity implements the Entity interface
 type entity struct {
 	table.Entry
+	world      *World
 	id         table.EntryID
 	sto        Storage
 	components []Component
@@ -118,7 +119,7 @@ func (e *entity) AddComponent(c Component) error {
 	}
 
 	e.components = append(e.components, c)
-	destArchetype, err := e.sto.NewOrExistingArchetype(e.components...)
+	destArchetype, err := e.sto.transitionArchetype(originTable, c, true, e.components)
 	if err != nil {
 		return err
 	}
@@ -126,8 +127,9 @@ func (e *entity) AddComponent(c Component) error {
 		return err
 	}
 
-	e.Entry = globalEntryIndex.Entries()[e.id-1]
-	globalEntities[e.id-1] = *e
+	e.Entry = e.world.entryIndex.Entries()[e.id-1]
+	e.world.entities[e.id-1] = *e
+	e.sto.SpatialIndexes().entitiesChanged(e)
 
 	return nil
 }
@@ -152,7 +154,7 @@ func (e *entity) AddComponentWithValue(c Component, value any) error {
 
 	e.components = append(e.components, c)
 
-	destArchetype, err := e.sto.NewOrExistingArchetype(e.components...)
+	destArchetype, err := e.sto.transitionArchetype(originTable, c, true, e.components)
 	if err != nil {
 		return err
 	}
@@ -163,7 +165,8 @@ func (e *entity) AddComponentWithValue(c Component, value any) error {
 	valueType := reflect.TypeOf(value)
 	for _, row := range destArchetype.Table().Rows() {
 		if row.Type().Elem() == valueType {
-			reflect.Value(row).Index(e.Index()).Set(reflect.ValueOf(value))
+			reflect.Value(row).Index(e.Index()).Set(componentValue(value))
+			e.sto.SpatialIndexes().entitiesChanged(e)
 			return nil
 		}
 	}
@@ -187,7 +190,7 @@ func (e *entity) RemoveComponent(c Component) error {
 		}
 	}
 	e.components = newComps
-	destArchetype, err := e.sto.NewOrExistingArchetype(newComps...)
+	destArchetype, err := e.sto.transitionArchetype(originTable, c, false, newComps)
 	if err != nil {
 		return fmt.Errorf("failed to get/create archetype: %w", err)
 	}
@@ -195,8 +198,9 @@ func (e *entity) RemoveComponent(c Component) error {
 		return fmt.Errorf("failed to transfer entity: %w", err)
 	}
 
-	e.Entry = globalEntryIndex.Entries()[e.id-1]
-	globalEntities[e.id-1] = *e
+	e.Entry = e.world.entryIndex.Entries()[e.id-1]
+	e.world.entities[e.id-1] = *e
+	e.sto.SpatialIndexes().entitiesChanged(e)
 
 	return nil
 }
@@ -246,7 +250,7 @@ func (e *entity) EnqueueRemoveComponent(c Component) error {
 
 // entry returns the table entry for this entity
 func (e *entity) entry() table.Entry {
-	en, err := globalEntryIndex.Entry(int(e.id - 1))
+	en, err := e.world.entryIndex.Entry(int(e.id - 1))
 	if err != nil {
 		panic(bark.AddTrace(err))
 	}
@@ -285,7 +289,7 @@ func (e *entity) Valid() bool {
 		return false
 	}
 	globalIndex := int(e.ID() - 1)
-	currentIndexData, err := globalEntryIndex.Entry(globalIndex)
+	currentIndexData, err := e.world.entryIndex.Entry(globalIndex)
 	if err != nil {
 		return false // Entry doesn't exist in the index.
 	}
@@ -313,7 +317,7 @@ func (e *entity) Serialize() SerializedEntity {
 	serializedEntity.Components = make([]string, 0, len(components))
 
 	for _, comp := range components {
-		typeName, ok := GlobalTypeRegistry.LookupName(comp)
+		typeName, ok := e.world.registry.LookupName(comp)
 		if !ok {
 			typeName = comp.Type().String()
 		}
@@ -342,7 +346,7 @@ func (e *entity) SerializeInclude(comps ...Component) SerializedEntity {
 	includeMap := make(map[string]bool, len(comps))
 
 	for _, filterComp := range comps {
-		typeName, ok := GlobalTypeRegistry.LookupName(filterComp)
+		typeName, ok := e.world.registry.LookupName(filterComp)
 		if !ok {
 			typeName = filterComp.Type().String()
 			log.Printf("SerializeInclude Warning: Component type %T not found in registry, using reflection name '%s' for filter.", filterComp, typeName)
@@ -359,7 +363,7 @@ func (e *entity) SerializeInclude(comps ...Component) SerializedEntity {
 
 	attachedComponents := e.Components()
 	for _, attachedComp := range attachedComponents {
-		typeName, ok := GlobalTypeRegistry.LookupName(attachedComp)
+		typeName, ok := e.world.registry.LookupName(attachedComp)
 		if !ok {
 			typeName = attachedComp.Type().String()
 		}
@@ -403,7 +407,7 @@ func (e *entity) SerializeInclude(comps ...Component) SerializedEntity {
 func (e *entity) SerializeExclude(comps ...Component) SerializedEntity {
 	excludeMap := make(map[string]bool, len(comps))
 	for _, filterComp := range comps {
-		typeName, ok := GlobalTypeRegistry.LookupName(filterComp)
+		typeName, ok := e.world.registry.LookupName(filterComp)
 		if !ok {
 			typeName = filterComp.Type().String()
 			log.Printf("SerializeExclude Warning: Component type %T not found in registry, using reflection name '%s' for filter.", filterComp, typeName)
@@ -421,7 +425,7 @@ func (e *entity) SerializeExclude(comps ...Component) SerializedEntity {
 
 	attachedComponents := e.Components()
 	for _, attachedComp := range attachedComponents {
-		typeName, ok := GlobalTypeRegistry.LookupName(attachedComp)
+		typeName, ok := e.world.registry.LookupName(attachedComp)
 		if !ok {
 			typeName = attachedComp.Type().String()
 		}
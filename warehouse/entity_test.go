@@ -19,6 +19,14 @@ type Health struct {
 	Current, Max int
 }
 
+// Shared test components
+// Every FactoryNewComponent call registers a new element type, and the default mask holds 64 of them per process
+var (
+	sharedPosComp    = FactoryNewComponent[Position]()
+	sharedVelComp    = FactoryNewComponent[Velocity]()
+	sharedHealthComp = FactoryNewComponent[Health]()
+)
+
 func TestEntityCreation(t *testing.T) {
 	// Create component instances once to reuse
 	posComp := FactoryNewComponent[Position]()
@@ -208,3 +216,50 @@ func TestComponentValues(t *testing.T) {
 		t.Errorf("Updated Velocity = {%v, %v}, want {7.0, 8.0}", velPtr2.X, velPtr2.Y)
 	}
 }
+
+type Inventory struct {
+	Items []int
+}
+
+func (inv Inventory) CloneComponent() any {
+	inv.Items = append([]int(nil), inv.Items...)
+	return inv
+}
+
+func TestComponentCloner(t *testing.T) {
+	schema := table.Factory.NewSchema()
+	storage := Factory.NewStorage(schema)
+	inventoryComp := FactoryNewComponent[Inventory]()
+
+	archetype, err := storage.NewOrExistingArchetype(inventoryComp)
+	if err != nil {
+		t.Fatalf("Failed to create archetype: %v", err)
+	}
+	template := Inventory{Items: []int{1, 2, 3}}
+	entities, err := archetype.GenerateAndReturnEntity(2, template)
+	if err != nil {
+		t.Fatalf("Failed to generate entities: %v", err)
+	}
+
+	first := inventoryComp.GetFromEntity(entities[0])
+	first.Items[0] = 10
+
+	if second := inventoryComp.GetFromEntity(entities[1]); second.Items[0] != 1 {
+		t.Errorf("Second entity shares items with the first: %v", second.Items)
+	}
+	if template.Items[0] != 1 {
+		t.Errorf("Template items were modified: %v", template.Items)
+	}
+
+	entity, err := storage.NewEntities(1, sharedPosComp)
+	if err != nil {
+		t.Fatalf("Failed to create entity: %v", err)
+	}
+	if err := entity[0].AddComponentWithValue(inventoryComp, template); err != nil {
+		t.Fatalf("Failed to add inventory: %v", err)
+	}
+	inventoryComp.GetFromEntity(entity[0]).Items[1] = 20
+	if template.Items[1] != 2 {
+		t.Errorf("AddComponentWithValue stored the template's items: %v", template.Items)
+	}
+}
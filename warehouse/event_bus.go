@@ -0,0 +1,191 @@
+package warehouse
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// EventBus holds the typed event channels of a storage
+//
+// Events are double buffered: an event emitted during a tick stays readable through the end of
+// the following tick, so readers that run before the emitter within a tick still see it once.
+// Update swaps the buffers and is called once per tick by the scene runners
+type EventBus struct {
+	mu       sync.Mutex
+	channels map[reflect.Type]eventChannel
+}
+
+// EventReader tracks which events of a type a single consumer has already read
+// The zero value reads every event still buffered. A reader moved to another storage starts over there
+type EventReader[T any] struct {
+	next uint64
+}
+
+// SerializedEvent is the wire form of an event registered with RegisterSerializableEvent
+type SerializedEvent struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// eventChannel is the type erased view of an eventQueue used by the bus
+type eventChannel interface {
+	update()
+	serializePending(name string) ([]SerializedEvent, error)
+}
+
+// eventQueue stores the events of one type in two buffers
+// Every event gets a sequence number, previous holds [prevStart, currStart) and current holds [currStart, currStart+len(current))
+type eventQueue[T any] struct {
+	previous, current    []T
+	prevStart, currStart uint64
+}
+
+func newEventBus() *EventBus {
+	return &EventBus{channels: make(map[reflect.Type]eventChannel)}
+}
+
+// Update swaps the buffers of every channel, dropping the events emitted two ticks ago
+func (b *EventBus) Update() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, ch := range b.channels {
+		ch.update()
+	}
+}
+
+// SerializePending returns the events emitted since the last Update whose types were
+// registered with RegisterSerializableEvent
+func (b *EventBus) SerializePending() ([]SerializedEvent, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	result := []SerializedEvent{}
+	for typ, ch := range b.channels {
+		name, ok := serializableEventName(typ)
+		if !ok {
+			continue
+		}
+		events, err := ch.serializePending(name)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, events...)
+	}
+	return result, nil
+}
+
+// EmitSerialized decodes a serialized event and emits it on the bus
+func (b *EventBus) EmitSerialized(event SerializedEvent) error {
+	emit, ok := serializableEventEmitter(event.Type)
+	if !ok {
+		return fmt.Errorf("event type %s is not registered", event.Type)
+	}
+	return emit(b, event.Data)
+}
+
+// Emit queues an event on the storage's bus
+func Emit[T any](sto Storage, event T) {
+	bus := sto.Events()
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	q := queueFor[T](bus)
+	q.current = append(q.current, event)
+}
+
+// Read returns the events of type T the reader has not seen yet and advances its cursor
+// Events emitted more than one Update ago are gone, even if the reader never saw them
+func Read[T any](sto Storage, reader *EventReader[T]) []T {
+	bus := sto.Events()
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	q := queueFor[T](bus)
+
+	result := []T{}
+	end := q.currStart + uint64(len(q.current))
+	// A cursor past the end comes from another storage (or a swapped scene), so start over like a new reader
+	if reader.next < q.prevStart || reader.next > end {
+		reader.next = q.prevStart
+	}
+	if reader.next < q.currStart {
+		result = append(result, q.previous[reader.next-q.prevStart:]...)
+		reader.next = q.currStart
+	}
+	result = append(result, q.current[reader.next-q.currStart:]...)
+	reader.next = end
+	return result
+}
+
+// queueFor returns the queue for T, creating it on first use
+// The caller must hold the bus lock
+func queueFor[T any](bus *EventBus) *eventQueue[T] {
+	typ := reflect.TypeFor[T]()
+	if ch, ok := bus.channels[typ]; ok {
+		return ch.(*eventQueue[T])
+	}
+	q := &eventQueue[T]{}
+	bus.channels[typ] = q
+	return q
+}
+
+func (q *eventQueue[T]) update() {
+	q.prevStart = q.currStart
+	q.currStart += uint64(len(q.current))
+	// Reuse the dropped buffer for the next tick
+	q.previous, q.current = q.current, q.previous[:0]
+}
+
+func (q *eventQueue[T]) serializePending(name string) ([]SerializedEvent, error) {
+	result := make([]SerializedEvent, 0, len(q.current))
+	for _, event := range q.current {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize event %s: %w", name, err)
+		}
+		result = append(result, SerializedEvent{Type: name, Data: data})
+	}
+	return result, nil
+}
+
+// serializableEvents maps registered event names and types in both directions
+var serializableEvents = struct {
+	sync.RWMutex
+	names    map[reflect.Type]string
+	emitters map[string]func(*EventBus, json.RawMessage) error
+}{
+	names:    make(map[reflect.Type]string),
+	emitters: make(map[string]func(*EventBus, json.RawMessage) error),
+}
+
+// RegisterSerializableEvent marks events of type T as serializable under the name
+// Only registered events are returned by SerializePending, which drip uses to forward events to clients
+func RegisterSerializableEvent[T any](name string) {
+	serializableEvents.Lock()
+	defer serializableEvents.Unlock()
+	serializableEvents.names[reflect.TypeFor[T]()] = name
+	serializableEvents.emitters[name] = func(bus *EventBus, data json.RawMessage) error {
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			return fmt.Errorf("failed to deserialize event %s: %w", name, err)
+		}
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+		q := queueFor[T](bus)
+		q.current = append(q.current, event)
+		return nil
+	}
+}
+
+func serializableEventName(typ reflect.Type) (string, bool) {
+	serializableEvents.RLock()
+	defer serializableEvents.RUnlock()
+	name, ok := serializableEvents.names[typ]
+	return name, ok
+}
+
+func serializableEventEmitter(name string) (func(*EventBus, json.RawMessage) error, bool) {
+	serializableEvents.RLock()
+	defer serializableEvents.RUnlock()
+	emit, ok := serializableEvents.emitters[name]
+	return emit, ok
+}
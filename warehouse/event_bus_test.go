@@ -0,0 +1,104 @@
+package warehouse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+type hitEvent struct {
+	Target int
+	Damage int
+}
+
+// TestEventBusReaders tests per reader cursors and double buffered clearing
+func TestEventBusReaders(t *testing.T) {
+	storage := Factory.NewStorage(table.Factory.NewSchema())
+	early, late := &EventReader[hitEvent]{}, &EventReader[hitEvent]{}
+
+	Emit(storage, hitEvent{Target: 1, Damage: 5})
+	Emit(storage, hitEvent{Target: 2, Damage: 3})
+
+	if got := Read(storage, early); len(got) != 2 || got[0].Target != 1 {
+		t.Fatalf("First read = %v, want both events", got)
+	}
+	if got := Read(storage, early); len(got) != 0 {
+		t.Errorf("Second read = %v, want none", got)
+	}
+
+	storage.Events().Update()
+	Emit(storage, hitEvent{Target: 3, Damage: 1})
+
+	// The late reader still sees last tick's events alongside this tick's
+	if got := Read(storage, late); len(got) != 3 {
+		t.Errorf("Late read = %v, want 3 events", got)
+	}
+	if got := Read(storage, early); len(got) != 1 || got[0].Target != 3 {
+		t.Errorf("Early read = %v, want only the new event", got)
+	}
+
+	storage.Events().Update()
+	storage.Events().Update()
+	if got := Read(storage, &EventReader[hitEvent]{}); len(got) != 0 {
+		t.Errorf("Read after two updates = %v, want none", got)
+	}
+}
+
+// TestEventBusReaderReuse tests a reader shared across storages, as after a scene swap
+func TestEventBusReaderReuse(t *testing.T) {
+	busy := Factory.NewStorage(table.Factory.NewSchema())
+	quiet := Factory.NewStorage(table.Factory.NewSchema())
+	reader := &EventReader[hitEvent]{}
+
+	Emit(busy, hitEvent{Target: 1})
+	Emit(busy, hitEvent{Target: 2})
+	if got := Read(busy, reader); len(got) != 2 {
+		t.Fatalf("Read busy = %v, want 2 events", got)
+	}
+
+	if got := Read(quiet, reader); len(got) != 0 {
+		t.Errorf("Read empty quiet = %v, want none", got)
+	}
+	Emit(quiet, hitEvent{Target: 3})
+	if got := Read(quiet, reader); len(got) != 1 || got[0].Target != 3 {
+		t.Errorf("Read quiet = %v, want the new event", got)
+	}
+
+	// The cursor is shared, so only the new event is guaranteed (and nothing may panic)
+	Emit(busy, hitEvent{Target: 4})
+	if got := Read(busy, reader); len(got) == 0 || got[len(got)-1].Target != 4 {
+		t.Errorf("Read busy again = %v, want it to end with the new event", got)
+	}
+}
+
+// TestEventBusSerialization tests forwarding registered events between buses
+func TestEventBusSerialization(t *testing.T) {
+	RegisterSerializableEvent[hitEvent]("hit")
+	server := Factory.NewStorage(table.Factory.NewSchema())
+	client := Factory.NewStorage(table.Factory.NewSchema())
+
+	Emit(server, hitEvent{Target: 7, Damage: 2})
+	Emit(server, "unregistered events stay local")
+
+	pending, err := server.Events().SerializePending()
+	if err != nil {
+		t.Fatalf("SerializePending failed: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Type != "hit" {
+		t.Fatalf("SerializePending = %v, want one hit event", pending)
+	}
+	for _, ev := range pending {
+		if err := client.Events().EmitSerialized(ev); err != nil {
+			t.Fatalf("EmitSerialized failed: %v", err)
+		}
+	}
+
+	got := Read(client, &EventReader[hitEvent]{})
+	if want := []hitEvent{{Target: 7, Damage: 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Client read %v, want %v", got, want)
+	}
+	if err := client.Events().EmitSerialized(SerializedEvent{Type: "missing"}); err == nil {
+		t.Errorf("EmitSerialized accepted an unregistered type")
+	}
+}
@@ -16,9 +16,14 @@ var (
 	factoryLogger = bark.For("warehouse.factory")
 )
 
-// NewStorage creates a new Storage instance with the given schema.
+// NewStorage creates a new Storage instance with the given schema in the DefaultWorld.
 func (f factory) NewStorage(schema table.Schema) Storage {
-	return newStorage(schema)
+	return newStorage(schema, DefaultWorld)
+}
+
+// NewStorageInWorld creates a new Storage instance with the given schema in the provided world.
+func (f factory) NewStorageInWorld(schema table.Schema, world *World) Storage {
+	return newStorage(schema, world)
 }
 
 // NewQuery creates a new Query instance.
@@ -31,6 +36,13 @@ func (f factory) NewCursor(query QueryNode, storage Storage) *Cursor {
 	return newCursor(query, storage)
 }
 
+// NewSpatialIndex creates a uniform grid SpatialIndex over storage for entities matching tracked.
+// Bounds are read through the provided BoundsFunc whenever an entity is synced or updated.
+// The index starts empty, attach it through the storage's SpatialIndexes and Sync it once.
+func (f factory) NewSpatialIndex(storage Storage, cellSize float64, tracked QueryNode, bounds BoundsFunc) SpatialIndex {
+	return newUniformGrid(storage, cellSize, tracked, bounds)
+}
+
 // FactoryNewComponent creates a new AccessibleComponent for type T.
 func FactoryNewComponent[T any]() AccessibleComponent[T] {
 	iden := table.FactoryNewElementType[T]()
@@ -56,3 +68,12 @@ func FactoryNewCache[T any](cap int) Cache[T] {
 		maxCapacity: cap,
 	}
 }
+
+// FactoryNewLRUCache creates a new RefCountedCache with the specified capacity.
+func FactoryNewLRUCache[T any](cap int) RefCountedCache[T] {
+	return &LRUCache[T]{
+		entries:     make([]*lruEntry[T], 0, cap),
+		itemIndices: make(map[string]int),
+		maxCapacity: cap,
+	}
+}
@@ -0,0 +1,452 @@
+/*
+Package inspector provides an opt-in HTTP/JSON debug server for live warehouse storages.
+
+Requests are accepted on a loopback address only and are executed on the game loop
+goroutine whenever Process is called, so handlers never race with systems mutating
+the storage. Process is typically called once per tick by a server or client system.
+
+Endpoints:
+
+	GET /storages                                      list registered storages
+	GET /storages/{name}                               archetypes with their components and table sizes
+	GET /storages/{name}/entities                      entity IDs and component names
+	GET /storages/{name}/entities/{id}                 serialized entity with component values
+	PUT /storages/{name}/entities/{id}                 update component values, body: {"data": {"pkg.Comp": {...}}}
+	GET /storages/{name}/entities/{id}/fields/{path}   single field value, path like Dynamics.Vel.X
+	PUT /storages/{name}/entities/{id}/fields/{path}   set a single field, body: {"value": ...}
+*/
+package inspector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+// DefaultAddr is the address used when NewServer is given an empty address
+const DefaultAddr = "127.0.0.1:6061"
+
+// RequestTimeout bounds how long a request waits for Process to pick it up
+var RequestTimeout = 2 * time.Second
+
+// Server serves a JSON view of registered storages
+type Server struct {
+	addr string
+
+	mu       sync.RWMutex
+	storages map[string]warehouse.Storage
+	// active holds the names registered through RegisterActive
+	active map[string]bool
+
+	jobs       chan job
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// job is a request handler deferred to the game loop
+type job struct {
+	run    func() (any, int, error)
+	result chan jobResult
+	// state moves from jobPending to either jobRunning in Process or jobCancelled in the handler
+	state *atomic.Int32
+}
+
+const (
+	jobPending int32 = iota
+	jobRunning
+	jobCancelled
+)
+
+type jobResult struct {
+	body   any
+	status int
+	err    error
+}
+
+// ArchetypeView describes a single archetype table
+type ArchetypeView struct {
+	ID         uint32   `json:"id"`
+	Components []string `json:"components"`
+	Size       int      `json:"size"`
+}
+
+// StorageView describes a registered storage
+type StorageView struct {
+	Name       string          `json:"name"`
+	Entities   int             `json:"entities"`
+	Archetypes []ArchetypeView `json:"archetypes,omitempty"`
+}
+
+// EntityView is a lightweight listing entry for an entity
+type EntityView struct {
+	ID         uint32   `json:"id"`
+	Recycled   int      `json:"recycled"`
+	Components []string `json:"components"`
+}
+
+// EntityUpdate is the request body accepted when editing an entity
+type EntityUpdate struct {
+	Data map[string]any `json:"data"`
+}
+
+// FieldView is a single component field, used both as response and as PUT request body
+type FieldView struct {
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// NewServer creates an inspector bound to addr, which must resolve to a loopback address
+func NewServer(addr string) (*Server, error) {
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inspector address %q: %w", addr, err)
+	}
+	if host != "localhost" {
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return nil, fmt.Errorf("inspector address %q is not a loopback address", addr)
+		}
+	}
+
+	s := &Server{
+		addr:     addr,
+		storages: make(map[string]warehouse.Storage),
+		active:   make(map[string]bool),
+		jobs:     make(chan job, 16),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /storages", s.deferred(s.listStorages))
+	mux.HandleFunc("GET /storages/{name}", s.deferred(s.describeStorage))
+	mux.HandleFunc("GET /storages/{name}/entities", s.deferred(s.listEntities))
+	mux.HandleFunc("GET /storages/{name}/entities/{id}", s.deferred(s.getEntity))
+	mux.HandleFunc("PUT /storages/{name}/entities/{id}", s.deferred(s.updateEntity))
+	mux.HandleFunc("GET /storages/{name}/entities/{id}/fields/{path}", s.deferred(s.getField))
+	mux.HandleFunc("PUT /storages/{name}/entities/{id}/fields/{path}", s.deferred(s.updateField))
+	s.httpServer = &http.Server{Handler: mux}
+	return s, nil
+}
+
+// Register exposes a storage under the given name, replacing any previous registration
+func (s *Server) Register(name string, sto warehouse.Storage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storages[name] = sto
+	delete(s.active, name)
+}
+
+// RegisterActive exposes the given storages and removes the ones a previous call exposed that are
+// no longer given, so systems can pass the active scenes each tick without pinning unloaded ones
+// Storages exposed through Register are left alone
+func (s *Server) RegisterActive(storages map[string]warehouse.Storage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name := range s.active {
+		if _, ok := storages[name]; !ok {
+			delete(s.storages, name)
+			delete(s.active, name)
+		}
+	}
+	for name, sto := range storages {
+		s.storages[name] = sto
+		s.active[name] = true
+	}
+}
+
+// Unregister removes a storage from the inspector
+func (s *Server) Unregister(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.storages, name)
+	delete(s.active, name)
+}
+
+// Start begins listening in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("inspector failed to listen on %s: %w", s.addr, err)
+	}
+	s.listener = listener
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("inspector stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Addr returns the address the server is listening on
+func (s *Server) Addr() string {
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+// Stop shuts the server down
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	return s.httpServer.Shutdown(ctx)
+}
+
+// Process runs all pending requests on the calling goroutine
+// It must be called from the goroutine that owns the registered storages
+func (s *Server) Process() {
+	for {
+		select {
+		case j := <-s.jobs:
+			// The handler gave up on it and already answered
+			if !j.state.CompareAndSwap(jobPending, jobRunning) {
+				continue
+			}
+			body, status, err := j.run()
+			j.result <- jobResult{body: body, status: status, err: err}
+		default:
+			return
+		}
+	}
+}
+
+// deferred wraps a handler so it runs during the next Process call
+func (s *Server) deferred(fn func(*http.Request) (any, int, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		j := job{
+			run:    func() (any, int, error) { return fn(r) },
+			result: make(chan jobResult, 1),
+			state:  new(atomic.Int32),
+		}
+
+		timeout := time.NewTimer(RequestTimeout)
+		defer timeout.Stop()
+
+		select {
+		case s.jobs <- j:
+		case <-timeout.C:
+			writeError(w, http.StatusServiceUnavailable, errors.New("inspector queue is full"))
+			return
+		}
+
+		var res jobResult
+		select {
+		case res = <-j.result:
+		case <-timeout.C:
+			// Cancel the job so a request reported as failed is never applied later
+			if j.state.CompareAndSwap(jobPending, jobCancelled) {
+				writeError(w, http.StatusServiceUnavailable, errors.New("inspector is not being processed, is Process called each tick?"))
+				return
+			}
+			// Process already started it, its result is on the way
+			res = <-j.result
+		}
+		if res.err != nil {
+			writeError(w, res.status, res.err)
+			return
+		}
+		writeJSON(w, res.status, res.body)
+	}
+}
+
+func (s *Server) storage(name string) (warehouse.Storage, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sto, ok := s.storages[name]
+	if !ok {
+		return nil, fmt.Errorf("storage %q not found", name)
+	}
+	return sto, nil
+}
+
+func (s *Server) entity(r *http.Request) (warehouse.Storage, warehouse.Entity, int, error) {
+	sto, err := s.storage(r.PathValue("name"))
+	if err != nil {
+		return nil, nil, http.StatusNotFound, err
+	}
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		return nil, nil, http.StatusBadRequest, fmt.Errorf("invalid entity id %q", r.PathValue("id"))
+	}
+	en, err := sto.Entity(id)
+	if err != nil || !en.Valid() || en.Storage() != sto {
+		return nil, nil, http.StatusNotFound, fmt.Errorf("entity %d not found in storage %q", id, r.PathValue("name"))
+	}
+	return sto, en, http.StatusOK, nil
+}
+
+func (s *Server) listStorages(*http.Request) (any, int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	views := make([]StorageView, 0, len(s.storages))
+	for name, sto := range s.storages {
+		views = append(views, StorageView{Name: name, Entities: sto.TotalEntities()})
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].Name < views[j].Name })
+	return views, http.StatusOK, nil
+}
+
+func (s *Server) describeStorage(r *http.Request) (any, int, error) {
+	name := r.PathValue("name")
+	sto, err := s.storage(name)
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	view := StorageView{Name: name, Entities: sto.TotalEntities()}
+	for _, arche := range sto.Archetypes() {
+		av := ArchetypeView{ID: arche.ID(), Size: arche.Table().Length()}
+		for et := range arche.Table().ElementTypes() {
+			av.Components = append(av.Components, componentName(sto, et))
+		}
+		sort.Strings(av.Components)
+		view.Archetypes = append(view.Archetypes, av)
+	}
+	return view, http.StatusOK, nil
+}
+
+func (s *Server) listEntities(r *http.Request) (any, int, error) {
+	sto, err := s.storage(r.PathValue("name"))
+	if err != nil {
+		return nil, http.StatusNotFound, err
+	}
+
+	entities := sto.Entities()
+	views := make([]EntityView, 0, len(entities))
+	for _, en := range entities {
+		if !en.Valid() {
+			continue
+		}
+		view := EntityView{ID: uint32(en.ID()), Recycled: en.Recycled()}
+		for _, comp := range en.Components() {
+			view.Components = append(view.Components, componentName(sto, comp))
+		}
+		views = append(views, view)
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].ID < views[j].ID })
+	return views, http.StatusOK, nil
+}
+
+func (s *Server) getEntity(r *http.Request) (any, int, error) {
+	_, en, status, err := s.entity(r)
+	if err != nil {
+		return nil, status, err
+	}
+	prepared, err := warehouse.PrepareForJSONMarshal(en.Serialize())
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return prepared, http.StatusOK, nil
+}
+
+func (s *Server) updateEntity(r *http.Request) (any, int, error) {
+	sto, en, status, err := s.entity(r)
+	if err != nil {
+		return nil, status, err
+	}
+	if sto.Locked() {
+		return nil, http.StatusConflict, errors.New("storage is locked")
+	}
+
+	var update EntityUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err)
+	}
+	// Validate every component before SetValue, which skips components the entity lacks
+	registry := sto.World().Registry()
+	for compName := range update.Data {
+		comp, ok := registry.LookupComp(compName)
+		if !ok {
+			return nil, http.StatusBadRequest, fmt.Errorf("unknown component %q", compName)
+		}
+		if !en.Table().Contains(comp) {
+			return nil, http.StatusBadRequest, fmt.Errorf("entity %d has no component %q", en.ID(), compName)
+		}
+	}
+
+	se := warehouse.SerializedEntity{
+		ID:       en.ID(),
+		Recycled: en.Recycled(),
+		Data:     update.Data,
+	}
+	if err := se.SetValue(en); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	prepared, err := warehouse.PrepareForJSONMarshal(en.Serialize())
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return prepared, http.StatusOK, nil
+}
+
+func (s *Server) getField(r *http.Request) (any, int, error) {
+	_, en, status, err := s.entity(r)
+	if err != nil {
+		return nil, status, err
+	}
+	path := r.PathValue("path")
+	value, err := warehouse.GetField(en, path)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	prepared, err := warehouse.PrepareForJSONMarshal(value)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return FieldView{Path: path, Value: prepared}, http.StatusOK, nil
+}
+
+func (s *Server) updateField(r *http.Request) (any, int, error) {
+	sto, en, status, err := s.entity(r)
+	if err != nil {
+		return nil, status, err
+	}
+	if sto.Locked() {
+		return nil, http.StatusConflict, errors.New("storage is locked")
+	}
+
+	var update FieldView
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err)
+	}
+	path := r.PathValue("path")
+	if err := warehouse.SetField(en, path, update.Value); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return s.getField(r)
+}
+
+// componentName returns the name a storage's registry knows a component by, falling back to its reflected type
+func componentName(sto warehouse.Storage, comp warehouse.Component) string {
+	if name, ok := sto.World().Registry().LookupName(comp); ok {
+		return name
+	}
+	return comp.Type().String()
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,316 @@
+package inspector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheBitDrifter/bappa/table"
+	"github.com/TheBitDrifter/bappa/warehouse"
+)
+
+type Position struct {
+	X, Y float64
+}
+
+// do performs a request while pumping Process on the test goroutine, like a game loop would
+func do(t *testing.T, s *Server, method, path, body string) (int, []byte) {
+	t.Helper()
+
+	type response struct {
+		status int
+		body   []byte
+		err    error
+	}
+	done := make(chan response, 1)
+	go func() {
+		req, err := http.NewRequest(method, "http://"+s.Addr()+path, strings.NewReader(body))
+		if err != nil {
+			done <- response{err: err}
+			return
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			done <- response{err: err}
+			return
+		}
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		done <- response{status: res.StatusCode, body: data, err: err}
+	}()
+
+	for {
+		select {
+		case res := <-done:
+			if res.err != nil {
+				t.Fatalf("%s %s failed: %v", method, path, res.err)
+			}
+			return res.status, res.body
+		case <-time.After(time.Millisecond):
+			s.Process()
+		}
+	}
+}
+
+func TestNewServerRejectsNonLoopback(t *testing.T) {
+	if _, err := NewServer("0.0.0.0:6061"); err == nil {
+		t.Errorf("Expected error for non-loopback address")
+	}
+	if _, err := NewServer("localhost:0"); err != nil {
+		t.Errorf("Unexpected error for localhost: %v", err)
+	}
+}
+
+func TestInspectorEndpoints(t *testing.T) {
+	posComp := warehouse.FactoryNewComponent[Position]()
+	posName, _ := warehouse.GlobalTypeRegistry.LookupName(posComp)
+
+	storage := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(2, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	*posComp.GetFromEntity(entities[0]) = Position{X: 1, Y: 2}
+
+	s, err := NewServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	s.Register("main", storage)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.Stop()
+
+	status, body := do(t, s, http.MethodGet, "/storages/main", "")
+	if status != http.StatusOK {
+		t.Fatalf("GET storage status = %d, body %s", status, body)
+	}
+	var view StorageView
+	if err := json.Unmarshal(body, &view); err != nil {
+		t.Fatalf("Invalid storage JSON: %v", err)
+	}
+	if view.Entities != 2 || len(view.Archetypes) != 1 || view.Archetypes[0].Size != 2 {
+		t.Errorf("Unexpected storage view: %+v", view)
+	}
+
+	status, _ = do(t, s, http.MethodGet, "/storages/missing", "")
+	if status != http.StatusNotFound {
+		t.Errorf("GET missing storage status = %d, want %d", status, http.StatusNotFound)
+	}
+
+	path := fmt.Sprintf("/storages/main/entities/%d", entities[0].ID())
+	update := fmt.Sprintf(`{"data": {%q: {"X": 10, "Y": 20}}}`, posName)
+	status, body = do(t, s, http.MethodPut, path, update)
+	if status != http.StatusOK {
+		t.Fatalf("PUT entity status = %d, body %s", status, body)
+	}
+
+	pos := posComp.GetFromEntity(entities[0])
+	if pos.X != 10 || pos.Y != 20 {
+		t.Errorf("Position after update = %+v, want {10 20}", *pos)
+	}
+
+	status, body = do(t, s, http.MethodGet, path, "")
+	if status != http.StatusOK {
+		t.Fatalf("GET entity status = %d, body %s", status, body)
+	}
+	var se warehouse.SerializedEntity
+	if err := json.Unmarshal(body, &se); err != nil {
+		t.Fatalf("Invalid entity JSON: %v", err)
+	}
+	if se.ID != entities[0].ID() || len(se.Components) != 1 {
+		t.Errorf("Unexpected serialized entity: %+v", se)
+	}
+
+	status, body = do(t, s, http.MethodPut, path+"/fields/Position.Y", `{"value": "25"}`)
+	if status != http.StatusOK {
+		t.Fatalf("PUT field status = %d, body %s", status, body)
+	}
+	var field FieldView
+	if err := json.Unmarshal(body, &field); err != nil {
+		t.Fatalf("Invalid field JSON: %v", err)
+	}
+	if field.Value != 25.0 || pos.Y != 25 {
+		t.Errorf("Field after update = %v, position %+v", field.Value, *pos)
+	}
+
+	status, _ = do(t, s, http.MethodGet, path+"/fields/Position.Z", "")
+	if status != http.StatusBadRequest {
+		t.Errorf("GET unknown field status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+type Velocity struct {
+	X, Y float64
+}
+
+func TestInspectorUsesWorldRegistry(t *testing.T) {
+	// Registered only with the world, not with GlobalTypeRegistry
+	iden := table.FactoryNewElementType[Velocity]()
+	velComp := warehouse.AccessibleComponent[Velocity]{Component: iden, Accessor: table.FactoryNewAccessor[Velocity](iden)}
+	world := warehouse.NewWorld()
+	world.Registry().RegisterComp(velComp)
+	velName, _ := world.Registry().LookupName(velComp)
+	if _, ok := warehouse.GlobalTypeRegistry.LookupComp(velName); ok {
+		t.Fatalf("Velocity should not be registered globally")
+	}
+
+	storage := world.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(1, velComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+
+	s, err := NewServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	s.Register("world", storage)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.Stop()
+
+	path := fmt.Sprintf("/storages/world/entities/%d", entities[0].ID())
+	update := fmt.Sprintf(`{"data": {%q: {"X": 3, "Y": 4}}}`, velName)
+	status, body := do(t, s, http.MethodPut, path, update)
+	if status != http.StatusOK {
+		t.Fatalf("PUT entity status = %d, body %s", status, body)
+	}
+	if vel := velComp.GetFromEntity(entities[0]); vel.X != 3 || vel.Y != 4 {
+		t.Errorf("Velocity after update = %+v, want {3 4}", *vel)
+	}
+}
+
+// TestInspectorCancelsTimedOutRequests tests that a request answered as failed is never applied later
+func TestInspectorCancelsTimedOutRequests(t *testing.T) {
+	posComp := warehouse.FactoryNewComponent[Position]()
+	posName, _ := warehouse.GlobalTypeRegistry.LookupName(posComp)
+
+	storage := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(1, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+
+	s, err := NewServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	s.Register("main", storage)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.Stop()
+
+	timeout := RequestTimeout
+	RequestTimeout = 20 * time.Millisecond
+	t.Cleanup(func() { RequestTimeout = timeout })
+
+	// Nothing calls Process, so the request times out while queued
+	path := fmt.Sprintf("http://%s/storages/main/entities/%d", s.Addr(), entities[0].ID())
+	update := fmt.Sprintf(`{"data": {%q: {"X": 10, "Y": 20}}}`, posName)
+	req, err := http.NewRequest(http.MethodPut, path, strings.NewReader(update))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("PUT status = %d, want %d", res.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	s.Process()
+	if pos := posComp.GetFromEntity(entities[0]); pos.X != 0 || pos.Y != 0 {
+		t.Errorf("Timed out update applied later: %+v", *pos)
+	}
+}
+
+type Speed struct {
+	X float64
+}
+
+// TestInspectorRejectsPartialUpdates tests that entity updates apply all components or none
+func TestInspectorRejectsPartialUpdates(t *testing.T) {
+	posComp := warehouse.FactoryNewComponent[Position]()
+	speedComp := warehouse.FactoryNewComponent[Speed]()
+	posName, _ := warehouse.GlobalTypeRegistry.LookupName(posComp)
+	speedName, _ := warehouse.GlobalTypeRegistry.LookupName(speedComp)
+
+	storage := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(1, posComp, speedComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	positionOnly, err := storage.NewEntities(1, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+
+	s, err := NewServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	s.Register("main", storage)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer s.Stop()
+
+	tests := []struct {
+		name   string
+		entity warehouse.Entity
+		data   string
+	}{
+		{
+			name:   "component the entity lacks",
+			entity: positionOnly[0],
+			data:   fmt.Sprintf(`{%q: {"X": 1}, %q: {"X": 2}}`, posName, speedName),
+		},
+		{
+			name:   "one component fails to convert",
+			entity: entities[0],
+			data:   fmt.Sprintf(`{%q: {"X": 1}, %q: {"X": "fast"}}`, posName, speedName),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := fmt.Sprintf("/storages/main/entities/%d", tt.entity.ID())
+			status, body := do(t, s, http.MethodPut, path, `{"data": `+tt.data+`}`)
+			if status != http.StatusBadRequest {
+				t.Errorf("PUT status = %d, want %d, body %s", status, http.StatusBadRequest, body)
+			}
+			if pos := posComp.GetFromEntity(tt.entity); pos.X != 0 {
+				t.Errorf("Rejected update changed the position to %+v", *pos)
+			}
+		})
+	}
+}
+
+func TestInspectorRegisterActive(t *testing.T) {
+	first := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	second := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+	manual := warehouse.Factory.NewStorage(table.Factory.NewSchema())
+
+	s, err := NewServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+	s.Register("manual", manual)
+	s.RegisterActive(map[string]warehouse.Storage{"first": first, "second": second})
+	s.RegisterActive(map[string]warehouse.Storage{"second": second})
+
+	for name, want := range map[string]bool{"first": false, "second": true, "manual": true} {
+		if _, err := s.storage(name); (err == nil) != want {
+			t.Errorf("Storage %q registered = %v, want %v", name, err == nil, want)
+		}
+	}
+}
@@ -9,8 +9,7 @@ type memConfig struct {
 
 func (*memConfig) Preallocate() {
 	if MemConfig.InitialEntityCapacity > 0 {
-		globalEntities = make([]entity, 0, MemConfig.InitialEntityCapacity)
-		globalEntryIndex.Preallocate(MemConfig.InitialEntityCapacity)
+		DefaultWorld.Preallocate(MemConfig.InitialEntityCapacity)
 	}
 }
 
@@ -1,5 +1,13 @@
 package warehouse
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
 // EntityOperation represents an operation that can be applied to a storage
 type EntityOperation interface {
 	Apply(Storage) error
@@ -14,22 +22,65 @@ type entityOperationsQueue struct {
 type EntityOperationsQueue interface {
 	Enqueue(EntityOperation)
 	ProcessAll(Storage) error
+	Pending() []EntityOperation
+}
+
+// OperationError records a queued operation that failed to apply
+type OperationError struct {
+	Operation EntityOperation
+	Err       error
+}
+
+func (e OperationError) Error() string {
+	return fmt.Sprintf("%T: %v", e.Operation, e.Err)
+}
+
+func (e OperationError) Unwrap() error {
+	return e.Err
+}
+
+// OperationsError aggregates every operation that failed during a single ProcessAll
+type OperationsError []OperationError
+
+func (e OperationsError) Error() string {
+	msgs := make([]string, len(e))
+	for i, opErr := range e {
+		msgs[i] = opErr.Error()
+	}
+	return fmt.Sprintf("%d queued operation(s) failed: %s", len(e), strings.Join(msgs, "; "))
 }
 
-// ProcessAll applies all queued operations to the provided storage
+func (e OperationsError) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, opErr := range e {
+		errs[i] = opErr
+	}
+	return errs
+}
+
+// ProcessAll coalesces and applies all queued operations to the provided storage
 // and clears the queue afterward
+//
+// A failing operation does not stop the remaining ones from being applied,
+// all failures are returned together as an OperationsError
 func (queue *entityOperationsQueue) ProcessAll(sto Storage) error {
 	// If storage is locked, keep operations in queue for later processing
 	if sto.Locked() {
 		return nil // Return without error, but don't clear queue
 	}
-	for _, op := range queue.operations {
+	ops := coalesceOperations(queue.operations)
+	queue.operations = []EntityOperation{}
+
+	var failed OperationsError
+	for _, op := range ops {
 		err := op.Apply(sto)
 		if err != nil {
-			return err
+			failed = append(failed, OperationError{Operation: op, Err: err})
 		}
 	}
-	queue.operations = []EntityOperation{}
+	if len(failed) > 0 {
+		return failed
+	}
 	return nil
 }
 
@@ -38,6 +89,131 @@ func (queue *entityOperationsQueue) Enqueue(op EntityOperation) {
 	queue.operations = append(queue.operations, op)
 }
 
+// Pending returns a copy of the operations waiting to be processed
+func (queue *entityOperationsQueue) Pending() []EntityOperation {
+	return slices.Clone(queue.operations)
+}
+
+// operationTarget identifies a specific incarnation of an entity
+type operationTarget struct {
+	id       table.EntryID
+	recycled int
+}
+
+type componentTarget struct {
+	operationTarget
+	component table.ElementTypeID
+}
+
+// targetOf returns the entity targeted by one of the built-in operations
+func targetOf(op EntityOperation) (Entity, operationTarget, bool) {
+	var en Entity
+	var recycled int
+	switch o := op.(type) {
+	case DestroyEntityOperation:
+		en, recycled = o.entity, o.recycled
+	case TransferEntityOperation:
+		en, recycled = o.entity, o.recycled
+	case AddComponentOperation:
+		en, recycled = o.entity, o.recycled
+	case RemoveComponentOperation:
+		en, recycled = o.entity, o.recycled
+	default:
+		return nil, operationTarget{}, false
+	}
+	if en == nil {
+		return nil, operationTarget{}, false
+	}
+	return en, operationTarget{id: en.ID(), recycled: recycled}, true
+}
+
+// coalesceOperations drops operations that would have no effect:
+//   - operations whose target entity is stale (destroyed or recycled since enqueue)
+//   - duplicate destroys, and component changes on an entity that is destroyed in the same batch
+//   - duplicate adds or removes of the same component
+//   - an add followed by a remove of the same component the entity does not currently have
+//
+// Entities that are transferred in the batch are left untouched since their storage changes mid-queue.
+// Operations of unknown types are always kept, in order
+func coalesceOperations(ops []EntityOperation) []EntityOperation {
+	dropped := make([]bool, len(ops))
+	destroyed := map[operationTarget]bool{}
+	transferred := map[operationTarget]bool{}
+
+	for i, op := range ops {
+		en, target, ok := targetOf(op)
+		if !ok {
+			continue
+		}
+		if !en.Valid() || en.Recycled() != target.recycled {
+			dropped[i] = true
+			continue
+		}
+		switch op.(type) {
+		case DestroyEntityOperation:
+			if destroyed[target] {
+				dropped[i] = true
+			}
+			destroyed[target] = true
+		case TransferEntityOperation:
+			transferred[target] = true
+		}
+	}
+
+	pendingAdds := map[componentTarget]int{}
+	pendingRemoves := map[componentTarget]bool{}
+	for i, op := range ops {
+		if dropped[i] {
+			continue
+		}
+		en, target, ok := targetOf(op)
+		if !ok || transferred[target] {
+			continue
+		}
+		switch o := op.(type) {
+		case AddComponentOperation:
+			if destroyed[target] {
+				dropped[i] = true
+				continue
+			}
+			key := componentTarget{target, o.component.ID()}
+			if _, dup := pendingAdds[key]; dup {
+				dropped[i] = true
+				continue
+			}
+			delete(pendingRemoves, key)
+			if !en.Table().Contains(o.component) {
+				pendingAdds[key] = i
+			}
+		case RemoveComponentOperation:
+			if destroyed[target] {
+				dropped[i] = true
+				continue
+			}
+			key := componentTarget{target, o.component.ID()}
+			if addIndex, ok := pendingAdds[key]; ok {
+				dropped[addIndex] = true
+				dropped[i] = true
+				delete(pendingAdds, key)
+				continue
+			}
+			if pendingRemoves[key] {
+				dropped[i] = true
+				continue
+			}
+			pendingRemoves[key] = true
+		}
+	}
+
+	result := make([]EntityOperation, 0, len(ops))
+	for i, op := range ops {
+		if !dropped[i] {
+			result = append(result, op)
+		}
+	}
+	return result
+}
+
 // NewEntityOperation creates multiple entities with the same components
 type NewEntityOperation struct {
 	count      int
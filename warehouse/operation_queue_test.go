@@ -0,0 +1,113 @@
+package warehouse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+// failingOperation always fails to apply
+type failingOperation struct{}
+
+func (failingOperation) Apply(Storage) error {
+	return errors.New("boom")
+}
+
+// TestOperationQueueCoalescing tests that redundant operations are dropped before processing
+func TestOperationQueueCoalescing(t *testing.T) {
+	posComp := sharedPosComp
+	velComp := sharedVelComp
+	healthComp := sharedHealthComp
+
+	schema := table.Factory.NewSchema()
+	storage := Factory.NewStorage(schema)
+	entities, err := storage.NewEntities(2, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	moving, err := storage.NewEntities(1, posComp, velComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	entities = append(entities, moving...)
+	stale, err := storage.NewEntities(1, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	staleOp := DestroyEntityOperation{entity: stale[0], recycled: stale[0].Recycled()}
+	if err := storage.DestroyEntities(stale[0]); err != nil {
+		t.Fatalf("Failed to destroy entity: %v", err)
+	}
+
+	storage.AddLock(1)
+
+	// Add then remove of a missing component cancels out
+	entities[0].EnqueueAddComponent(velComp)
+	entities[0].EnqueueRemoveComponent(velComp)
+	// Duplicate adds collapse into one
+	entities[1].EnqueueAddComponent(healthComp)
+	entities[1].EnqueueAddComponent(healthComp)
+	// Remove then add of an existing component is kept
+	entities[2].EnqueueRemoveComponent(velComp)
+	entities[2].EnqueueAddComponent(velComp)
+	// Stale target is skipped
+	storage.Enqueue(staleOp)
+
+	if got := len(storage.PendingOperations()); got != 7 {
+		t.Errorf("Pending operations = %d, want 7", got)
+	}
+	if got := len(coalesceOperations(storage.PendingOperations())); got != 3 {
+		t.Errorf("Coalesced operations = %d, want 3", got)
+	}
+
+	storage.RemoveLock(1)
+
+	if got := len(storage.PendingOperations()); got != 0 {
+		t.Errorf("Pending operations after processing = %d, want 0", got)
+	}
+	if entities[0].Table().Contains(velComp) {
+		t.Errorf("Entity 0 should not have velocity")
+	}
+	if !entities[1].Table().Contains(healthComp) {
+		t.Errorf("Entity 1 should have health")
+	}
+	if !entities[2].Table().Contains(velComp) {
+		t.Errorf("Entity 2 should still have velocity")
+	}
+}
+
+// TestOperationQueueFailureIsolation tests that a failing operation does not block the rest of the queue
+func TestOperationQueueFailureIsolation(t *testing.T) {
+	posComp := sharedPosComp
+
+	var reported error
+	Config.SetOperationErrorHandler(func(err error) { reported = err })
+	defer Config.SetOperationErrorHandler(nil)
+
+	schema := table.Factory.NewSchema()
+	storage := Factory.NewStorage(schema)
+
+	storage.AddLock(1)
+	storage.Enqueue(failingOperation{})
+	if err := storage.EnqueueNewEntities(2, posComp); err != nil {
+		t.Fatalf("EnqueueNewEntities failed: %v", err)
+	}
+	storage.Enqueue(failingOperation{})
+	storage.RemoveLock(1)
+
+	if storage.TotalEntities() != 2 {
+		t.Errorf("Total entities = %d, want 2", storage.TotalEntities())
+	}
+	if len(storage.PendingOperations()) != 0 {
+		t.Errorf("Queue was not cleared after failures")
+	}
+
+	var opsErr OperationsError
+	if !errors.As(reported, &opsErr) {
+		t.Fatalf("Reported error %v is not an OperationsError", reported)
+	}
+	if len(opsErr) != 2 {
+		t.Errorf("Reported %d failures, want 2", len(opsErr))
+	}
+}
@@ -0,0 +1,356 @@
+package warehouse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// QuerySyntaxError reports an invalid query expression and where it went wrong
+type QuerySyntaxError struct {
+	Expr string
+	Pos  int // Byte offset into Expr
+	Msg  string
+}
+
+func (e *QuerySyntaxError) Error() string {
+	return fmt.Sprintf("query syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// ParseQuery parses a query expression into a QueryNode, resolving component names through GlobalTypeRegistry
+//
+// Expressions combine component names with && (and), || (or), ! (not) and parentheses:
+//
+//	Position && Shape && !(Invincible || Defeat)
+//
+// Names may be fully qualified ("components.Position") or bare ("Position") when the bare name is unambiguous.
+// The Key() format of any QueryNode is accepted as well, so ParseQuery(node.Key()) rebuilds an equivalent node
+func ParseQuery(expr string) (QueryNode, error) {
+	return GlobalTypeRegistry.ParseQuery(expr)
+}
+
+// ParseQuery parses a query expression into a QueryNode, resolving component names through the registry
+// See the package level ParseQuery for the syntax
+func (r *TypeRegistry) ParseQuery(expr string) (QueryNode, error) {
+	tokens, err := lexQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{expr: expr, tokens: tokens, registry: r}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok.pos, "unexpected %s", tok)
+	}
+	if comp, ok := node.(Component); ok {
+		return newCompositeNode(OpAnd, []Component{comp}), nil
+	}
+	return node.(QueryNode), nil
+}
+
+type queryTokenKind int
+
+const (
+	tokEOF queryTokenKind = iota
+	tokIdent
+	tokInt
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	pos  int
+}
+
+func (t queryToken) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// querySymbols maps single character tokens to their kind
+var querySymbols = map[rune]queryTokenKind{
+	'!': tokNot,
+	'(': tokLParen,
+	')': tokRParen,
+	'[': tokLBracket,
+	']': tokRBracket,
+	',': tokComma,
+}
+
+// lexQuery splits a query expression into tokens, positions are byte offsets
+func lexQuery(expr string) ([]queryToken, error) {
+	tokens := make([]queryToken, 0)
+	for i := 0; i < len(expr); {
+		c, size := utf8.DecodeRuneInString(expr[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, queryToken{tokAnd, "&&", i})
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, queryToken{tokOr, "||", i})
+			i += 2
+		case querySymbols[c] != tokEOF:
+			tokens = append(tokens, queryToken{querySymbols[c], string(c), i})
+			i += size
+		case unicode.IsDigit(c):
+			start := i
+			i = lexRunes(expr, i, unicode.IsDigit)
+			tokens = append(tokens, queryToken{tokInt, expr[start:i], start})
+		case isQueryIdentRune(c):
+			start := i
+			i = lexRunes(expr, i, func(c rune) bool { return isQueryIdentRune(c) || unicode.IsDigit(c) })
+			tokens = append(tokens, queryToken{tokIdent, expr[start:i], start})
+		default:
+			return nil, &QuerySyntaxError{Expr: expr, Pos: i, Msg: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return append(tokens, queryToken{tokEOF, "", len(expr)}), nil
+}
+
+// lexRunes returns the offset of the first rune at or after i that does not satisfy accept
+func lexRunes(expr string, i int, accept func(rune) bool) int {
+	for i < len(expr) {
+		c, size := utf8.DecodeRuneInString(expr[i:])
+		if !accept(c) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+func isQueryIdentRune(c rune) bool {
+	return c == '_' || c == '.' || unicode.IsLetter(c)
+}
+
+// queryParser is a recursive descent parser over lexed query tokens
+//
+// Operands are either a Component (bare names) or a QueryNode (everything else) so
+// that chains like A && B && !C collapse into a single node with components, matching
+// what the Query builder produces for the equivalent Go code
+type queryParser struct {
+	expr     string
+	tokens   []queryToken
+	next     int
+	registry *TypeRegistry
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.next]
+}
+
+func (p *queryParser) advance() queryToken {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *queryParser) expect(kind queryTokenKind, want string) (queryToken, error) {
+	tok := p.advance()
+	if tok.kind != kind {
+		return tok, p.errorf(tok.pos, "expected %s, found %s", want, tok)
+	}
+	return tok, nil
+}
+
+func (p *queryParser) errorf(pos int, format string, args ...interface{}) error {
+	return &QuerySyntaxError{Expr: p.expr, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parseOr parses: and ('||' and)*
+func (p *queryParser) parseOr() (interface{}, error) {
+	return p.parseChain(tokOr, OpOr, p.parseAnd)
+}
+
+// parseAnd parses: unary ('&&' unary)*
+func (p *queryParser) parseAnd() (interface{}, error) {
+	return p.parseChain(tokAnd, OpAnd, p.parseUnary)
+}
+
+// parseChain parses a flat chain of operands joined by the same operator
+func (p *queryParser) parseChain(sep queryTokenKind, op QueryOperation, operand func() (interface{}, error)) (interface{}, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != sep {
+		return first, nil
+	}
+	items := []interface{}{first}
+	for p.peek().kind == sep {
+		p.advance()
+		item, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return buildQueryNode(op, items), nil
+}
+
+// parseUnary parses: '!' unary | primary
+func (p *queryParser) parseUnary() (interface{}, error) {
+	if p.peek().kind != tokNot {
+		return p.parsePrimary()
+	}
+	p.advance()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return buildQueryNode(OpNot, []interface{}{operand}), nil
+}
+
+// parsePrimary parses: '(' or ')' | keyNode | name
+func (p *queryParser) parsePrimary() (interface{}, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case tokIdent:
+		if p.peek().kind == tokLParen {
+			return p.parseKeyNode(tok)
+		}
+		return p.resolveName(tok)
+	}
+	return nil, p.errorf(tok.pos, "expected component name or \"(\", found %s", tok)
+}
+
+// parseKeyNode parses the Key() format: op '(' [part (',' part)*] ')'
+// where part is comps([ids]) or another key node
+func (p *queryParser) parseKeyNode(opTok queryToken) (QueryNode, error) {
+	var op QueryOperation
+	switch opTok.text {
+	case "and":
+		op = OpAnd
+	case "or":
+		op = OpOr
+	case "not":
+		op = OpNot
+	case "leaf":
+		if _, err := p.expect(tokLParen, `"("`); err != nil {
+			return nil, err
+		}
+		comps, err := p.parseIDList()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		return newLeafNode(comps), nil
+	default:
+		return nil, p.errorf(opTok.pos, "unknown operation %q", opTok.text)
+	}
+	if _, err := p.expect(tokLParen, `"("`); err != nil {
+		return nil, err
+	}
+
+	node := newCompositeNode(op, []Component{})
+	for p.peek().kind != tokRParen {
+		if len(node.children) > 0 || len(node.components) > 0 {
+			if _, err := p.expect(tokComma, `","`); err != nil {
+				return nil, err
+			}
+		}
+		tok, err := p.expect(tokIdent, "comps or a nested operation")
+		if err != nil {
+			return nil, err
+		}
+		if tok.text != "comps" {
+			child, err := p.parseKeyNode(tok)
+			if err != nil {
+				return nil, err
+			}
+			node.children = append(node.children, child)
+			continue
+		}
+		if _, err := p.expect(tokLParen, `"("`); err != nil {
+			return nil, err
+		}
+		comps, err := p.parseIDList()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+		node.components = append(node.components, comps...)
+	}
+	p.advance()
+	return node, nil
+}
+
+// parseIDList parses a bracketed, space separated list of component IDs
+func (p *queryParser) parseIDList() ([]Component, error) {
+	if _, err := p.expect(tokLBracket, `"["`); err != nil {
+		return nil, err
+	}
+	comps := make([]Component, 0)
+	for p.peek().kind == tokInt {
+		tok := p.advance()
+		id, err := strconv.ParseUint(tok.text, 10, 32)
+		if err != nil {
+			return nil, p.errorf(tok.pos, "invalid component id %s", tok.text)
+		}
+		comp, ok := p.registry.lookupID(uint32(id))
+		if !ok {
+			return nil, p.errorf(tok.pos, "unknown component id %d", id)
+		}
+		comps = append(comps, comp)
+	}
+	if _, err := p.expect(tokRBracket, `"]"`); err != nil {
+		return nil, err
+	}
+	return comps, nil
+}
+
+// resolveName resolves a component name token, reporting failures at the token's position
+func (p *queryParser) resolveName(tok queryToken) (Component, error) {
+	comp, err := p.registry.resolveComponentName(tok.text)
+	if err != nil {
+		return nil, p.errorf(tok.pos, "%v", err)
+	}
+	return comp, nil
+}
+
+// buildQueryNode groups operands the same way the Query builder does: bare components
+// become the node's components and everything else becomes a child
+func buildQueryNode(op QueryOperation, items []interface{}) QueryNode {
+	node := newCompositeNode(op, []Component{})
+	for _, item := range items {
+		switch v := item.(type) {
+		case Component:
+			node.components = append(node.components, v)
+		case QueryNode:
+			node.children = append(node.children, v)
+		}
+	}
+	return node
+}
@@ -0,0 +1,156 @@
+package warehouse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+// registeredComp returns the component GlobalTypeRegistry resolves for the name
+func registeredComp(t *testing.T, name string) Component {
+	t.Helper()
+	comp, ok := GlobalTypeRegistry.LookupComp(name)
+	if !ok {
+		t.Fatalf("Component %s is not registered", name)
+	}
+	return comp
+}
+
+// TestParseQuery tests that parsed expressions build the same tree as the Query builder
+func TestParseQuery(t *testing.T) {
+	pos := registeredComp(t, "warehouse.Position")
+	vel := registeredComp(t, "warehouse.Velocity")
+	health := registeredComp(t, "warehouse.Health")
+
+	tests := []struct {
+		name  string
+		expr  string
+		build func(q Query) QueryNode
+	}{
+		{
+			name:  "Single name",
+			expr:  "Position",
+			build: func(q Query) QueryNode { return q.And(pos) },
+		},
+		{
+			name:  "Qualified names",
+			expr:  "warehouse.Position && warehouse.Velocity",
+			build: func(q Query) QueryNode { return q.And(pos, vel) },
+		},
+		{
+			name: "Negated group",
+			expr: "Position && Velocity && !(Health || Velocity)",
+			build: func(q Query) QueryNode {
+				return q.And(pos, vel, q.Not(q.Or(health, vel)))
+			},
+		},
+		{
+			name: "And binds tighter than or",
+			expr: "Position || Velocity && !Health",
+			build: func(q Query) QueryNode {
+				return q.Or(pos, q.And(vel, q.Not(health)))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := ParseQuery(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) failed: %v", tt.expr, err)
+			}
+			want := tt.build(Factory.NewQuery()).Key()
+			if node.Key() != want {
+				t.Errorf("Key = %s, want %s", node.Key(), want)
+			}
+
+			roundTrip, err := ParseQuery(node.Key())
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) failed: %v", node.Key(), err)
+			}
+			if roundTrip.Key() != want {
+				t.Errorf("Round trip key = %s, want %s", roundTrip.Key(), want)
+			}
+		})
+	}
+}
+
+// TestParseQueryEvaluate tests that a parsed query filters entities like the equivalent built query
+func TestParseQueryEvaluate(t *testing.T) {
+	pos := registeredComp(t, "warehouse.Position")
+	vel := registeredComp(t, "warehouse.Velocity")
+	health := registeredComp(t, "warehouse.Health")
+
+	storage := Factory.NewStorage(table.Factory.NewSchema())
+	for _, comps := range [][]Component{{pos}, {pos, vel}, {pos, health}, {pos, vel, health}, {vel}} {
+		if _, err := storage.NewEntities(2, comps...); err != nil {
+			t.Fatalf("Failed to create entities: %v", err)
+		}
+	}
+
+	node, err := ParseQuery("Position && !(Velocity && Health)")
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	count := 0
+	cursor := Factory.NewCursor(node, storage)
+	for range cursor.Next() {
+		count++
+	}
+	if count != 6 {
+		t.Errorf("Query matched %d entities, want 6", count)
+	}
+}
+
+// TestParseQueryErrors tests that syntax errors report where they occurred
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{"Position && Unknown", 12},
+		{"Position & Velocity", 9},
+		{"(Position || Velocity", 21},
+		{"Position &&", 11},
+		{"Position Velocity", 9},
+		{"and(comps([999999]))", 11},
+		{"and(comps([1]),)", 15},
+		{"Position && Größe2", 12},
+		{"Größe2 €", 9},
+		{"Position && €", 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseQuery(tt.expr)
+			var syntaxErr *QuerySyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected QuerySyntaxError, got %v", err)
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("Error position = %d, want %d (%v)", syntaxErr.Pos, tt.pos, err)
+			}
+		})
+	}
+}
+
+// Größe has a non-ASCII name, which Go identifiers allow
+type Größe struct {
+	W, H float64
+}
+
+// TestParseQueryUnicodeNames tests that component names are lexed by rune rather than by byte
+func TestParseQueryUnicodeNames(t *testing.T) {
+	size := FactoryNewComponent[Größe]()
+	pos := registeredComp(t, "warehouse.Position")
+
+	node, err := ParseQuery("warehouse.Größe && !Position")
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	want := Factory.NewQuery().And(size, Factory.NewQuery().Not(pos))
+	if node.Key() != want.Key() {
+		t.Errorf("Parsed %s, want %s", node.Key(), want.Key())
+	}
+}
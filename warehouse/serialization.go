@@ -33,10 +33,16 @@ type SerializedEntity struct {
 	Data map[string]any `json:"data"`
 }
 
+// GetComponents resolves the entity's component names through the GlobalTypeRegistry
 func (se SerializedEntity) GetComponents() []Component {
+	return se.GetComponentsFrom(GlobalTypeRegistry)
+}
+
+// GetComponentsFrom resolves the entity's component names through the given registry
+func (se SerializedEntity) GetComponentsFrom(registry *TypeRegistry) []Component {
 	result := []Component{}
 	for _, n := range se.Components {
-		c, ok := GlobalTypeRegistry.LookupComp(n)
+		c, ok := registry.LookupComp(n)
 		if ok {
 			result = append(result, c)
 		}
@@ -45,30 +51,39 @@ func (se SerializedEntity) GetComponents() []Component {
 }
 
 func (se SerializedEntity) SetValue(entity Entity) error {
+	registry := entity.Storage().World().Registry()
+	tbl := entity.Table()
+	idx := entity.Index()
+
+	// Convert everything before setting anything so a bad component leaves the entity untouched
+	type converted struct {
+		comp  Component
+		value reflect.Value
+	}
+	values := make([]converted, 0, len(se.Data))
 	for compName, compData := range se.Data {
-		comp, ok := GlobalTypeRegistry.LookupComp(compName)
+		comp, ok := registry.LookupComp(compName)
 		if !ok {
 			continue
 		}
-
-		tbl := entity.Table()
 		if !tbl.Contains(comp) {
 			continue
 		}
-		idx := entity.Index()
-
-		targetType := comp.Type()
 
-		convertedValue, err := convertToType(compData, targetType)
+		convertedValue, err := convertToType(compData, comp.Type())
 		if err != nil {
 			return fmt.Errorf("failed to convert component data for %s: %w", compName, err)
 		}
+		values = append(values, converted{comp: comp, value: reflect.ValueOf(convertedValue)})
+	}
 
-		err = tbl.Set(comp, reflect.ValueOf(convertedValue), idx)
+	for _, v := range values {
+		err := tbl.Set(v.comp, v.value, idx)
 		if err != nil {
 			return fmt.Errorf("failed to set component data: %w", err)
 		}
 	}
+	entity.Storage().SpatialIndexes().entitiesChanged(entity)
 	return nil
 }
 
@@ -110,7 +125,7 @@ func deserializeStorage(storage Storage, world *SerializedStorage, purge bool) (
 		// Get components slice
 		entityComponents := make([]Component, 0)
 		for _, compName := range serializedEntity.Components {
-			comp, ok := GlobalTypeRegistry.LookupComp(compName)
+			comp, ok := storage.World().Registry().LookupComp(compName)
 			if !ok {
 				return nil, fmt.Errorf("component not found: %s", compName)
 			}
@@ -184,10 +199,9 @@ func LoadStorage(filename string) (*SerializedStorage, error) {
 	return &world, nil
 }
 
-// Rests globalEntities && globalEntryIndex
+// ResetAll resets the entities and entry index of the DefaultWorld
 func ResetAll() {
-	globalEntities = []entity{}
-	globalEntryIndex.Reset()
+	DefaultWorld.Reset()
 }
 
 // prepareForJSONMarshal recursively traverses data and returns a *new* structure
@@ -0,0 +1,463 @@
+package warehouse
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+// Ensure uniformGrid implements SpatialIndex interface
+var _ SpatialIndex = &uniformGrid{}
+
+// AABB represents an axis-aligned bounding box in world space
+type AABB struct {
+	MinX, MinY float64
+	MaxX, MaxY float64
+}
+
+// NewAABBFromCenter creates an AABB centered on (x, y) with the given dimensions
+func NewAABBFromCenter(x, y, width, height float64) AABB {
+	return AABB{
+		MinX: x - width/2,
+		MinY: y - height/2,
+		MaxX: x + width/2,
+		MaxY: y + height/2,
+	}
+}
+
+// Intersects reports whether the two boxes overlap (touching edges count as overlap)
+func (a AABB) Intersects(b AABB) bool {
+	return a.MinX <= b.MaxX && a.MaxX >= b.MinX && a.MinY <= b.MaxY && a.MaxY >= b.MinY
+}
+
+// Contains reports whether the point (x, y) lies inside the box
+func (a AABB) Contains(x, y float64) bool {
+	return x >= a.MinX && x <= a.MaxX && y >= a.MinY && y <= a.MaxY
+}
+
+// distanceSquaredTo returns the squared distance from (x, y) to the closest point of the box
+func (a AABB) distanceSquaredTo(x, y float64) float64 {
+	dx := math.Max(math.Max(a.MinX-x, 0), x-a.MaxX)
+	dy := math.Max(math.Max(a.MinY-y, 0), y-a.MaxY)
+	return dx*dx + dy*dy
+}
+
+// BoundsFunc reports the world bounds of an entity
+// Returning false excludes the entity from the index
+type BoundsFunc func(en Entity) (AABB, bool)
+
+// SpatialIndex accelerates region queries over the entities of a single storage
+//
+// Indexes attached to a storage through its SpatialIndexes are kept current by the storage itself:
+// created, destroyed and transferred entities and component adds and removes are applied as they
+// happen. Component values are written through pointers and cannot be observed, so whatever moves
+// entities reports them with Update. Sync rebuilds the whole index, which is only needed for
+// detached indexes or after bulk writes that bypassed Update
+type SpatialIndex interface {
+	// Sync rebuilds the index from the current state of the storage
+	Sync() error
+	// Update re-reads the bounds of the entities, indexing the ones that match the tracked query and
+	// dropping the rest
+	Update(entities ...Entity)
+	// Remove drops the entities from the index
+	Remove(entities ...Entity)
+	// QueryRegion returns the entities whose bounds intersect the region and that match filter (nil matches all)
+	QueryRegion(region AABB, filter QueryNode) []Entity
+	// QueryRadius returns the entities whose bounds intersect the circle and that match filter (nil matches all)
+	QueryRadius(x, y, radius float64, filter QueryNode) []Entity
+	// Tracks reports whether the entity is currently indexed
+	Tracks(en Entity) bool
+	// Len returns the number of indexed entities
+	Len() int
+	// Clear removes all entities from the index
+	Clear()
+}
+
+// SpatialIndexes holds the spatial indexes attached to a storage by name, so systems that share
+// an index find the same one and it goes away with the storage
+type SpatialIndexes struct {
+	mu      sync.Mutex
+	indexes map[string]SpatialIndex
+}
+
+// Get returns the index registered under name
+func (s *SpatialIndexes) Get(name string) (SpatialIndex, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index, ok := s.indexes[name]
+	return index, ok
+}
+
+// GetOrCreate returns the index registered under name, creating it on first use
+func (s *SpatialIndexes) GetOrCreate(name string, create func() SpatialIndex) SpatialIndex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if index, ok := s.indexes[name]; ok {
+		return index
+	}
+	if s.indexes == nil {
+		s.indexes = make(map[string]SpatialIndex)
+	}
+	index := create()
+	s.indexes[name] = index
+	return index
+}
+
+// Release drops the index registered under name
+func (s *SpatialIndexes) Release(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.indexes, name)
+}
+
+// entitiesChanged updates the entities in every attached index after they were created,
+// transferred in or changed archetype
+func (s *SpatialIndexes) entitiesChanged(entities ...Entity) {
+	for _, index := range s.attached() {
+		index.Update(entities...)
+	}
+}
+
+// entitiesRemoved drops the entities from every attached index before they are destroyed or
+// transferred out
+func (s *SpatialIndexes) entitiesRemoved(entities ...Entity) {
+	for _, index := range s.attached() {
+		index.Remove(entities...)
+	}
+}
+
+// attached returns the registered indexes, so they are notified without holding the lock
+func (s *SpatialIndexes) attached() []SpatialIndex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.indexes) == 0 {
+		return nil
+	}
+	indexes := make([]SpatialIndex, 0, len(s.indexes))
+	for _, index := range s.indexes {
+		indexes = append(indexes, index)
+	}
+	return indexes
+}
+
+// maxGridCoord bounds cell coordinates so huge or infinite bounds cannot overflow the cell math
+// Bounds past it share the outermost cells, which only costs precision far outside any real level
+const maxGridCoord = 1 << 30
+
+// maxEntryCells is the most cells an entry is bucketed into, larger entries are kept in a list
+// every query checks
+const maxEntryCells = 1024
+
+type gridCell struct {
+	x, y int
+}
+
+type gridEntry struct {
+	recycled int
+	bounds   AABB
+	minCell  gridCell
+	maxCell  gridCell
+	// oversized entries are not bucketed, see maxEntryCells
+	oversized bool
+	syncGen   int
+}
+
+// uniformGrid implements SpatialIndex by bucketing entities into fixed size cells
+type uniformGrid struct {
+	storage  Storage
+	tracked  QueryNode
+	bounds   BoundsFunc
+	cellSize float64
+
+	cells     map[gridCell][]table.EntryID
+	entries   map[table.EntryID]*gridEntry
+	oversized map[table.EntryID]bool
+	syncGen   int
+	// matches caches whether the tracked query matches each archetype table
+	matches map[table.Table]bool
+}
+
+// newUniformGrid creates a uniform grid index over storage for entities matching tracked
+func newUniformGrid(storage Storage, cellSize float64, tracked QueryNode, bounds BoundsFunc) *uniformGrid {
+	if cellSize <= 0 {
+		cellSize = 1
+	}
+	return &uniformGrid{
+		storage:   storage,
+		tracked:   tracked,
+		bounds:    bounds,
+		cellSize:  cellSize,
+		cells:     make(map[gridCell][]table.EntryID),
+		entries:   make(map[table.EntryID]*gridEntry),
+		oversized: make(map[table.EntryID]bool),
+		matches:   make(map[table.Table]bool),
+	}
+}
+
+// Sync rebuilds the index from the current state of the storage
+func (g *uniformGrid) Sync() error {
+	if g.storage == nil || g.tracked == nil || g.bounds == nil {
+		return errors.New("spatial index is missing a storage, query or bounds func")
+	}
+	g.syncGen++
+
+	cursor := Factory.NewCursor(g.tracked, g.storage)
+	for range cursor.Next() {
+		en, err := cursor.CurrentEntity()
+		if err != nil {
+			return err
+		}
+		bounds, ok := g.bounds(en)
+		if !ok {
+			continue
+		}
+		g.upsert(en.ID(), en.Recycled(), bounds)
+	}
+
+	for id, e := range g.entries {
+		if e.syncGen != g.syncGen {
+			g.remove(id)
+		}
+	}
+	return nil
+}
+
+// Update re-reads the bounds of the entities, indexing the ones that match the tracked query and
+// dropping the rest
+func (g *uniformGrid) Update(entities ...Entity) {
+	if g.storage == nil || g.tracked == nil || g.bounds == nil {
+		return
+	}
+	for _, en := range entities {
+		if en == nil {
+			continue
+		}
+		if !en.Valid() || en.Storage() != g.storage || !g.matchesTable(en.Table()) {
+			g.Remove(en)
+			continue
+		}
+		bounds, ok := g.bounds(en)
+		if !ok {
+			g.Remove(en)
+			continue
+		}
+		g.upsert(en.ID(), en.Recycled(), bounds)
+	}
+}
+
+// Remove drops the entities from the index
+func (g *uniformGrid) Remove(entities ...Entity) {
+	for _, en := range entities {
+		if en != nil {
+			g.remove(en.ID())
+		}
+	}
+}
+
+// matchesTable reports whether the tracked query matches the archetype stored in tbl
+func (g *uniformGrid) matchesTable(tbl table.Table) bool {
+	if matched, ok := g.matches[tbl]; ok {
+		return matched
+	}
+	matched := false
+	for _, arche := range g.storage.Archetypes() {
+		if arche.table == tbl {
+			matched = g.tracked.Evaluate(arche, g.storage)
+			break
+		}
+	}
+	g.matches[tbl] = matched
+	return matched
+}
+
+// upsert inserts or updates the entry for id, only re-bucketing when its cell range changed
+func (g *uniformGrid) upsert(id table.EntryID, recycled int, bounds AABB) {
+	minCell, maxCell := g.cellRange(bounds)
+
+	existing, found := g.entries[id]
+	if found && existing.recycled == recycled && existing.minCell == minCell && existing.maxCell == maxCell {
+		existing.bounds = bounds
+		existing.syncGen = g.syncGen
+		return
+	}
+	if found {
+		g.remove(id)
+	}
+
+	e := &gridEntry{
+		recycled:  recycled,
+		bounds:    bounds,
+		minCell:   minCell,
+		maxCell:   maxCell,
+		oversized: cellCount(minCell, maxCell) > maxEntryCells,
+		syncGen:   g.syncGen,
+	}
+	g.entries[id] = e
+	if e.oversized {
+		g.oversized[id] = true
+		return
+	}
+	for cx := minCell.x; cx <= maxCell.x; cx++ {
+		for cy := minCell.y; cy <= maxCell.y; cy++ {
+			key := gridCell{cx, cy}
+			g.cells[key] = append(g.cells[key], id)
+		}
+	}
+}
+
+// remove deletes the entry for id from every cell it occupies
+func (g *uniformGrid) remove(id table.EntryID) {
+	e, ok := g.entries[id]
+	if !ok {
+		return
+	}
+	delete(g.entries, id)
+	if e.oversized {
+		delete(g.oversized, id)
+		return
+	}
+	for cx := e.minCell.x; cx <= e.maxCell.x; cx++ {
+		for cy := e.minCell.y; cy <= e.maxCell.y; cy++ {
+			key := gridCell{cx, cy}
+			ids := g.cells[key]
+			for i, other := range ids {
+				if other == id {
+					ids[i] = ids[len(ids)-1]
+					ids = ids[:len(ids)-1]
+					break
+				}
+			}
+			if len(ids) == 0 {
+				delete(g.cells, key)
+			} else {
+				g.cells[key] = ids
+			}
+		}
+	}
+}
+
+// cellRange returns the inclusive range of cells covered by bounds, clamped to maxGridCoord
+// NaN bounds give an empty range
+func (g *uniformGrid) cellRange(bounds AABB) (gridCell, gridCell) {
+	if math.IsNaN(bounds.MinX) || math.IsNaN(bounds.MinY) || math.IsNaN(bounds.MaxX) || math.IsNaN(bounds.MaxY) {
+		return gridCell{0, 0}, gridCell{-1, -1}
+	}
+	return gridCell{
+		x: g.cellCoord(bounds.MinX),
+		y: g.cellCoord(bounds.MinY),
+	}, gridCell{
+		x: g.cellCoord(bounds.MaxX),
+		y: g.cellCoord(bounds.MaxY),
+	}
+}
+
+// cellCoord returns the cell coordinate of a world coordinate
+func (g *uniformGrid) cellCoord(v float64) int {
+	return int(math.Max(-maxGridCoord, math.Min(maxGridCoord, math.Floor(v/g.cellSize))))
+}
+
+// cellCount returns the number of cells in an inclusive range, 0 when it is empty
+func cellCount(minCell, maxCell gridCell) int {
+	if maxCell.x < minCell.x || maxCell.y < minCell.y {
+		return 0
+	}
+	return (maxCell.x - minCell.x + 1) * (maxCell.y - minCell.y + 1)
+}
+
+// QueryRegion returns the entities whose bounds intersect the region and that match filter
+func (g *uniformGrid) QueryRegion(region AABB, filter QueryNode) []Entity {
+	return g.query(region, filter, func(e *gridEntry) bool {
+		return e.bounds.Intersects(region)
+	})
+}
+
+// QueryRadius returns the entities whose bounds intersect the circle and that match filter
+func (g *uniformGrid) QueryRadius(x, y, radius float64, filter QueryNode) []Entity {
+	region := AABB{MinX: x - radius, MinY: y - radius, MaxX: x + radius, MaxY: y + radius}
+	radiusSq := radius * radius
+	return g.query(region, filter, func(e *gridEntry) bool {
+		return e.bounds.distanceSquaredTo(x, y) <= radiusSq
+	})
+}
+
+// query collects candidates from the cells overlapping region, validates them against the
+// storage and the filter, and returns them ordered by ID so results are deterministic
+// Regions covering more cells than there are occupied cells scan the entries instead
+func (g *uniformGrid) query(region AABB, filter QueryNode, accept func(*gridEntry) bool) []Entity {
+	minCell, maxCell := g.cellRange(region)
+
+	candidates := []table.EntryID{}
+	if cellCount(minCell, maxCell) > len(g.cells) {
+		for id, e := range g.entries {
+			if accept(e) {
+				candidates = append(candidates, id)
+			}
+		}
+	} else {
+		seen := make(map[table.EntryID]bool)
+		for cx := minCell.x; cx <= maxCell.x; cx++ {
+			for cy := minCell.y; cy <= maxCell.y; cy++ {
+				for _, id := range g.cells[gridCell{cx, cy}] {
+					if seen[id] {
+						continue
+					}
+					seen[id] = true
+					if accept(g.entries[id]) {
+						candidates = append(candidates, id)
+					}
+				}
+			}
+		}
+		for id := range g.oversized {
+			if accept(g.entries[id]) {
+				candidates = append(candidates, id)
+			}
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i] < candidates[j] })
+
+	var tableMatches map[table.Table]bool
+	if filter != nil {
+		tableMatches = make(map[table.Table]bool)
+		for _, arche := range g.storage.Archetypes() {
+			tableMatches[arche.table] = filter.Evaluate(arche, g.storage)
+		}
+	}
+
+	result := make([]Entity, 0, len(candidates))
+	for _, id := range candidates {
+		en, err := g.storage.Entity(int(id))
+		if err != nil || !en.Valid() || en.Recycled() != g.entries[id].recycled {
+			continue
+		}
+		if en.Storage() != g.storage {
+			continue
+		}
+		if filter != nil && !tableMatches[en.Table()] {
+			continue
+		}
+		result = append(result, en)
+	}
+	return result
+}
+
+// Tracks reports whether the entity is currently indexed
+func (g *uniformGrid) Tracks(en Entity) bool {
+	e, ok := g.entries[en.ID()]
+	return ok && e.recycled == en.Recycled() && en.Storage() == g.storage
+}
+
+// Len returns the number of indexed entities
+func (g *uniformGrid) Len() int {
+	return len(g.entries)
+}
+
+// Clear removes all entities from the index
+func (g *uniformGrid) Clear() {
+	g.cells = make(map[gridCell][]table.EntryID)
+	g.entries = make(map[table.EntryID]*gridEntry)
+	g.oversized = make(map[table.EntryID]bool)
+}
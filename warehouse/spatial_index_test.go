@@ -0,0 +1,349 @@
+package warehouse
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+// TestSpatialIndexQueries tests region and radius queries against a synced grid
+func TestSpatialIndexQueries(t *testing.T) {
+	posComp := sharedPosComp
+	healthComp := sharedHealthComp
+
+	schema := table.Factory.NewSchema()
+	storage := Factory.NewStorage(schema)
+
+	// Three entities with health, one without
+	withHealth, err := storage.NewEntities(3, posComp, healthComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	withoutHealth, err := storage.NewEntities(1, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+
+	positions := []Position{{0, 0}, {50, 50}, {200, 200}}
+	for i, en := range withHealth {
+		*posComp.GetFromEntity(en) = positions[i]
+	}
+	*posComp.GetFromEntity(withoutHealth[0]) = Position{5, 5}
+
+	tracked := Factory.NewQuery().And(posComp)
+	index := Factory.NewSpatialIndex(storage, 32, tracked, func(en Entity) (AABB, bool) {
+		pos := posComp.GetFromEntity(en)
+		return NewAABBFromCenter(pos.X, pos.Y, 10, 10), true
+	})
+	if err := index.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if index.Len() != 4 {
+		t.Errorf("Indexed %d entities, want 4", index.Len())
+	}
+
+	tests := []struct {
+		name   string
+		run    func() []Entity
+		wantID []table.EntryID
+	}{
+		{
+			name: "Region without filter",
+			run: func() []Entity {
+				return index.QueryRegion(AABB{MinX: -10, MinY: -10, MaxX: 60, MaxY: 60}, nil)
+			},
+			wantID: []table.EntryID{withHealth[0].ID(), withHealth[1].ID(), withoutHealth[0].ID()},
+		},
+		{
+			name: "Region with filter",
+			run: func() []Entity {
+				return index.QueryRegion(AABB{MinX: -10, MinY: -10, MaxX: 60, MaxY: 60}, Factory.NewQuery().And(healthComp))
+			},
+			wantID: []table.EntryID{withHealth[0].ID(), withHealth[1].ID()},
+		},
+		{
+			name: "Radius reaches box edge",
+			run: func() []Entity {
+				return index.QueryRadius(220, 200, 16, nil)
+			},
+			wantID: []table.EntryID{withHealth[2].ID()},
+		},
+		{
+			name: "Radius misses",
+			run: func() []Entity {
+				return index.QueryRadius(120, 120, 10, nil)
+			},
+			wantID: []table.EntryID{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.run()
+			if len(got) != len(tt.wantID) {
+				t.Fatalf("Got %d entities, want %d", len(got), len(tt.wantID))
+			}
+			for i, en := range got {
+				if en.ID() != tt.wantID[i] {
+					t.Errorf("Result %d has ID %d, want %d", i, en.ID(), tt.wantID[i])
+				}
+			}
+		})
+	}
+}
+
+// TestSpatialIndexSync tests that moved and destroyed entities are reflected after Sync
+func TestSpatialIndexSync(t *testing.T) {
+	posComp := sharedPosComp
+
+	schema := table.Factory.NewSchema()
+	storage := Factory.NewStorage(schema)
+
+	entities, err := storage.NewEntities(2, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+
+	tracked := Factory.NewQuery().And(posComp)
+	index := Factory.NewSpatialIndex(storage, 16, tracked, func(en Entity) (AABB, bool) {
+		pos := posComp.GetFromEntity(en)
+		return NewAABBFromCenter(pos.X, pos.Y, 2, 2), true
+	})
+	if err := index.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	// Move the first entity far away and destroy the second
+	*posComp.GetFromEntity(entities[0]) = Position{500, 500}
+	if err := storage.DestroyEntities(entities[1]); err != nil {
+		t.Fatalf("Failed to destroy entity: %v", err)
+	}
+	if err := index.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if index.Len() != 1 {
+		t.Errorf("Indexed %d entities, want 1", index.Len())
+	}
+	if got := index.QueryRegion(AABB{MinX: -5, MinY: -5, MaxX: 5, MaxY: 5}, nil); len(got) != 0 {
+		t.Errorf("Got %d entities at old position, want 0", len(got))
+	}
+	got := index.QueryRadius(500, 500, 1, nil)
+	if len(got) != 1 || got[0].ID() != entities[0].ID() {
+		t.Errorf("Moved entity not found at new position")
+	}
+}
+
+// TestSpatialIndexFollowsStorage tests that attached indexes follow structural changes without Sync
+func TestSpatialIndexFollowsStorage(t *testing.T) {
+	posComp := sharedPosComp
+	healthComp := sharedHealthComp
+
+	world := NewWorld()
+	storage := world.NewStorage(table.Factory.NewSchema())
+	other := world.NewStorage(table.Factory.NewSchema())
+
+	attach := func(sto Storage) SpatialIndex {
+		return sto.SpatialIndexes().GetOrCreate("positions", func() SpatialIndex {
+			return Factory.NewSpatialIndex(sto, 16, Factory.NewQuery().And(posComp), func(en Entity) (AABB, bool) {
+				pos := posComp.GetFromEntity(en)
+				return NewAABBFromCenter(pos.X, pos.Y, 2, 2), true
+			})
+		})
+	}
+	index, otherIndex := attach(storage), attach(other)
+
+	at := func(index SpatialIndex, x, y float64) []table.EntryID {
+		ids := []table.EntryID{}
+		for _, en := range index.QueryRadius(x, y, 1, nil) {
+			ids = append(ids, en.ID())
+		}
+		return ids
+	}
+
+	created, err := storage.NewEntities(2, posComp, healthComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	archetype, err := storage.NewOrExistingArchetype(posComp)
+	if err != nil {
+		t.Fatalf("Failed to create archetype: %v", err)
+	}
+	generated, err := archetype.GenerateAndReturnEntity(1, Position{100, 100})
+	if err != nil {
+		t.Fatalf("Failed to generate entity: %v", err)
+	}
+	if index.Len() != 3 {
+		t.Fatalf("Indexed %d created entities, want 3", index.Len())
+	}
+	if got := at(index, 100, 100); len(got) != 1 || got[0] != generated[0].ID() {
+		t.Errorf("Generated entity found as %v at its initial position", got)
+	}
+
+	// Values written through pointers are reported with Update
+	*posComp.GetFromEntity(created[0]) = Position{50, 50}
+	index.Update(created[0])
+	if got := at(index, 50, 50); len(got) != 1 || got[0] != created[0].ID() {
+		t.Errorf("Moved entity found as %v at its new position", got)
+	}
+
+	if err := created[1].RemoveComponent(posComp); err != nil {
+		t.Fatalf("Failed to remove component: %v", err)
+	}
+	if index.Tracks(created[1]) {
+		t.Error("Entity still indexed after losing its position")
+	}
+	if err := created[1].AddComponentWithValue(posComp, Position{-30, 0}); err != nil {
+		t.Fatalf("Failed to add component: %v", err)
+	}
+	if got := at(index, -30, 0); len(got) != 1 || got[0] != created[1].ID() {
+		t.Errorf("Entity found as %v after regaining its position", got)
+	}
+
+	if err := storage.TransferEntities(other, generated[0]); err != nil {
+		t.Fatalf("Failed to transfer entity: %v", err)
+	}
+	if index.Tracks(generated[0]) || !otherIndex.Tracks(generated[0]) {
+		t.Error("Transferred entity not moved between the storages' indexes")
+	}
+
+	if err := storage.DestroyEntities(created[0]); err != nil {
+		t.Fatalf("Failed to destroy entity: %v", err)
+	}
+	if index.Tracks(created[0]) || index.Len() != 1 {
+		t.Errorf("Destroyed entity still indexed, %d entries", index.Len())
+	}
+
+	if _, err := storage.NewEntities(1, posComp); err != nil {
+		t.Fatalf("Failed to create entity: %v", err)
+	}
+	if index.Len() != 2 {
+		t.Errorf("Indexed %d entities, want 2", index.Len())
+	}
+}
+
+// TestSpatialIndexHugeBounds tests that infinite and huge bounds neither overflow the cell math
+// nor walk every cell they cover
+func TestSpatialIndexHugeBounds(t *testing.T) {
+	posComp := sharedPosComp
+
+	schema := table.Factory.NewSchema()
+	storage := Factory.NewStorage(schema)
+
+	entities, err := storage.NewEntities(3, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	*posComp.GetFromEntity(entities[0]) = Position{0, 0}
+	*posComp.GetFromEntity(entities[1]) = Position{1e300, -1e300}
+	*posComp.GetFromEntity(entities[2]) = Position{math.Inf(1), 0}
+
+	tracked := Factory.NewQuery().And(posComp)
+	index := Factory.NewSpatialIndex(storage, 1, tracked, func(en Entity) (AABB, bool) {
+		if en.ID() == entities[0].ID() {
+			// Level sized bounds covering far more cells than maxEntryCells
+			return AABB{MinX: -1e6, MinY: -1e6, MaxX: 1e6, MaxY: 1e6}, true
+		}
+		pos := posComp.GetFromEntity(en)
+		return NewAABBFromCenter(pos.X, pos.Y, 2, 2), true
+	})
+	if err := index.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if index.Len() != 3 {
+		t.Fatalf("Indexed %d entities, want 3", index.Len())
+	}
+
+	tests := []struct {
+		name   string
+		region AABB
+		wantID []table.EntryID
+	}{
+		{
+			name:   "Infinite region",
+			region: AABB{MinX: math.Inf(-1), MinY: math.Inf(-1), MaxX: math.Inf(1), MaxY: math.Inf(1)},
+			wantID: []table.EntryID{entities[0].ID(), entities[1].ID(), entities[2].ID()},
+		},
+		{
+			name:   "Huge region",
+			region: AABB{MinX: -1e18, MinY: -1e18, MaxX: 1e18, MaxY: 1e18},
+			wantID: []table.EntryID{entities[0].ID()},
+		},
+		{
+			name:   "Small region inside oversized entry",
+			region: AABB{MinX: 10, MinY: 10, MaxX: 11, MaxY: 11},
+			wantID: []table.EntryID{entities[0].ID()},
+		},
+		{
+			name:   "Far region",
+			region: NewAABBFromCenter(1e300, -1e300, 4, 4),
+			wantID: []table.EntryID{entities[1].ID()},
+		},
+		{
+			name:   "NaN region",
+			region: AABB{MinX: math.NaN(), MinY: 0, MaxX: 1, MaxY: 1},
+			wantID: []table.EntryID{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := index.QueryRegion(tt.region, nil)
+			if len(got) != len(tt.wantID) {
+				t.Fatalf("Got %d entities, want %d", len(got), len(tt.wantID))
+			}
+			for i, en := range got {
+				if en.ID() != tt.wantID[i] {
+					t.Errorf("Result %d has ID %d, want %d", i, en.ID(), tt.wantID[i])
+				}
+			}
+		})
+	}
+
+	if err := storage.DestroyEntities(entities[0]); err != nil {
+		t.Fatalf("Failed to destroy entity: %v", err)
+	}
+	if err := index.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if index.Tracks(entities[0]) || !index.Tracks(entities[1]) {
+		t.Errorf("Tracks does not reflect the destroyed entity")
+	}
+	if got := index.QueryRegion(AABB{MinX: 10, MinY: 10, MaxX: 11, MaxY: 11}, nil); len(got) != 0 {
+		t.Errorf("Got %d entities after removing the oversized entry, want 0", len(got))
+	}
+}
+
+// TestSpatialIndexesPerStorage tests that named indexes are shared within a storage and not across storages
+func TestSpatialIndexesPerStorage(t *testing.T) {
+	first := Factory.NewStorage(table.Factory.NewSchema())
+	second := Factory.NewStorage(table.Factory.NewSchema())
+
+	created := 0
+	create := func(sto Storage) func() SpatialIndex {
+		return func() SpatialIndex {
+			created++
+			return Factory.NewSpatialIndex(sto, 16, Factory.NewQuery().And(sharedPosComp), nil)
+		}
+	}
+
+	a := first.SpatialIndexes().GetOrCreate("shapes", create(first))
+	if again := first.SpatialIndexes().GetOrCreate("shapes", create(first)); again != a {
+		t.Error("GetOrCreate returned a new index for the same name")
+	}
+	if b := second.SpatialIndexes().GetOrCreate("shapes", create(second)); b == a {
+		t.Error("Storages share an index")
+	}
+	if created != 2 {
+		t.Errorf("Created %d indexes, want 2", created)
+	}
+
+	first.SpatialIndexes().Release("shapes")
+	if _, ok := first.SpatialIndexes().Get("shapes"); ok {
+		t.Error("Released index still registered")
+	}
+	if _, ok := second.SpatialIndexes().Get("shapes"); !ok {
+		t.Error("Releasing one storage's index dropped another's")
+	}
+}
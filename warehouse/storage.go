@@ -12,12 +12,6 @@ import (
 // Ensure storage implements Storage interface
 var _ Storage = &storage{}
 
-var (
-	globalEntryIndex = table.Factory.NewEntryIndex()
-	globalEntities   = make([]entity, 0)
-	initOnce         sync.Once
-)
-
 // Storage defines the interface for entity storage and manipulation
 type Storage interface {
 	Entity(id int) (Entity, error)
@@ -32,9 +26,13 @@ type Storage interface {
 	RemoveLock(bit uint32)
 	Register(...Component)
 	tableFor(...Component) (table.Table, error)
+	transitionArchetype(origin table.Table, c Component, add bool, components []Component) (Archetype, error)
 
 	TransferEntities(target Storage, entities ...Entity) error
 	Enqueue(EntityOperation)
+	PendingOperations() []EntityOperation
+	Events() *EventBus
+	SpatialIndexes() *SpatialIndexes
 	Archetypes() []ArchetypeImpl
 	TotalEntities() int
 	Entities() []Entity
@@ -44,15 +42,21 @@ type Storage interface {
 
 	ForceSerializedEntityExclude(se SerializedEntity, excludeComps ...Component) (Entity, error)
 	Gen() int
+	World() *World
+	ReserveIDRange(min, max table.EntryID) error
 }
 
 // storage implements the Storage interface
 type storage struct {
+	world          *World
 	locks          mask.Mask256
 	lockmu         sync.RWMutex
 	schema         table.Schema
 	archetypes     *archetypes
 	operationQueue EntityOperationsQueue
+	idRange        table.IDRange
+	events         *EventBus
+	spatialIndexes SpatialIndexes
 }
 
 // archetypes manages archetype collections and identification
@@ -60,18 +64,22 @@ type archetypes struct {
 	nextID           archetypeID
 	asSlice          []ArchetypeImpl
 	idsGroupedByMask map[mask.Mask]archetypeID
+	idsByTable       map[table.Table]archetypeID
 }
 
-// newStorage creates a new Storage implementation with the given schema
-func newStorage(schema table.Schema) Storage {
+// newStorage creates a new Storage implementation with the given schema in the given world
+func newStorage(schema table.Schema, world *World) Storage {
 	archetypes := &archetypes{
 		nextID:           1,
 		idsGroupedByMask: make(map[mask.Mask]archetypeID),
+		idsByTable:       make(map[table.Table]archetypeID),
 	}
 	storage := &storage{
+		world:          world,
 		archetypes:     archetypes,
 		schema:         schema,
 		operationQueue: &entityOperationsQueue{},
+		events:         newEventBus(),
 	}
 	return storage
 }
@@ -79,10 +87,10 @@ func newStorage(schema table.Schema) Storage {
 // Entity retrieves an entity by ID
 func (sto *storage) Entity(id int) (Entity, error) {
 	index := id - 1
-	if index < 0 || index >= len(globalEntities) {
+	if index < 0 || index >= len(sto.world.entities) {
 		return nil, errors.New("invalid index")
 	}
-	return &globalEntities[index], nil
+	return &sto.world.entities[index], nil
 }
 
 // NewOrExistingArchetype gets an existing archetype matching the component signature or creates a new one
@@ -98,16 +106,53 @@ func (sto *storage) NewOrExistingArchetype(components ...Component) (Archetype,
 		return sto.archetypes.asSlice[id-1], nil
 	}
 
-	created, err := newArchetype(sto, globalEntryIndex, sto.archetypes.nextID, components...)
+	created, err := newArchetype(sto, sto.world.entryIndex, sto.archetypes.nextID, components...)
 	if err != nil {
 		return nil, err
 	}
 	sto.archetypes.asSlice = append(sto.archetypes.asSlice, created)
 	sto.archetypes.idsGroupedByMask[entityMask] = created.id
+	sto.archetypes.idsByTable[created.table] = created.id
 	sto.archetypes.nextID++
 	return &created, nil
 }
 
+// archetypeEdgesDisabled resolves every transition through a full lookup, so benchmarks can measure the edge cache
+var archetypeEdgesDisabled = false
+
+// transitionArchetype returns the archetype an entity in origin moves to when c is added or removed
+//
+// The transition is resolved through the origin archetype's cached edge. On a miss the destination is
+// found (or created) from the full component list and the edge is cached in both directions
+func (sto *storage) transitionArchetype(origin table.Table, c Component, add bool, components []Component) (Archetype, error) {
+	fromID, tracked := sto.archetypes.idsByTable[origin]
+	tracked = tracked && !archetypeEdgesDisabled
+	if tracked {
+		from := sto.archetypes.asSlice[fromID-1]
+		if toID, ok := from.edges.lookup(c, add); ok {
+			return sto.archetypes.asSlice[toID-1], nil
+		}
+	}
+
+	dest, err := sto.NewOrExistingArchetype(components...)
+	if err != nil {
+		return nil, err
+	}
+	if !tracked {
+		return dest, nil
+	}
+	toID := archetypeID(dest.ID())
+	from, to := sto.archetypes.asSlice[fromID-1], sto.archetypes.asSlice[toID-1]
+	if add {
+		from.edges.add[c.ID()] = toID
+		to.edges.remove[c.ID()] = fromID
+	} else {
+		from.edges.remove[c.ID()] = toID
+		to.edges.add[c.ID()] = fromID
+	}
+	return dest, nil
+}
+
 // NewEntities creates n new entities with the specified components
 func (sto *storage) NewEntities(n int, components ...Component) ([]Entity, error) {
 	if sto.Locked() {
@@ -140,7 +185,7 @@ func (sto *storage) NewEntities(n int, components ...Component) ([]Entity, error
 		return nil, err
 	}
 
-	// Resize globalEntities as needed and create entity objects
+	// Resize the world entities as needed and create entity objects
 	entities := make([]Entity, n)
 
 	// Find maximum ID for preallocation
@@ -151,15 +196,15 @@ func (sto *storage) NewEntities(n int, components ...Component) ([]Entity, error
 		}
 	}
 
-	// Expand globalEntities if needed
-	if int(maxID) > len(globalEntities) {
-		newCap := max(int(maxID), 2*len(globalEntities))
-		if cap(globalEntities) < newCap {
-			newEntities := make([]entity, len(globalEntities), newCap)
-			copy(newEntities, globalEntities)
-			globalEntities = newEntities
+	// Expand the world entities if needed
+	if int(maxID) > len(sto.world.entities) {
+		newCap := max(int(maxID), 2*len(sto.world.entities))
+		if cap(sto.world.entities) < newCap {
+			newEntities := make([]entity, len(sto.world.entities), newCap)
+			copy(newEntities, sto.world.entities)
+			sto.world.entities = newEntities
 		}
-		globalEntities = globalEntities[:int(maxID)]
+		sto.world.entities = sto.world.entities[:int(maxID)]
 	}
 
 	// Create entities and add them to the right positions
@@ -167,19 +212,21 @@ func (sto *storage) NewEntities(n int, components ...Component) ([]Entity, error
 		entryID := entry.ID()
 		en := &entity{
 			Entry:      entry,
+			world:      sto.world,
 			sto:        sto,
 			id:         entryID,
 			components: components,
 		}
 		entities[i] = en
 
-		// Place the entity at the correct position in globalEntities
+		// Place the entity at the correct position in the world entities
 		idx := int(entryID) - 1
-		for idx >= len(globalEntities) {
-			globalEntities = append(globalEntities, entity{})
+		for idx >= len(sto.world.entities) {
+			sto.world.entities = append(sto.world.entities, entity{})
 		}
-		globalEntities[idx] = *en
+		sto.world.entities[idx] = *en
 	}
+	sto.spatialIndexes.entitiesChanged(entities...)
 	return entities, nil
 }
 
@@ -217,8 +264,7 @@ func (sto *storage) RemoveLock(bit uint32) {
 
 		err := sto.operationQueue.ProcessAll(sto)
 		if err != nil {
-			// Handle the error appropriately for your application
-			panic(fmt.Errorf("error processing queued operations: %w", err))
+			Config.operationErrorHandler(fmt.Errorf("error processing queued operations: %w", err))
 		}
 
 		// Re-acquire the lock
@@ -254,6 +300,7 @@ func (s *storage) DestroyEntities(entities ...Entity) error {
 			continue
 		}
 
+		s.spatialIndexes.entitiesRemoved(en)
 		table := en.Table()
 		_, err := table.DeleteEntries(en.Index())
 		if err != nil {
@@ -285,6 +332,9 @@ func (s *storage) TransferEntities(target Storage, entities ...Entity) error {
 	if s.Locked() {
 		return errors.New("storage is locked")
 	}
+	if target.World() != s.world {
+		return errors.New("cannot transfer entities between storages of different worlds")
+	}
 	for _, en := range entities {
 		comps := en.Components()
 		target.Register(comps...)
@@ -293,11 +343,13 @@ func (s *storage) TransferEntities(target Storage, entities ...Entity) error {
 			return err
 		}
 
+		s.spatialIndexes.entitiesRemoved(en)
 		err = en.Table().TransferEntries(targetTbl, en.Index())
 		if err != nil {
 			return err
 		}
 		en.SetStorage(target)
+		target.SpatialIndexes().entitiesChanged(en)
 	}
 	return nil
 }
@@ -316,6 +368,21 @@ func (s *storage) Enqueue(op EntityOperation) {
 	s.operationQueue.Enqueue(op)
 }
 
+// PendingOperations returns the operations queued while the storage was locked
+func (s *storage) PendingOperations() []EntityOperation {
+	return s.operationQueue.Pending()
+}
+
+// Events returns the storage's event bus
+func (s *storage) Events() *EventBus {
+	return s.events
+}
+
+// SpatialIndexes returns the spatial indexes attached to the storage
+func (s *storage) SpatialIndexes() *SpatialIndexes {
+	return &s.spatialIndexes
+}
+
 // Archetypes returns all archetypes in this storage
 func (s *storage) Archetypes() []ArchetypeImpl {
 	return s.archetypes.asSlice
@@ -341,11 +408,12 @@ func (s *storage) tableFor(comps ...Component) (table.Table, error) {
 	decrement := 1
 	if !ok {
 		decrement++
-		created, err := newArchetype(s, globalEntryIndex, s.archetypes.nextID, comps...)
+		created, err := newArchetype(s, s.world.entryIndex, s.archetypes.nextID, comps...)
 		if err != nil {
 			return nil, err
 		}
 		s.archetypes.asSlice = append(s.archetypes.asSlice, created)
+		s.archetypes.idsByTable[created.table] = created.id
 		s.archetypes.nextID++
 		id = s.archetypes.nextID
 	}
@@ -363,7 +431,7 @@ func (s *storage) Entities() []Entity {
 		for i := 0; i < n; i++ {
 			entry, err := tbl.Entry(i)
 			if err == nil {
-				result = append(result, &globalEntities[entry.ID()-1])
+				result = append(result, &s.world.entities[entry.ID()-1])
 			}
 		}
 	}
@@ -371,22 +439,22 @@ func (s *storage) Entities() []Entity {
 }
 
 func (s *storage) ForceSerializedEntityWithID(se SerializedEntity, id int) (Entity, error) {
-	comps := se.GetComponents()
+	comps := se.GetComponentsFrom(s.world.registry)
 	index := id - 1
 
-	entityExistsGlobally := id > 0 && index < len(globalEntities) && globalEntities[index].Valid()
+	entityExistsGlobally := id > 0 && index < len(s.world.entities) && s.world.entities[index].Valid()
 
 	var entityPtr *entity
 
 	if !entityExistsGlobally {
-		createdEntity, err := s.forceNewEntity(se) // Creates in globalEntities and table
+		createdEntity, err := s.forceNewEntity(se) // Creates in the world entities and table
 		if err != nil {
 			return nil, fmt.Errorf("failed to force new entity %d: %w", id, err)
 		}
 		return createdEntity, nil
 	}
 
-	entityPtr = &globalEntities[index]
+	entityPtr = &s.world.entities[index]
 
 	if entityPtr.sto != s {
 		sourceStorage := entityPtr.sto
@@ -398,7 +466,7 @@ func (s *storage) ForceSerializedEntityWithID(se SerializedEntity, id int) (Enti
 			return nil, fmt.Errorf("failed to transfer entity %d from other storage: %w", id, err)
 		}
 
-		refreshedEntry, err := globalEntryIndex.Entry(index)
+		refreshedEntry, err := s.world.entryIndex.Entry(index)
 		if err != nil {
 			return nil, err
 		}
@@ -417,7 +485,7 @@ func (s *storage) ForceSerializedEntityWithID(se SerializedEntity, id int) (Enti
 		if err != nil {
 			return nil, fmt.Errorf("failed to transfer entity %d to target archetype: %w", id, err)
 		}
-		refreshedEntry, err := globalEntryIndex.Entry(index)
+		refreshedEntry, err := s.world.entryIndex.Entry(index)
 		if err != nil {
 			return nil, err
 		}
@@ -425,6 +493,7 @@ func (s *storage) ForceSerializedEntityWithID(se SerializedEntity, id int) (Enti
 	}
 
 	entityPtr.components = comps
+	s.spatialIndexes.entitiesChanged(entityPtr)
 
 	return entityPtr, nil
 }
@@ -435,21 +504,21 @@ func (s *storage) ForceSerializedEntity(se SerializedEntity) (Entity, error) {
 
 func (s *storage) forceNewEntity(se SerializedEntity) (Entity, error) {
 	id := int(se.ID)
-	comps := se.GetComponents()
+	comps := se.GetComponentsFrom(s.world.registry)
 	recycled := se.Recycled
 
 	// Calculate index in the slice (id-1 since arrays are 0-indexed)
 	index := id - 1
 
 	// Check how many new slots we need to add
-	amountNeeded := index + 1 - len(globalEntities)
+	amountNeeded := index + 1 - len(s.world.entities)
 
 	// If we need to expand the slice
 	if amountNeeded > 0 {
 		// Create a new slice with enough capacity
 		newEntities := make([]entity, amountNeeded)
 		// Append the new space to the global entities
-		globalEntities = append(globalEntities, newEntities...)
+		s.world.entities = append(s.world.entities, newEntities...)
 	}
 
 	arche, err := s.NewOrExistingArchetype(comps...)
@@ -462,22 +531,24 @@ func (s *storage) forceNewEntity(se SerializedEntity) (Entity, error) {
 	}
 
 	// Initialize the entity at the specified index
-	globalEntities[index] = entity{
+	s.world.entities[index] = entity{
+		world:      s.world,
 		id:         table.EntryID(id),
 		components: comps,
-		Entry:      globalEntryIndex.Entries()[id-1],
+		Entry:      s.world.entryIndex.Entries()[id-1],
 		sto:        s,
 	}
+	s.spatialIndexes.entitiesChanged(&s.world.entities[index])
 
-	return &globalEntities[index], nil
+	return &s.world.entities[index], nil
 }
 
 func (s *storage) ForceSerializedEntityExclude(se SerializedEntity, xComps ...Component) (Entity, error) {
 	id := int(se.ID)
-	seComps := se.GetComponents()
+	seComps := se.GetComponentsFrom(s.world.registry)
 	index := id - 1
 
-	entityExistsGlobally := id > 0 && index < len(globalEntities) && globalEntities[index].Valid()
+	entityExistsGlobally := id > 0 && index < len(s.world.entities) && s.world.entities[index].Valid()
 
 	var entityPtr *entity
 
@@ -489,7 +560,7 @@ func (s *storage) ForceSerializedEntityExclude(se SerializedEntity, xComps ...Co
 		return createdEntity, nil
 	}
 
-	entityPtr = &globalEntities[index]
+	entityPtr = &s.world.entities[index]
 
 	if entityPtr.sto != s {
 		sourceStorage := entityPtr.sto
@@ -503,7 +574,7 @@ func (s *storage) ForceSerializedEntityExclude(se SerializedEntity, xComps ...Co
 			return nil, fmt.Errorf("failed to transfer entity %d from other storage: %w", id, err)
 		}
 
-		refreshedEntry, err := globalEntryIndex.Entry(index)
+		refreshedEntry, err := s.world.entryIndex.Entry(index)
 		if err != nil {
 			return nil, err
 		}
@@ -525,7 +596,7 @@ func (s *storage) ForceSerializedEntityExclude(se SerializedEntity, xComps ...Co
 		if err != nil {
 			return nil, fmt.Errorf("failed to transfer entity %d to target archetype: %w", id, err)
 		}
-		refreshedEntry, err := globalEntryIndex.Entry(index)
+		refreshedEntry, err := s.world.entryIndex.Entry(index)
 		if err != nil {
 			return nil, err
 		}
@@ -533,6 +604,7 @@ func (s *storage) ForceSerializedEntityExclude(se SerializedEntity, xComps ...Co
 	}
 
 	entityPtr.components = targetComps
+	s.spatialIndexes.entitiesChanged(entityPtr)
 
 	return entityPtr, nil
 }
@@ -560,5 +632,29 @@ func mergeUniqueComponents(slice1, slice2 []Component) []Component {
 }
 
 func (s *storage) Gen() int {
-	return globalEntryIndex.Gen()
+	return s.world.entryIndex.Gen()
+}
+
+// World returns the world this storage belongs to
+func (s *storage) World() *World {
+	return s.world
+}
+
+// ReserveIDRange reserves the inclusive ID range in the world and makes every entity created by this
+// storage draw its ID from it. Other storages of the world never receive IDs from the range
+//
+// Typical use is keeping client local entities out of the ID space mirrored from an authoritative server
+func (s *storage) ReserveIDRange(min, max table.EntryID) error {
+	if !s.idRange.IsZero() {
+		return fmt.Errorf("storage already reserved ID range [%d, %d]", s.idRange.Min, s.idRange.Max)
+	}
+	r := table.IDRange{Min: min, Max: max}
+	if err := s.world.entryIndex.ReserveRange(r); err != nil {
+		return err
+	}
+	s.idRange = r
+	for _, arche := range s.archetypes.asSlice {
+		arche.table.SetIDRange(r)
+	}
+	return nil
 }
@@ -77,6 +77,48 @@ func TestArchetypeCreation(t *testing.T) {
 	}
 }
 
+// TestArchetypeEdges tests that component add/remove transitions are cached between neighboring archetypes
+func TestArchetypeEdges(t *testing.T) {
+	storage := Factory.NewStorage(table.Factory.NewSchema())
+	entities, err := storage.NewEntities(2, sharedPosComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	first, second := entities[0], entities[1]
+
+	posArche, _ := storage.NewOrExistingArchetype(sharedPosComp)
+	if err := first.AddComponent(sharedVelComp); err != nil {
+		t.Fatalf("AddComponent failed: %v", err)
+	}
+	posVelArche, _ := storage.NewOrExistingArchetype(sharedPosComp, sharedVelComp)
+
+	from := storage.Archetypes()[posArche.ID()-1]
+	if id, ok := from.edges.lookup(sharedVelComp, true); !ok || uint32(id) != posVelArche.ID() {
+		t.Fatalf("Add edge = %d, %v, want %d", id, ok, posVelArche.ID())
+	}
+	to := storage.Archetypes()[posVelArche.ID()-1]
+	if id, ok := to.edges.lookup(sharedVelComp, false); !ok || uint32(id) != posArche.ID() {
+		t.Fatalf("Remove edge = %d, %v, want %d", id, ok, posArche.ID())
+	}
+
+	// The cached edge must land the second entity in the same archetype
+	if err := second.AddComponent(sharedVelComp); err != nil {
+		t.Fatalf("AddComponent failed: %v", err)
+	}
+	if second.Table() != posVelArche.Table() {
+		t.Errorf("Entity moved to the wrong archetype")
+	}
+	if err := second.RemoveComponent(sharedVelComp); err != nil {
+		t.Fatalf("RemoveComponent failed: %v", err)
+	}
+	if second.Table() != posArche.Table() {
+		t.Errorf("Entity did not return to its original archetype")
+	}
+	if got := len(storage.Archetypes()); got != 2 {
+		t.Errorf("Storage has %d archetypes, want 2", got)
+	}
+}
+
 // TestEntityDestruction tests destroying entities
 func TestEntityDestruction(t *testing.T) {
 	schema := table.Factory.NewSchema()
@@ -330,3 +372,40 @@ func TestComponentAccessAfterTransfer(t *testing.T) {
 			posPtr2.X, posPtr2.Y)
 	}
 }
+
+// BenchmarkAddRemoveComponent measures AddComponent and RemoveComponent with and without the archetype edge cache
+func BenchmarkAddRemoveComponent(b *testing.B) {
+	for _, bm := range []struct {
+		name     string
+		disabled bool
+	}{
+		{"edges", false},
+		{"lookup", true},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			archetypeEdgesDisabled = bm.disabled
+			b.Cleanup(func() { archetypeEdgesDisabled = false })
+
+			storage := Factory.NewStorage(table.Factory.NewSchema())
+			// Extra components make the full lookup mask and archetype search realistic
+			entities, err := storage.NewEntities(100, sharedPosComp, sharedHealthComp)
+			if err != nil {
+				b.Fatalf("Failed to create entities: %v", err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, en := range entities {
+					if err := en.AddComponent(sharedVelComp); err != nil {
+						b.Fatalf("AddComponent failed: %v", err)
+					}
+				}
+				for _, en := range entities {
+					if err := en.RemoveComponent(sharedVelComp); err != nil {
+						b.Fatalf("RemoveComponent failed: %v", err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package warehouse
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 )
 
@@ -8,6 +10,7 @@ type TypeRegistry struct {
 	mu         sync.RWMutex
 	nameToComp map[string]Component
 	compToName map[Component]string
+	parent     *TypeRegistry
 }
 
 var GlobalTypeRegistry = NewTypeRegistry()
@@ -20,7 +23,17 @@ func NewTypeRegistry() *TypeRegistry {
 	}
 }
 
+// NewTypeRegistryWithParent creates a type registry that falls back to parent for unknown lookups
+func NewTypeRegistryWithParent(parent *TypeRegistry) *TypeRegistry {
+	r := NewTypeRegistry()
+	r.parent = parent
+	return r
+}
+
 func (r *TypeRegistry) RegisterComp(comp Component) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	typeName := comp.Type().String()
 	r.nameToComp[typeName] = comp
 	r.compToName[comp] = typeName
@@ -29,16 +42,71 @@ func (r *TypeRegistry) RegisterComp(comp Component) {
 // LookupType retrieves a type by name
 func (r *TypeRegistry) LookupComp(name string) (Component, bool) {
 	r.mu.RLock()
-	defer r.mu.RUnlock()
-
 	t, ok := r.nameToComp[name]
+	r.mu.RUnlock()
+
+	if !ok && r.parent != nil {
+		return r.parent.LookupComp(name)
+	}
 	return t, ok
 }
 
 func (r *TypeRegistry) LookupName(comp Component) (string, bool) {
 	r.mu.RLock()
-	defer r.mu.RUnlock()
-
 	name, ok := r.compToName[comp]
+	r.mu.RUnlock()
+
+	if !ok && r.parent != nil {
+		return r.parent.LookupName(comp)
+	}
 	return name, ok
 }
+
+// lookupID finds a component by its element type ID
+func (r *TypeRegistry) lookupID(id uint32) (Component, bool) {
+	r.mu.RLock()
+	for comp := range r.compToName {
+		if uint32(comp.ID()) == id {
+			r.mu.RUnlock()
+			return comp, true
+		}
+	}
+	r.mu.RUnlock()
+
+	if r.parent != nil {
+		return r.parent.lookupID(id)
+	}
+	return nil, false
+}
+
+// lookupShortName finds the components whose type name without package qualifier matches
+func (r *TypeRegistry) lookupShortName(short string) []Component {
+	r.mu.RLock()
+	matches := make([]Component, 0)
+	for name, comp := range r.nameToComp {
+		if name[strings.LastIndex(name, ".")+1:] == short {
+			matches = append(matches, comp)
+		}
+	}
+	r.mu.RUnlock()
+
+	if len(matches) == 0 && r.parent != nil {
+		return r.parent.lookupShortName(short)
+	}
+	return matches
+}
+
+// resolveComponentName finds a component by registered name or unambiguous bare type name
+func (r *TypeRegistry) resolveComponentName(name string) (Component, error) {
+	if comp, ok := r.LookupComp(name); ok {
+		return comp, nil
+	}
+	matches := r.lookupShortName(name)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown component %q", name)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("ambiguous component %q", name)
+}
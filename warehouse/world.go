@@ -0,0 +1,73 @@
+package warehouse
+
+import (
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+// World owns the bookkeeping shared by a group of storages: the entry index that hands out
+// entity IDs, the entity slots those IDs resolve to, and the component type registry
+//
+// Storages in the same world share an ID space and can transfer entities between each other.
+// Separate worlds are fully isolated, so each one can be driven from its own goroutine, e.g. parallel
+// tests or several matches hosted by one headless server. A single world is not safe for concurrent use
+type World struct {
+	entryIndex table.EntryIndex
+	entities   []entity
+	registry   *TypeRegistry
+}
+
+// DefaultWorld backs every storage created through Factory.NewStorage
+var DefaultWorld = newWorld(GlobalTypeRegistry)
+
+// NewWorld creates an isolated world
+// Its type registry falls back to GlobalTypeRegistry, so components created with
+// FactoryNewComponent resolve in every world
+func NewWorld() *World {
+	return newWorld(NewTypeRegistryWithParent(GlobalTypeRegistry))
+}
+
+func newWorld(registry *TypeRegistry) *World {
+	return &World{
+		entryIndex: table.Factory.NewEntryIndex(),
+		entities:   make([]entity, 0),
+		registry:   registry,
+	}
+}
+
+// NewStorage creates a storage that belongs to this world
+func (w *World) NewStorage(schema table.Schema) Storage {
+	return newStorage(schema, w)
+}
+
+// Registry returns the type registry used to (de)serialize entities of this world
+func (w *World) Registry() *TypeRegistry {
+	return w.registry
+}
+
+// Gen returns the generation of the world's entry index
+func (w *World) Gen() int {
+	return w.entryIndex.Gen()
+}
+
+// SetAllocationMode sets the order in which the world reuses freed entity IDs
+// Peers that must agree on IDs, like a server and its clients running the same Plan,
+// should use table.AllocateLowestFirst so IDs do not depend on destruction order
+func (w *World) SetAllocationMode(mode table.AllocationMode) {
+	w.entryIndex.SetAllocationMode(mode)
+}
+
+// Reset clears every entity and ID of the world
+// Storages created in the world must not be used afterwards
+func (w *World) Reset() {
+	w.entities = []entity{}
+	w.entryIndex.Reset()
+}
+
+// Preallocate reserves capacity for the given number of entities
+func (w *World) Preallocate(capacity int) {
+	if capacity <= 0 {
+		return
+	}
+	w.entities = make([]entity, 0, capacity)
+	w.entryIndex.Preallocate(capacity)
+}
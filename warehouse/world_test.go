@@ -0,0 +1,136 @@
+package warehouse
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/TheBitDrifter/bappa/table"
+)
+
+// TestWorldIsolation tests that separate worlds hand out independent IDs and can run concurrently
+func TestWorldIsolation(t *testing.T) {
+	posComp, velComp := sharedPosComp, sharedVelComp
+
+	worlds := []*World{NewWorld(), NewWorld(), NewWorld()}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(worlds))
+	for i, w := range worlds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			storage := Factory.NewStorageInWorld(table.Factory.NewSchema(), w)
+			entities, err := storage.NewEntities(100, posComp)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			for _, en := range entities[:50] {
+				if err := en.AddComponent(velComp); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+			errs[i] = storage.DestroyEntities(entities[50:]...)
+		}()
+	}
+	wg.Wait()
+
+	for i, w := range worlds {
+		if errs[i] != nil {
+			t.Fatalf("World %d failed: %v", i, errs[i])
+		}
+		storage := Factory.NewStorageInWorld(table.Factory.NewSchema(), w)
+		entities, err := storage.NewEntities(1, posComp)
+		if err != nil {
+			t.Fatalf("Failed to create entity in world %d: %v", i, err)
+		}
+		// Every world recycles its own first destroyed ID
+		if entities[0].ID() != 51 {
+			t.Errorf("World %d reused ID %d, want 51", i, entities[0].ID())
+		}
+	}
+}
+
+// TestWorldTransferAndReset tests cross world transfer rejection and per world reset
+func TestWorldTransferAndReset(t *testing.T) {
+	posComp := sharedPosComp
+
+	w := NewWorld()
+	isolated := Factory.NewStorageInWorld(table.Factory.NewSchema(), w)
+	shared := Factory.NewStorage(table.Factory.NewSchema())
+
+	entities, err := isolated.NewEntities(2, posComp)
+	if err != nil {
+		t.Fatalf("Failed to create entities: %v", err)
+	}
+	if err := isolated.TransferEntities(shared, entities[0]); err == nil {
+		t.Errorf("Expected error transferring between worlds")
+	}
+
+	sibling := w.NewStorage(table.Factory.NewSchema())
+	if err := isolated.TransferEntities(sibling, entities[0]); err != nil {
+		t.Errorf("Transfer within a world failed: %v", err)
+	}
+
+	ResetAll()
+	if !entities[1].Valid() {
+		t.Errorf("ResetAll invalidated an entity of a non-default world")
+	}
+	w.Reset()
+	if entities[1].Valid() {
+		t.Errorf("Entity still valid after its world was reset")
+	}
+}
+
+// TestWorldDeterministicIDs tests that lowest first allocation and reserved ranges give peers identical IDs
+func TestWorldDeterministicIDs(t *testing.T) {
+	posComp := sharedPosComp
+
+	allocate := func(destroyOrder []int) []table.EntryID {
+		w := NewWorld()
+		w.SetAllocationMode(table.AllocateLowestFirst)
+		storage := w.NewStorage(table.Factory.NewSchema())
+		local := w.NewStorage(table.Factory.NewSchema())
+		if err := local.ReserveIDRange(1000, 1009); err != nil {
+			t.Fatalf("ReserveIDRange failed: %v", err)
+		}
+
+		entities, err := storage.NewEntities(5, posComp)
+		if err != nil {
+			t.Fatalf("Failed to create entities: %v", err)
+		}
+		if _, err := local.NewEntities(2, posComp); err != nil {
+			t.Fatalf("Failed to create local entities: %v", err)
+		}
+		for _, i := range destroyOrder {
+			if err := storage.DestroyEntities(entities[i]); err != nil {
+				t.Fatalf("Failed to destroy entity: %v", err)
+			}
+		}
+
+		created, err := storage.NewEntities(3, posComp)
+		if err != nil {
+			t.Fatalf("Failed to create entities: %v", err)
+		}
+		createdLocal, err := local.NewEntities(1, posComp)
+		if err != nil {
+			t.Fatalf("Failed to create local entities: %v", err)
+		}
+
+		ids := make([]table.EntryID, 0, len(created)+1)
+		for _, en := range append(created, createdLocal...) {
+			ids = append(ids, en.ID())
+		}
+		return ids
+	}
+
+	server := allocate([]int{3, 0})
+	client := allocate([]int{0, 3})
+	expected := []table.EntryID{1, 4, 6, 1002}
+	for i := range expected {
+		if server[i] != expected[i] || client[i] != expected[i] {
+			t.Errorf("ID %d: server %d, client %d, expected %d", i, server[i], client[i], expected[i])
+		}
+	}
+}